@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"math"
 	"math/rand"
 
@@ -30,17 +31,24 @@ func main() {
 		EnableLogger:  true,
 	}
 
-	gaInstance.Initialize(populationSize, initializeGenotype, evaluatePhenotype)
-	gaInstance.Evolve(evaluatePhenotype)
+	if err := gaInstance.Initialize(populationSize, initializeGenotype, evaluatePhenotype); err != nil {
+		log.Fatalf("Failed to initialize the population: %v", err)
+	}
+	bestIndividual, evaluationErrors, err := gaInstance.Evolve(evaluatePhenotype)
+	if err != nil {
+		log.Fatalf("Failed to evolve the population: %v", err)
+	}
+	for _, evaluationError := range evaluationErrors {
+		log.Printf("Evaluation failed: %v", evaluationError)
+	}
 
-	bestIndividual := findBestIndividual(gaInstance.Population)
 	bestX := decodeGenotype(bestIndividual.Genotype)
 
 	fmt.Printf("Best x: %f, Fitness: %f\n", bestX, bestIndividual.Phenotype.Fitness)
 }
 
 func initializeGenotype() *ga.Genotype {
-	genotype := ga.NewGenotype(genomeLength)
+	genotype := ga.MustNewGenotype(genomeLength)
 	for i := range genotype.Genome {
 		genotype.Genome[i] = byte(rand.Intn(2))
 	}
@@ -60,13 +68,3 @@ func decodeGenotype(genotype *ga.Genotype) float64 {
 	}
 	return lowerBound + (upperBound-lowerBound)*float64(value)/float64((1<<genomeLength)-1)
 }
-
-func findBestIndividual(population []*ga.Individual) *ga.Individual {
-	best := population[0]
-	for _, ind := range population {
-		if ind.Phenotype.Fitness > best.Phenotype.Fitness {
-			best = ind
-		}
-	}
-	return best
-}
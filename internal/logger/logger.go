@@ -1,21 +1,123 @@
 package logger
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"sort"
+	"sync"
+	"time"
 )
 
+// ContextKey is the type of the context keys read by Logger.WithContext.
+type ContextKey string
+
+// RequestIDKey is the context key of the request ID that Logger.WithContext attaches to every
+// log line as request_id.
+const RequestIDKey ContextKey = "ga_request_id"
+
 type Logger struct {
-	logger *slog.Logger
+	logger  *slog.Logger
+	closers []io.Closer
+}
+
+// LoggerOption configures the output of a Logger created by NewLoggerWithOptions.
+type LoggerOption func(*loggerConfig) error
+
+type loggerConfig struct {
+	writers []io.Writer
+	closers []io.Closer
 }
 
 func NewLogger(enabled bool) *Logger {
 	if !enabled {
 		return nil
 	}
+	return NewLoggerWithWriter(os.Stdout)
+}
+
+func NewLoggerWithWriter(w io.Writer) *Logger {
 	return &Logger{
-		logger: slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		logger: slog.New(slog.NewTextHandler(w, nil)),
+	}
+}
+
+// NewLoggerWithOptions creates a Logger writing to the destinations configured by the options,
+// or to os.Stdout if no option configures a destination.
+func NewLoggerWithOptions(opts ...LoggerOption) (*Logger, error) {
+	cfg := &loggerConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			for _, c := range cfg.closers {
+				c.Close()
+			}
+			return nil, err
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if len(cfg.writers) == 1 {
+		w = cfg.writers[0]
+	} else if len(cfg.writers) > 1 {
+		w = io.MultiWriter(cfg.writers...)
+	}
+	l := NewLoggerWithWriter(w)
+	l.closers = cfg.closers
+	return l, nil
+}
+
+// WithFileOutput writes the log to the file at path, creating it if absent and appending to it
+// otherwise. If maxSizeMB is positive, the file is rotated whenever a write would make it exceed
+// maxSizeMB megabytes: it is renamed to path.1, replacing any previous rotation, and a fresh file
+// is opened at path.
+func WithFileOutput(path string, maxSizeMB int) LoggerOption {
+	return func(cfg *loggerConfig) error {
+		f, err := newRotatingFile(path, int64(maxSizeMB)<<20)
+		if err != nil {
+			return err
+		}
+		cfg.writers = append(cfg.writers, f)
+		cfg.closers = append(cfg.closers, f)
+		return nil
+	}
+}
+
+// WithMultiWriter additionally writes the log to each of the given writers.
+func WithMultiWriter(writers ...io.Writer) LoggerOption {
+	return func(cfg *loggerConfig) error {
+		cfg.writers = append(cfg.writers, writers...)
+		return nil
+	}
+}
+
+// Close closes the files opened by WithFileOutput.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	var errs []error
+	for _, c := range l.closers {
+		errs = append(errs, c.Close())
+	}
+	l.closers = nil
+	return errors.Join(errs...)
+}
+
+// WithContext returns a logger that attaches the request ID stored in ctx under RequestIDKey
+// to every log line as request_id, or l itself if ctx holds no request ID. The returned logger
+// writes to the same destinations as l, which remain owned by l.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if l == nil || l.logger == nil {
+		return l
+	}
+	requestID := ctx.Value(RequestIDKey)
+	if requestID == nil {
+		return l
 	}
+	return &Logger{logger: l.logger.With("request_id", requestID)}
 }
 
 func (l *Logger) Log(msg string, key string, value interface{}) {
@@ -23,3 +125,87 @@ func (l *Logger) Log(msg string, key string, value interface{}) {
 		l.logger.Info(msg, key, value)
 	}
 }
+
+func (l *Logger) Warn(msg string, key string, value interface{}) {
+	if l != nil && l.logger != nil {
+		l.logger.Warn(msg, key, value)
+	}
+}
+
+func (l *Logger) LogOperatorTimings(timings map[string]time.Duration) {
+	if l == nil || l.logger == nil {
+		return
+	}
+	phases := make([]string, 0, len(timings))
+	for phase := range timings {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	args := make([]any, 0, 2*len(phases))
+	for _, phase := range phases {
+		args = append(args, phase, timings[phase])
+	}
+	l.logger.Info("Operator timings", args...)
+}
+
+// rotatingFile is an io.WriteCloser writing to a file that is rotated when it exceeds maxBytes.
+// A maxBytes of zero disables rotation.
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+func newRotatingFile(path string, maxBytes int64) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxBytes: maxBytes}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+	r.file, r.size = f, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.maxBytes > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ga.log")
+	l, err := NewLoggerWithOptions(WithFileOutput(path, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.Log(fmt.Sprintf("Generation %d", i), "BestFitness", i)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Expected no error closing the logger, but got %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the log file to exist, but got %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if !strings.Contains(string(content), fmt.Sprintf("Generation %d", i)) {
+			t.Errorf("Expected the log file to contain message %d, but got %q", i, content)
+		}
+	}
+}
+
+func TestWithFileOutputRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ga.log")
+	l, err := NewLoggerWithOptions(WithFileOutput(path, 1))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	defer l.Close()
+	// A megabyte is too large to exceed in a test, so shrink the limit to a few messages.
+	rotating, ok := l.closers[0].(*rotatingFile)
+	if !ok {
+		t.Fatalf("Expected a rotating file, but got %T", l.closers[0])
+	}
+	rotating.maxBytes = 200
+
+	for i := 0; i < 10; i++ {
+		l.Log(fmt.Sprintf("Generation %d", i), "BestFitness", i)
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the log file to exist, but got %v", err)
+	}
+	rotated, err := os.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("Expected the rotated log file to exist, but got %v", err)
+	}
+	if len(current) > 200 || len(rotated) > 200 {
+		t.Errorf("Expected log files of at most %d bytes, but got %d and %d", 200, len(current), len(rotated))
+	}
+	if !strings.Contains(string(current), "Generation 9") {
+		t.Errorf("Expected the log file to contain the latest message, but got %q", current)
+	}
+}
+
+func TestWithMultiWriter(t *testing.T) {
+	var first, second bytes.Buffer
+	path := filepath.Join(t.TempDir(), "ga.log")
+	l, err := NewLoggerWithOptions(WithMultiWriter(&first, &second), WithFileOutput(path, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	l.Log("Generation 0", "BestFitness", 1)
+	l.Close()
+
+	content, _ := os.ReadFile(path)
+	for name, output := range map[string]string{"first": first.String(), "second": second.String(), "file": string(content)} {
+		if !strings.Contains(output, "Generation 0") {
+			t.Errorf("Expected the %s destination to contain the message, but got %q", name, output)
+		}
+	}
+}
+
+func TestWithFileOutputError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "ga.log")
+	if _, err := NewLoggerWithOptions(WithFileOutput(path, 0)); err == nil {
+		t.Errorf("Expected an error for a file in a missing directory")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{name: "with request ID", ctx: context.WithValue(context.Background(), RequestIDKey, "abc123"), expected: "request_id=abc123"},
+		{name: "without request ID", ctx: context.Background(), expected: ""},
+	}
+
+	for _, tc := range cases {
+		var output bytes.Buffer
+		l := NewLoggerWithWriter(&output).WithContext(tc.ctx)
+		l.Log("Generation 0", "BestFitness", 1.0)
+		l.Warn("Warning", "Seeds", 2)
+
+		for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+			if strings.Contains(line, "request_id=") != (tc.expected != "") || !strings.Contains(line, tc.expected) {
+				t.Errorf("%s: Expected log line to contain %q only, but got %q", tc.name, tc.expected, line)
+			}
+		}
+	}
+	if l := (*Logger)(nil).WithContext(context.Background()); l != nil {
+		t.Errorf("Expected a nil logger to stay nil, but got %v", l)
+	}
+}
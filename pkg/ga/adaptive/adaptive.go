@@ -0,0 +1,147 @@
+// Package adaptive provides adaptive operator selection for genetic algorithms, choosing
+// among several genetic operators by treating them as arms of a multi-armed bandit.
+package adaptive
+
+import (
+	"math"
+	"math/rand"
+)
+
+// AdaptiveOperatorSelector chooses which of several operators to apply, and learns from
+// the reward observed after each application.
+type AdaptiveOperatorSelector interface {
+	// Select returns the index of the operator to apply next, in [0, numOperators).
+	Select(numOperators int) int
+	// Update records the reward observed after applying the operator at the given index.
+	Update(operator int, reward float64)
+}
+
+// rewardTracker tracks the number of applications and the total reward of each operator.
+type rewardTracker struct {
+	counts  []int
+	rewards []float64
+	total   int
+}
+
+// grow ensures the tracker has room for numOperators operators.
+func (t *rewardTracker) grow(numOperators int) {
+	for len(t.counts) < numOperators {
+		t.counts = append(t.counts, 0)
+		t.rewards = append(t.rewards, 0)
+	}
+}
+
+// Update records the reward observed after applying the operator at the given index.
+func (t *rewardTracker) Update(operator int, reward float64) {
+	t.grow(operator + 1)
+	t.counts[operator]++
+	t.rewards[operator] += reward
+	t.total++
+}
+
+// Reset forgets the applications and rewards recorded so far.
+func (t *rewardTracker) Reset() {
+	*t = rewardTracker{}
+}
+
+// Counts returns the number of times each operator has been applied.
+func (t *rewardTracker) Counts() []int {
+	return append([]int(nil), t.counts...)
+}
+
+// Estimates returns the mean reward observed for each operator.
+func (t *rewardTracker) Estimates() []float64 {
+	estimates := make([]float64, len(t.counts))
+	for i := range estimates {
+		estimates[i] = t.mean(i)
+	}
+	return estimates
+}
+
+func (t *rewardTracker) mean(operator int) float64 {
+	if t.counts[operator] == 0 {
+		return 0
+	}
+	return t.rewards[operator] / float64(t.counts[operator])
+}
+
+// untried returns the index of the first operator that has never been applied, or -1.
+func (t *rewardTracker) untried(numOperators int) int {
+	for i := 0; i < numOperators; i++ {
+		if t.counts[i] == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// EpsilonGreedy is an AdaptiveOperatorSelector that applies a random operator with
+// probability Epsilon, and otherwise the operator with the highest mean reward.
+// Every operator is applied once before rewards are compared.
+type EpsilonGreedy struct {
+	rewardTracker
+	Epsilon float64
+}
+
+// NewEpsilonGreedy creates a new EpsilonGreedy selector.
+//
+// Parameters:
+// - epsilon: the probability of applying a random operator.
+//
+// Returns:
+// - A pointer to the newly created EpsilonGreedy selector.
+func NewEpsilonGreedy(epsilon float64) *EpsilonGreedy {
+	return &EpsilonGreedy{Epsilon: epsilon}
+}
+
+// Select returns the index of the operator to apply next.
+func (s *EpsilonGreedy) Select(numOperators int) int {
+	s.grow(numOperators)
+	if i := s.untried(numOperators); i >= 0 {
+		return i
+	}
+	if rand.Float64() < s.Epsilon {
+		return rand.Intn(numOperators)
+	}
+
+	best := 0
+	for i := 1; i < numOperators; i++ {
+		if s.mean(i) > s.mean(best) {
+			best = i
+		}
+	}
+	return best
+}
+
+// UCB1 is an AdaptiveOperatorSelector that applies the operator with the highest upper
+// confidence bound on its mean reward, balancing exploitation of operators with high
+// rewards against exploration of rarely applied ones. Every operator is applied once
+// before rewards are compared.
+type UCB1 struct {
+	rewardTracker
+}
+
+// NewUCB1 creates a new UCB1 selector.
+//
+// Returns:
+// - A pointer to the newly created UCB1 selector.
+func NewUCB1() *UCB1 {
+	return &UCB1{}
+}
+
+// Select returns the index of the operator to apply next.
+func (s *UCB1) Select(numOperators int) int {
+	s.grow(numOperators)
+	if i := s.untried(numOperators); i >= 0 {
+		return i
+	}
+
+	best, bestBound := 0, math.Inf(-1)
+	for i := 0; i < numOperators; i++ {
+		bound := s.mean(i) + math.Sqrt(2*math.Log(float64(s.total))/float64(s.counts[i]))
+		if bound > bestBound {
+			best, bestBound = i, bound
+		}
+	}
+	return best
+}
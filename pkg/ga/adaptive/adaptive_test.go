@@ -0,0 +1,53 @@
+package adaptive
+
+import "testing"
+
+func TestAdaptiveOperatorSelector(t *testing.T) {
+	cases := []struct {
+		name     string
+		selector interface {
+			AdaptiveOperatorSelector
+			Counts() []int
+			Estimates() []float64
+		}
+	}{
+		{name: "EpsilonGreedy", selector: NewEpsilonGreedy(0.1)},
+		{name: "UCB1", selector: NewUCB1()},
+	}
+
+	// The operator at index 1 yields ten times the reward of the others.
+	rewards := []float64{0.1, 1.0, 0.1}
+
+	for _, tc := range cases {
+		for step := 0; step < 100; step++ {
+			operator := tc.selector.Select(len(rewards))
+			if operator < 0 || operator >= len(rewards) {
+				t.Fatalf("%s: expected operator index in [0, %d), but got %d", tc.name, len(rewards), operator)
+			}
+			tc.selector.Update(operator, rewards[operator])
+		}
+
+		counts := tc.selector.Counts()
+		for i, count := range counts {
+			if i != 1 && count >= counts[1] {
+				t.Errorf("%s: expected operator 1 to be selected most often, but got counts %v", tc.name, counts)
+			}
+		}
+		if estimates := tc.selector.Estimates(); estimates[1] != rewards[1] {
+			t.Errorf("%s: expected estimate %f for operator 1, but got %f", tc.name, rewards[1], estimates[1])
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	selector := NewEpsilonGreedy(0.1)
+	selector.Update(selector.Select(3), 1.0)
+	selector.Reset()
+
+	if counts := selector.Counts(); len(counts) != 0 {
+		t.Errorf("Expected no counts after Reset, but got %v", counts)
+	}
+	if operator := selector.Select(3); operator != 0 {
+		t.Errorf("Expected the first untried operator 0 after Reset, but got %d", operator)
+	}
+}
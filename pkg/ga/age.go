@@ -0,0 +1,42 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the tracking of individual ages and age-based replacement.
+package ga
+
+// AgeBasedReplacement replaces every individual older than maxAge generations with a new one.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - maxAge: the maximum age an individual may reach.
+// - newIndividual: a function returning a new, evaluated individual.
+//
+// Returns:
+// - A new population in which every individual older than maxAge has been replaced.
+func AgeBasedReplacement(population []*Individual, maxAge int, newIndividual func() *Individual) []*Individual {
+	replaced := make([]*Individual, len(population))
+	for i, ind := range population {
+		if ind.Age > maxAge {
+			ind = newIndividual()
+		}
+		replaced[i] = ind
+	}
+	return replaced
+}
+
+// ageSurvivors increments the Age of every individual of the current population that was
+// already in the previous one. Individuals that appear several times are aged once.
+//
+// Parameters:
+// - previous: the population at the start of the generation and the elites copied from it.
+// - current: the population at the end of the generation.
+func ageSurvivors(previous, current []*Individual) {
+	survivors := make(map[*Individual]bool, len(previous))
+	for _, ind := range previous {
+		survivors[ind] = true
+	}
+	for _, ind := range current {
+		if survivors[ind] {
+			ind.Age++
+			delete(survivors, ind)
+		}
+	}
+}
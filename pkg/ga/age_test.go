@@ -0,0 +1,115 @@
+package ga
+
+import "testing"
+
+func TestAgeTracking(t *testing.T) {
+	cases := []struct {
+		name                string
+		ageBasedReplacement bool
+		maxAge              int
+		expectedOldest      int
+	}{
+		{name: "without replacement", ageBasedReplacement: false, maxAge: 2, expectedOldest: 10},
+		// Ages cycle through 1, 2, 3 -> replaced, so after 10 generations they are back at 1.
+		{name: "with replacement", ageBasedReplacement: true, maxAge: 2, expectedOldest: 1},
+	}
+
+	for _, tc := range cases {
+		// Without crossover, every individual survives each generation.
+		ga := &GA{
+			Selection:           func(population []*Individual) []*Individual { return population },
+			Crossover:           SinglePointCrossover,
+			Mutation:            BitFlipMutation,
+			CrossoverRate:       0.0,
+			MutationRate:        0.01,
+			Generations:         10,
+			MaxAge:              tc.maxAge,
+			AgeBasedReplacement: tc.ageBasedReplacement,
+		}
+		ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+		ga.Evolve(oneMax)
+
+		oldest := 0
+		for _, ind := range ga.Population {
+			oldest = max(oldest, ind.Age)
+		}
+		if oldest != tc.expectedOldest {
+			t.Errorf("%s: Expected the oldest individual to have Age %d, but got %d", tc.name, tc.expectedOldest, oldest)
+		}
+	}
+}
+
+func TestOffspringAge(t *testing.T) {
+	ga := &GA{
+		Selection:     func(population []*Individual) []*Individual { return population },
+		Crossover:     SinglePointCrossover,
+		Mutation:      BitFlipMutation,
+		CrossoverRate: 1.0,
+		Generations:   5,
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+	ga.Evolve(oneMax)
+
+	for i, ind := range ga.Population {
+		if ind.Age != 0 {
+			t.Errorf("Expected offspring %d to have Age 0, but got %d", i, ind.Age)
+		}
+	}
+}
+
+func TestAgeBasedReplacement(t *testing.T) {
+	population := []*Individual{{Age: 0}, {Age: 3}, {Age: 2}, {Age: 5}}
+	created := 0
+	newIndividual := func() *Individual {
+		created++
+		return &Individual{}
+	}
+
+	replaced := AgeBasedReplacement(population, 2, newIndividual)
+
+	if created != 2 {
+		t.Errorf("Expected %d new individuals, but got %d", 2, created)
+	}
+	for i, ind := range replaced {
+		if ind.Age > 2 {
+			t.Errorf("Expected individual %d to have Age at most %d, but got %d", i, 2, ind.Age)
+		}
+	}
+	if replaced[0] != population[0] || replaced[2] != population[2] {
+		t.Errorf("Expected young individuals to be kept")
+	}
+}
+
+func TestEliteAge(t *testing.T) {
+	const generations = 5
+	// Every offspring is worse than the initial individuals, so the elite survives throughout.
+	ga := &GA{
+		Selection: func(population []*Individual) []*Individual { return population },
+		Crossover: func(population []*Individual, _ float64) []*Individual {
+			offspring := make([]*Individual, len(population))
+			for i := range offspring {
+				offspring[i] = &Individual{Genotype: MustNewGenotype(8)}
+			}
+			return offspring
+		},
+		Mutation:     BitFlipMutation,
+		Generations:  generations,
+		ElitismCount: 1,
+	}
+	ga.Initialize(10, func() *Genotype {
+		genotype := MustNewGenotype(8)
+		for i := range genotype.Genome {
+			genotype.Genome[i] = 1
+		}
+		return genotype
+	}, oneMax)
+	ga.Evolve(oneMax)
+
+	oldest := 0
+	for _, ind := range ga.Population {
+		oldest = max(oldest, ind.Age)
+	}
+	if oldest != generations {
+		t.Errorf("Expected the elite to have Age %d, but got %d", generations, oldest)
+	}
+}
@@ -0,0 +1,36 @@
+// Package analysis provides tools for analyzing the behavior of genetic algorithms,
+// including the distribution of the crossover points used during evolution.
+package analysis
+
+// CrossoverPointFrequency returns the relative frequency of each crossover point recorded in
+// the history, such as GA.CrossoverPointHistory, over all generations.
+//
+// Parameters:
+// - history: the crossover points used in each generation.
+//
+// Returns:
+// - The fraction of the recorded points at each position, from 0 to the largest point, which
+// sum to 1, or nil if no point was recorded.
+func CrossoverPointFrequency(history [][]int) []float64 {
+	length, total := 0, 0
+	for _, points := range history {
+		for _, point := range points {
+			length = max(length, point+1)
+		}
+		total += len(points)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	frequencies := make([]float64, length)
+	for _, points := range history {
+		for _, point := range points {
+			frequencies[point]++
+		}
+	}
+	for i := range frequencies {
+		frequencies[i] /= float64(total)
+	}
+	return frequencies
+}
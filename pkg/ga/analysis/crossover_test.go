@@ -0,0 +1,55 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func TestCrossoverPointFrequency(t *testing.T) {
+	const genomeLength = 16
+	oneMax := func(genotype *ga.Genotype) *ga.Phenotype {
+		fitness := 0.0
+		for _, gene := range genotype.Genome {
+			fitness += float64(gene)
+		}
+		return &ga.Phenotype{Fitness: fitness}
+	}
+
+	g := &ga.GA{
+		Selection:            func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 2) },
+		Mutation:             ga.BitFlipMutation,
+		CrossoverRate:        0.7,
+		MutationRate:         0.05,
+		Generations:          100,
+		TrackCrossoverPoints: true,
+	}
+	g.Crossover = g.SinglePointCrossover()
+	g.Initialize(20, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(genomeLength, genomeLength) }, oneMax)
+	g.Evolve(oneMax)
+
+	if len(g.CrossoverPointHistory) != g.Generations {
+		t.Fatalf("Expected crossover points for %d generations, but got %d", g.Generations, len(g.CrossoverPointHistory))
+	}
+	frequencies := CrossoverPointFrequency(g.CrossoverPointHistory)
+	if len(frequencies) != genomeLength {
+		t.Fatalf("Expected frequencies for %d positions, but got %d", genomeLength, len(frequencies))
+	}
+	total := 0.0
+	for i, frequency := range frequencies {
+		if frequency <= 0 {
+			t.Errorf("Expected a non-zero frequency at position %d, but got %f", i, frequency)
+		}
+		total += frequency
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected frequencies to sum to 1, but got %f", total)
+	}
+}
+
+func TestCrossoverPointFrequencyEmpty(t *testing.T) {
+	if frequencies := CrossoverPointFrequency([][]int{nil, {}}); frequencies != nil {
+		t.Errorf("Expected no frequencies without crossover points, but got %v", frequencies)
+	}
+}
@@ -0,0 +1,50 @@
+// Package analysis provides tools for analyzing the behavior of genetic algorithms,
+// including the export of the genealogy of individuals as a graph.
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// ExportGenealogyDOT returns the genealogy of the given individuals as a directed graph in the
+// DOT language, with a node for each individual and an edge from each parent to its offspring.
+//
+// Individuals are identified by their ID, so duplicates are listed once. Parent IDs of zero,
+// which mean an individual has no recorded parents, produce no edges. Parents that are not
+// among the individuals still appear as edge endpoints.
+//
+// Parameters:
+// - individuals: the individuals to include, for example all individuals of every generation.
+//
+// Returns:
+// - The DOT representation of the genealogy.
+func ExportGenealogyDOT(individuals []*ga.Individual) string {
+	byID := make(map[int64]*ga.Individual, len(individuals))
+	for _, ind := range individuals {
+		byID[ind.ID] = ind
+	}
+	ids := make([]int64, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var b strings.Builder
+	b.WriteString("digraph genealogy {\n")
+	for _, id := range ids {
+		fmt.Fprintf(&b, "  %d;\n", id)
+	}
+	for _, id := range ids {
+		for _, parent := range byID[id].ParentIDs {
+			if parent != 0 {
+				fmt.Fprintf(&b, "  %d -> %d;\n", parent, id)
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func TestExportGenealogyDOT(t *testing.T) {
+	var individuals []*ga.Individual
+	g := &ga.GA{
+		Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 2) },
+		Crossover:     ga.SinglePointCrossover,
+		Mutation:      ga.BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.01,
+		Generations:   5,
+		OnGeneration: func(_ int, population []*ga.Individual) {
+			individuals = append(individuals, population...)
+		},
+	}
+	oneMax := func(genotype *ga.Genotype) *ga.Phenotype {
+		fitness := 0.0
+		for _, gene := range genotype.Genome {
+			fitness += float64(gene)
+		}
+		return &ga.Phenotype{Fitness: fitness}
+	}
+	g.Initialize(10, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+	initial := make(map[int64]bool, len(g.Population))
+	for _, ind := range g.Population {
+		initial[ind.ID] = true
+	}
+	individuals = append(individuals, g.Population...)
+	g.Evolve(oneMax)
+
+	dot := ExportGenealogyDOT(individuals)
+
+	if !strings.HasPrefix(dot, "digraph genealogy {") || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("Expected a DOT digraph, but got %q", dot)
+	}
+	offspring := 0
+	for _, ind := range individuals {
+		if ind.ID == 0 {
+			t.Fatalf("Expected every individual to have an ID")
+		}
+		if !strings.Contains(dot, fmt.Sprintf("  %d;\n", ind.ID)) {
+			t.Errorf("Expected the DOT graph to contain individual %d", ind.ID)
+		}
+		if initial[ind.ID] {
+			continue
+		}
+		offspring++
+		edges := strings.Count(dot, fmt.Sprintf(" -> %d;\n", ind.ID))
+		if edges != 2 {
+			t.Errorf("Expected individual %d to have %d parent edges, but got %d", ind.ID, 2, edges)
+		}
+	}
+	if offspring == 0 {
+		t.Errorf("Expected some offspring to be created")
+	}
+}
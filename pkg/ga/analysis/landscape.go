@@ -0,0 +1,59 @@
+// Package analysis provides tools for analyzing the behavior of genetic algorithms,
+// including measures of the ruggedness of fitness landscapes.
+package analysis
+
+import (
+	"math/rand"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// RandomWalkCorrelation measures the ruggedness of a binary fitness landscape by the
+// autocorrelation of the fitness values along a random walk.
+//
+// Starting from a copy of startGenotype, the walk flips one random bit per step, recording
+// the fitness before the first step and after each one. The result is the autocorrelation
+// at lag 1 of the recorded fitness values, which lies in [-1, 1]: values near 1 indicate a
+// smooth landscape, in which neighbors have similar fitness, and values near 0 a rugged one.
+// A walk along which the fitness never changes is perfectly smooth and yields 1.
+//
+// Parameters:
+// - evalFunc: a function to evaluate a Genotype and return its Phenotype.
+// - startGenotype: the binary genotype to start the walk from, which is not modified.
+// - steps: the number of bit flips to perform.
+// - rng: the source of randomness for choosing the bits to flip.
+//
+// Returns:
+// - The lag-1 autocorrelation of the fitness values along the walk.
+func RandomWalkCorrelation(evalFunc func(*ga.Genotype) *ga.Phenotype, startGenotype *ga.Genotype, steps int, rng *rand.Rand) float64 {
+	genotype := startGenotype.Clone()
+	fitness := make([]float64, 0, steps+1)
+	fitness = append(fitness, evalFunc(genotype).Fitness)
+	for i := 0; i < steps && len(genotype.Genome) > 0; i++ {
+		genotype.Genome[rng.Intn(len(genotype.Genome))] ^= 1
+		fitness = append(fitness, evalFunc(genotype).Fitness)
+	}
+	return lagOneAutocorrelation(fitness)
+}
+
+// lagOneAutocorrelation returns the autocorrelation at lag 1 of the given series, or 1 if
+// the series has fewer than two values or no variance.
+func lagOneAutocorrelation(series []float64) float64 {
+	mean := 0.0
+	for _, value := range series {
+		mean += value
+	}
+	mean /= float64(len(series))
+
+	covariance, variance := 0.0, 0.0
+	for i, value := range series {
+		variance += (value - mean) * (value - mean)
+		if i > 0 {
+			covariance += (series[i-1] - mean) * (value - mean)
+		}
+	}
+	if len(series) < 2 || variance == 0 {
+		return 1
+	}
+	return covariance / variance
+}
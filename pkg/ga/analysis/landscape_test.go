@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func TestRandomWalkCorrelation(t *testing.T) {
+	oneMax := func(genotype *ga.Genotype) *ga.Phenotype {
+		fitness := 0.0
+		for _, gene := range genotype.Genome {
+			fitness += float64(gene)
+		}
+		return &ga.Phenotype{Fitness: fitness}
+	}
+	// rugged assigns unrelated pseudo-random fitness values to neighboring genomes.
+	rugged := func(genotype *ga.Genotype) *ga.Phenotype {
+		hash := uint32(2166136261)
+		for _, gene := range genotype.Genome {
+			hash = (hash ^ uint32(gene)) * 16777619
+		}
+		return &ga.Phenotype{Fitness: float64(hash % 1000)}
+	}
+
+	cases := []struct {
+		name        string
+		evalFunc    func(*ga.Genotype) *ga.Phenotype
+		minExpected float64
+		maxExpected float64
+	}{
+		{name: "OneMax", evalFunc: oneMax, minExpected: 0.5, maxExpected: 1},
+		{name: "rugged", evalFunc: rugged, minExpected: -0.3, maxExpected: 0.3},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range cases {
+		start := ga.MustVariableLengthBinaryGenotype(32, 32)
+		genome := append([]byte(nil), start.Genome...)
+
+		correlation := RandomWalkCorrelation(tc.evalFunc, start, 1000, rng)
+
+		if correlation < -1 || correlation > 1 {
+			t.Fatalf("%s: Expected a correlation in [-1, 1], but got %f", tc.name, correlation)
+		}
+		if correlation < tc.minExpected || correlation > tc.maxExpected {
+			t.Errorf("%s: Expected a correlation in [%.1f, %.1f], but got %f", tc.name, tc.minExpected, tc.maxExpected, correlation)
+		}
+		if string(start.Genome) != string(genome) {
+			t.Errorf("%s: Expected the start genotype to be unchanged", tc.name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package analysis provides tools for analyzing the behavior of genetic algorithms,
+// such as tracking the propagation of schemata through the generations.
+package analysis
+
+import "github.com/Okabe-Junya/gago/pkg/ga"
+
+// SchemaTracker records the proportion of the population matching each of a set of schemata
+// at every generation. It implements ga.SchemaRecorder, so it can be set as the GA's
+// SchemaTracker, and its OnGeneration method can also be used as the GA's OnGeneration hook.
+type SchemaTracker struct {
+	Patterns []string
+	// History holds, for each recorded generation, the proportion of individuals matching
+	// each pattern, in the order of Patterns.
+	History [][]float64
+}
+
+// NewSchemaTracker creates a new SchemaTracker for the given schemata.
+//
+// Parameters:
+// - patterns: the schemata to track, as described by TrackSchemas.
+//
+// Returns:
+// - A pointer to the newly created SchemaTracker.
+func NewSchemaTracker(patterns ...string) *SchemaTracker {
+	return &SchemaTracker{Patterns: patterns}
+}
+
+// OnGeneration records the proportion of the population matching each pattern.
+//
+// Parameters:
+// - generation: the zero-based generation number, unused.
+// - population: the population at the end of the generation.
+func (s *SchemaTracker) OnGeneration(_ int, population []*ga.Individual) {
+	s.History = append(s.History, TrackSchemas(population, s.Patterns))
+}
+
+// TrackSchemas computes the proportion of a binary-encoded population matching each schema.
+//
+// A schema is a string of '0', '1', and '#' of the same length as the genome, where '#' matches
+// either gene value. Genomes of a different length do not match.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - patterns: the schemata to match.
+//
+// Returns:
+// - The proportion of individuals matching each pattern, in the order of patterns.
+func TrackSchemas(population []*ga.Individual, patterns []string) []float64 {
+	proportions := make([]float64, len(patterns))
+	if len(population) == 0 {
+		return proportions
+	}
+	for k, pattern := range patterns {
+		matches := 0
+		for _, ind := range population {
+			if matchesSchema(pattern, ind.Genotype.Genome) {
+				matches++
+			}
+		}
+		proportions[k] = float64(matches) / float64(len(population))
+	}
+	return proportions
+}
+
+// SchemaFitness computes the mean fitness of the individuals matching a schema.
+//
+// Parameters:
+// - schema: the schema to match, as described by TrackSchemas.
+// - population: a slice of pointers to Individual, representing the current population.
+//
+// Returns:
+// - The mean fitness of the matching individuals, or 0 if no individual matches.
+func SchemaFitness(schema string, population []*ga.Individual) float64 {
+	total, matches := 0.0, 0
+	for _, ind := range population {
+		if matchesSchema(schema, ind.Genotype.Genome) {
+			total += ind.Phenotype.Fitness
+			matches++
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	return total / float64(matches)
+}
+
+// matchesSchema reports whether the genome is an instance of the schema.
+func matchesSchema(schema string, genome []byte) bool {
+	if len(schema) != len(genome) {
+		return false
+	}
+	for i := 0; i < len(schema); i++ {
+		switch schema[i] {
+		case '#':
+		case '0', '1':
+			if genome[i] != schema[i]-'0' {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
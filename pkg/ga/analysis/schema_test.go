@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func newIndividual(genome []byte, fitness float64) *ga.Individual {
+	return &ga.Individual{Genotype: &ga.Genotype{Genome: genome}, Phenotype: &ga.Phenotype{Fitness: fitness}}
+}
+
+func TestTrackSchemas(t *testing.T) {
+	allOnes := []*ga.Individual{
+		newIndividual([]byte{1, 1, 1, 1}, 4),
+		newIndividual([]byte{1, 1, 1, 1}, 4),
+	}
+	mixed := []*ga.Individual{
+		newIndividual([]byte{1, 1, 0, 0}, 2),
+		newIndividual([]byte{1, 0, 1, 0}, 2),
+		newIndividual([]byte{0, 0, 0, 1}, 1),
+		newIndividual([]byte{1, 1, 1, 1}, 4),
+	}
+
+	cases := []struct {
+		population []*ga.Individual
+		patterns   []string
+		expected   []float64
+	}{
+		{population: allOnes, patterns: []string{"1111", "####", "0###", "111"}, expected: []float64{1, 1, 0, 0}},
+		{population: mixed, patterns: []string{"1###", "11##", "###1", "1#1#"}, expected: []float64{0.75, 0.5, 0.5, 0.5}},
+		{population: nil, patterns: []string{"1111"}, expected: []float64{0}},
+	}
+
+	for _, tc := range cases {
+		proportions := TrackSchemas(tc.population, tc.patterns)
+		for k, expected := range tc.expected {
+			if proportions[k] != expected {
+				t.Errorf("Expected proportion %f for schema %q, but got %f", expected, tc.patterns[k], proportions[k])
+			}
+		}
+	}
+}
+
+func TestSchemaFitness(t *testing.T) {
+	population := []*ga.Individual{
+		newIndividual([]byte{1, 1, 0, 0}, 2),
+		newIndividual([]byte{1, 0, 1, 0}, 2),
+		newIndividual([]byte{0, 0, 0, 1}, 1),
+		newIndividual([]byte{1, 1, 1, 1}, 4),
+	}
+
+	cases := []struct {
+		schema   string
+		expected float64
+	}{
+		{schema: "1###", expected: 8.0 / 3.0},
+		{schema: "11##", expected: 3},
+		{schema: "0000", expected: 0},
+	}
+
+	for _, tc := range cases {
+		if got := SchemaFitness(tc.schema, population); got != tc.expected {
+			t.Errorf("Expected fitness %f for schema %q, but got %f", tc.expected, tc.schema, got)
+		}
+	}
+}
+
+func TestSchemaTrackerOnGeneration(t *testing.T) {
+	tracker := NewSchemaTracker("1#", "#1")
+	g := &ga.GA{
+		Selection:    func(population []*ga.Individual) []*ga.Individual { return population },
+		Crossover:    ga.SinglePointCrossover,
+		Mutation:     ga.BitFlipMutation,
+		Generations:  5,
+		OnGeneration: tracker.OnGeneration,
+	}
+	oneMax := func(genotype *ga.Genotype) *ga.Phenotype {
+		return &ga.Phenotype{Fitness: float64(genotype.Genome[0] + genotype.Genome[1])}
+	}
+	g.Initialize(10, func() *ga.Genotype { return &ga.Genotype{Genome: []byte{1, 1}} }, oneMax)
+	g.Evolve(oneMax)
+
+	if len(tracker.History) != g.Generations {
+		t.Fatalf("Expected %d recorded generations, but got %d", g.Generations, len(tracker.History))
+	}
+	for gen, proportions := range tracker.History {
+		if proportions[0] != 1 || proportions[1] != 1 {
+			t.Errorf("Expected all individuals to match at generation %d, but got %v", gen, proportions)
+		}
+	}
+}
+
+func TestSchemaTrackerEvolve(t *testing.T) {
+	const generations = 15
+	patterns := []string{"11111111", "1#######", "0#######"}
+	tracker := NewSchemaTracker(patterns...)
+	g := &ga.GA{
+		Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 3) },
+		Crossover:     ga.UniformCrossover,
+		Mutation:      ga.BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.01,
+		Generations:   generations,
+		SchemaTracker: tracker,
+	}
+	oneMax := func(genotype *ga.Genotype) *ga.Phenotype {
+		fitness := 0.0
+		for _, gene := range genotype.Genome {
+			fitness += float64(gene)
+		}
+		return &ga.Phenotype{Fitness: fitness}
+	}
+	if err := g.Initialize(40, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) }, oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if _, _, err := g.Evolve(oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if len(tracker.History) != generations {
+		t.Fatalf("Expected %d recorded generations, but got %d", generations, len(tracker.History))
+	}
+	for gen, proportions := range tracker.History {
+		if sum := proportions[1] + proportions[2]; sum < 1-1e-9 || sum > 1+1e-9 {
+			t.Errorf("Expected the complementary schemata to cover the population in generation %d, but got %v", gen, proportions)
+		}
+	}
+	last := tracker.History[generations-1]
+	expected := TrackSchemas(g.Population, patterns)
+	for k := range patterns {
+		if last[k] != expected[k] {
+			t.Errorf("Expected the last recorded proportion of %q to be %f, but got %f", patterns[k], expected[k], last[k])
+		}
+	}
+	if first := tracker.History[0][0]; last[0] <= first {
+		t.Errorf("Expected the optimal schema to spread from %f, but got %f", first, last[0])
+	}
+}
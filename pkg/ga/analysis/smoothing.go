@@ -0,0 +1,43 @@
+// Package analysis provides tools for analyzing the behavior of genetic algorithms,
+// including the smoothing of convergence curves recorded on noisy fitness landscapes.
+package analysis
+
+import "github.com/Okabe-Junya/gago/pkg/ga"
+
+// SmoothHistory smooths a history of statistics, such as the result of GA.GetHistory, with a
+// trailing sliding-window average over BestFitness, AverageFitness, and Diversity: each entry
+// is averaged with the entries of up to window-1 preceding generations. On a noisy fitness
+// landscape, the best fitness can worsen from one generation to the next even while the GA
+// makes progress, and the smoothed curve shows the trend instead. Only the entries before an
+// entry are used, so that the smoothed history of a run in progress does not change as later
+// generations are recorded.
+//
+// Parameters:
+// - history: the statistics of each generation, oldest first.
+// - window: the number of generations to average over; values below 2 leave the curve as is.
+//
+// Returns:
+// - New statistics, one per entry of the history, with the smoothed fields and the other
+// fields of the original entry.
+func SmoothHistory(history []*ga.Statistics, window int) []*ga.Statistics {
+	window = max(window, 1)
+	smoothed := make([]*ga.Statistics, len(history))
+	var best, average, diversity float64
+	for i, stats := range history {
+		best += stats.BestFitness
+		average += stats.AverageFitness
+		diversity += stats.Diversity
+		if i >= window {
+			best -= history[i-window].BestFitness
+			average -= history[i-window].AverageFitness
+			diversity -= history[i-window].Diversity
+		}
+		n := float64(min(i+1, window))
+		entry := *stats
+		entry.BestFitness = best / n
+		entry.AverageFitness = average / n
+		entry.Diversity = diversity / n
+		smoothed[i] = &entry
+	}
+	return smoothed
+}
@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// roughness returns the mean absolute change of the best fitness between generations.
+func roughness(history []*ga.Statistics) float64 {
+	total := 0.0
+	for i := 1; i < len(history); i++ {
+		total += math.Abs(history[i].BestFitness - history[i-1].BestFitness)
+	}
+	return total / float64(len(history)-1)
+}
+
+func TestSmoothHistory(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	history := make([]*ga.Statistics, 100)
+	for i := range history {
+		trend := float64(i) / 10
+		history[i] = &ga.Statistics{
+			PopulationSize: 20,
+			BestFitness:    trend + rng.NormFloat64(),
+			AverageFitness: trend / 2,
+			Diversity:      1,
+		}
+	}
+
+	original := history[50].BestFitness
+	smoothed := SmoothHistory(history, 5)
+
+	if len(smoothed) != len(history) {
+		t.Fatalf("Expected %d entries, but got %d", len(history), len(smoothed))
+	}
+	if raw, smooth := roughness(history), roughness(smoothed); smooth > raw/2 {
+		t.Errorf("Expected the smoothed best fitness to change less than half as much between generations as %f, but got %f", raw, smooth)
+	}
+	decreases := func(history []*ga.Statistics) int {
+		count := 0
+		for i := 1; i < len(history); i++ {
+			if history[i].BestFitness < history[i-1].BestFitness {
+				count++
+			}
+		}
+		return count
+	}
+	if raw, smooth := decreases(history), decreases(smoothed); smooth >= raw {
+		t.Errorf("Expected fewer decreases of the best fitness than %d after smoothing, but got %d", raw, smooth)
+	}
+
+	cases := []struct {
+		index    int
+		expected float64
+	}{
+		{index: 0, expected: history[0].BestFitness},
+		{index: 2, expected: (history[0].BestFitness + history[1].BestFitness + history[2].BestFitness) / 3},
+		{index: 50, expected: (history[46].BestFitness + history[47].BestFitness + history[48].BestFitness + history[49].BestFitness + history[50].BestFitness) / 5},
+	}
+	for _, tc := range cases {
+		if got := smoothed[tc.index].BestFitness; math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("Expected smoothed best fitness %f at %d, but got %f", tc.expected, tc.index, got)
+		}
+		if smoothed[tc.index].Diversity != 1 || smoothed[tc.index].PopulationSize != 20 {
+			t.Errorf("Expected constant fields to be kept at %d, but got %+v", tc.index, smoothed[tc.index])
+		}
+	}
+	if history[50].BestFitness != original {
+		t.Errorf("Expected the original history not to be modified, but best fitness %f became %f", original, history[50].BestFitness)
+	}
+}
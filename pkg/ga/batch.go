@@ -0,0 +1,120 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the evaluation of a whole population in a single batch.
+package ga
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// BatchEvaluator evaluates many genotypes at once, for fitness functions that are more
+// efficient on batches than on single genotypes, such as those run on a GPU or vectorized.
+type BatchEvaluator interface {
+	// EvaluateBatch returns the phenotypes of the genotypes, in the same order.
+	EvaluateBatch(genotypes []*Genotype) []*Phenotype
+}
+
+// BatchEvaluatorFunc adapts an ordinary function to the BatchEvaluator interface.
+type BatchEvaluatorFunc func(genotypes []*Genotype) []*Phenotype
+
+// EvaluateBatch calls f(genotypes).
+func (f BatchEvaluatorFunc) EvaluateBatch(genotypes []*Genotype) []*Phenotype {
+	return f(genotypes)
+}
+
+// evaluateBatch evaluates the population with the BatchEvaluator. Each distinct individual
+// is evaluated once, phenotypes found in the Cache or Surrogate are not sent to the batch, and
+// individuals that already have a Phenotype are kept as they are once the evaluation budget
+// is exhausted. If the batch evaluation panics or returns the wrong number of phenotypes,
+// every individual of the batch is given the worst finite fitness.
+//
+// Returns:
+// - The errors of the batch evaluation, one per failed individual, ordered by individual index.
+func (ga *GA) evaluateBatch() []EvaluationError {
+	var indices []int
+	var genotypes []*Genotype
+	var keys [][]byte
+	visited := make(map[*Individual]bool, len(ga.Population))
+	for i, ind := range ga.Population {
+		if visited[ind] {
+			continue
+		}
+		visited[ind] = true
+		if ind.Phenotype != nil && !ga.withinBudget(len(indices)+1) {
+			continue
+		}
+		var key []byte
+		if ga.Cache != nil || ga.Surrogate != nil {
+			key = ind.Genotype.key()
+			if phenotype, ok := ga.lookup(key); ok {
+				ind.Phenotype = phenotype
+				ga.penalize(ind)
+				continue
+			}
+		}
+		indices = append(indices, i)
+		genotypes = append(genotypes, ind.Genotype)
+		keys = append(keys, key)
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	atomic.AddInt64(&ga.EvaluationsUsed, int64(len(indices)))
+	phenotypes, cause := ga.callBatchEvaluator(genotypes)
+	if cause != nil {
+		evaluationErrors := make([]EvaluationError, len(indices))
+		for j, i := range indices {
+			ga.Population[i].Phenotype = &Phenotype{Fitness: ga.worstFitness()}
+			evaluationErrors[j] = EvaluationError{IndividualIndex: i, Cause: cause}
+		}
+		return evaluationErrors
+	}
+	for j, i := range indices {
+		ind := ga.Population[i]
+		ind.Phenotype = phenotypes[j]
+		if keys[j] != nil {
+			ga.store(keys[j], ind.Phenotype)
+		}
+		ga.penalize(ind)
+	}
+	return nil
+}
+
+// callBatchEvaluator calls the BatchEvaluator, recovering from panics. If EvalRepeats is
+// greater than 1, the batch is evaluated EvalRepeats times and the phenotypes of each genotype
+// are averaged as by NoisyEval.
+//
+// Parameters:
+// - genotypes: the genotypes to evaluate.
+//
+// Returns:
+// - The phenotypes of the genotypes.
+// - The cause of the failure if the evaluation panicked or returned the wrong number of
+// phenotypes, or nil otherwise.
+func (ga *GA) callBatchEvaluator(genotypes []*Genotype) (phenotypes []*Phenotype, cause interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			phenotypes, cause = nil, r
+		}
+	}()
+	repeats := max(ga.EvalRepeats, 1)
+	evaluations := make([][]*Phenotype, len(genotypes))
+	for r := 0; r < repeats; r++ {
+		batch := ga.BatchEvaluator.EvaluateBatch(genotypes)
+		if len(batch) != len(genotypes) {
+			return nil, fmt.Errorf("batch evaluator returned %d phenotypes for %d genotypes", len(batch), len(genotypes))
+		}
+		if repeats == 1 {
+			return batch, nil
+		}
+		for i, phenotype := range batch {
+			evaluations[i] = append(evaluations[i], phenotype)
+		}
+	}
+	phenotypes = make([]*Phenotype, len(genotypes))
+	for i := range phenotypes {
+		phenotypes[i] = meanPhenotype(evaluations[i])
+	}
+	return phenotypes, nil
+}
@@ -0,0 +1,151 @@
+package ga
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// mockBatchEvaluator returns pre-computed fitness values, in order, for every batch, and
+// checks that no genotype is sent twice in a batch.
+type mockBatchEvaluator struct {
+	t       *testing.T
+	values  []float64
+	batches []int
+}
+
+func (m *mockBatchEvaluator) EvaluateBatch(genotypes []*Genotype) []*Phenotype {
+	m.batches = append(m.batches, len(genotypes))
+	seen := make(map[*Genotype]bool, len(genotypes))
+	for _, genotype := range genotypes {
+		if genotype == nil || seen[genotype] {
+			m.t.Errorf("Expected distinct non-nil genotypes in the batch, but got %v", genotype)
+		}
+		seen[genotype] = true
+	}
+	phenotypes := make([]*Phenotype, min(len(genotypes), len(m.values)))
+	for i := range phenotypes {
+		phenotypes[i] = &Phenotype{Fitness: m.values[i]}
+	}
+	return phenotypes
+}
+
+func TestBatchEvaluator(t *testing.T) {
+	const size = 10
+	values := []float64{5, 3, 8, 1, 9, 2, 7, 4, 6, 0}
+
+	cases := []struct {
+		name            string
+		selection       func([]*Individual) []*Individual
+		values          []float64
+		expectedBatches []int
+		expectedFitness func(i int) float64
+		expectedErrors  int
+	}{
+		{
+			name:            "distinct",
+			selection:       func(population []*Individual) []*Individual { return population },
+			values:          values,
+			expectedBatches: []int{size},
+			expectedFitness: func(i int) float64 { return values[i] },
+		},
+		{
+			name: "duplicates",
+			selection: func(population []*Individual) []*Individual {
+				return append(population[:size/2:size/2], population[:size/2]...)
+			},
+			values:          values,
+			expectedBatches: []int{size / 2},
+			expectedFitness: func(i int) float64 { return values[i%(size/2)] },
+		},
+		{
+			name:            "wrong count",
+			selection:       func(population []*Individual) []*Individual { return population },
+			values:          values[:3],
+			expectedBatches: []int{size},
+			expectedFitness: func(int) float64 { return -math.MaxFloat64 },
+			expectedErrors:  size,
+		},
+	}
+
+	for _, tc := range cases {
+		evaluations := 0
+		evaluate := func(genotype *Genotype) *Phenotype {
+			evaluations++
+			return oneMax(genotype)
+		}
+		batch := &mockBatchEvaluator{t: t, values: tc.values}
+		ga := &GA{
+			Selection:      tc.selection,
+			Crossover:      func(population []*Individual, _ float64) []*Individual { return population },
+			Mutation:       func([]*Individual, float64) {},
+			Generations:    1,
+			BatchEvaluator: batch,
+		}
+		ga.Initialize(size, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, evaluate)
+		evaluations = 0
+		_, evaluationErrors, err := ga.Evolve(evaluate)
+		if err != nil {
+			t.Fatalf("%s: Expected no error, but got %v", tc.name, err)
+		}
+
+		if evaluations != 0 {
+			t.Errorf("%s: Expected the fitness function not to be called, but it was called %d times", tc.name, evaluations)
+		}
+		if !slices.Equal(batch.batches, tc.expectedBatches) {
+			t.Errorf("%s: Expected batches of sizes %v, but got %v", tc.name, tc.expectedBatches, batch.batches)
+		}
+		for i, ind := range ga.Population {
+			if expected := tc.expectedFitness(i); ind.Phenotype.Fitness != expected {
+				t.Errorf("%s: Expected individual %d to have fitness %f, but got %f", tc.name, i, expected, ind.Phenotype.Fitness)
+			}
+		}
+		if len(evaluationErrors) != tc.expectedErrors {
+			t.Errorf("%s: Expected %d evaluation errors, but got %d", tc.name, tc.expectedErrors, len(evaluationErrors))
+		}
+	}
+}
+
+func TestBatchEvaluatorEvalRepeats(t *testing.T) {
+	const size, repeats = 4, 3
+	// Each genotype's fitness alternates between 1 and 3 across batches, so the mean is
+	// 5/3 and the sample standard deviation is sqrt(4/3).
+	batches := 0
+	evaluator := BatchEvaluatorFunc(func(genotypes []*Genotype) []*Phenotype {
+		batches++
+		phenotypes := make([]*Phenotype, len(genotypes))
+		for i := range phenotypes {
+			phenotypes[i] = &Phenotype{Fitness: float64(1 + 2*((batches+1)%2))}
+		}
+		return phenotypes
+	})
+	ga := &GA{
+		Selection:      func(population []*Individual) []*Individual { return population },
+		Crossover:      func(population []*Individual, _ float64) []*Individual { return population },
+		Mutation:       func([]*Individual, float64) {},
+		Generations:    1,
+		EvalRepeats:    repeats,
+		BatchEvaluator: evaluator,
+	}
+	if err := ga.Initialize(size, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if _, _, err := ga.Evolve(oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if batches != repeats {
+		t.Errorf("Expected %d batches, but got %d", repeats, batches)
+	}
+	for _, ind := range ga.Population {
+		if math.Abs(ind.Phenotype.Fitness-5.0/3) > 1e-9 {
+			t.Errorf("Expected fitness %f, but got %f", 5.0/3, ind.Phenotype.Fitness)
+		}
+		if std, ok := ind.Phenotype.GetFloat(StdFeature); !ok || math.Abs(std-math.Sqrt(4.0/3)) > 1e-9 {
+			t.Errorf("Expected a standard deviation of %f, but got %f", math.Sqrt(4.0/3), std)
+		}
+	}
+	if ga.EvaluationsUsed != 2*size {
+		t.Errorf("Expected %d evaluations, but got %d", 2*size, ga.EvaluationsUsed)
+	}
+}
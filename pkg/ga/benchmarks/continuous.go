@@ -0,0 +1,84 @@
+// Package benchmarks provides fitness functions for benchmarking genetic algorithms,
+// including classic continuous test functions over real-valued genotypes.
+package benchmarks
+
+import (
+	"math"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// The known optima of the continuous benchmarks, which are the highest fitness values their
+// evaluators can return. Each is attained at the all-zeros vector, except RosenbrockOptimum,
+// which is attained at the all-ones vector.
+const (
+	RastriginOptimum  = 0.0
+	AckleyOptimum     = 0.0
+	RosenbrockOptimum = 0.0
+)
+
+// RastriginEval returns an evaluator of the n-dimensional Rastrigin function
+// 10n + sum(x_i^2 - 10 cos(2 pi x_i)), a highly multimodal function whose global minimum is 0
+// at the all-zeros vector. It is usually searched over [-5.12, 5.12] in every dimension.
+//
+// Parameters:
+// - n: the number of dimensions, which are the first n genes of a real-valued genotype.
+//
+// Returns:
+// - An evaluator whose fitness is the negated function value, so that maximizing the fitness
+// minimizes the function.
+func RastriginEval(n int) func(*ga.Genotype) *ga.Phenotype {
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		value := 10 * float64(n)
+		for i := 0; i < n; i++ {
+			x := genotype.GetRealValue(i)
+			value += x*x - 10*math.Cos(2*math.Pi*x)
+		}
+		return &ga.Phenotype{Fitness: -value}
+	}
+}
+
+// AckleyEval returns an evaluator of the n-dimensional Ackley function
+// -20 exp(-0.2 sqrt(sum(x_i^2) / n)) - exp(sum(cos(2 pi x_i)) / n) + 20 + e, a multimodal
+// function with a nearly flat outer region whose global minimum is 0 at the all-zeros vector.
+// It is usually searched over [-32.768, 32.768] in every dimension.
+//
+// Parameters:
+// - n: the number of dimensions, which are the first n genes of a real-valued genotype.
+//
+// Returns:
+// - An evaluator whose fitness is the negated function value, so that maximizing the fitness
+// minimizes the function.
+func AckleyEval(n int) func(*ga.Genotype) *ga.Phenotype {
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		squares, cosines := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			x := genotype.GetRealValue(i)
+			squares += x * x
+			cosines += math.Cos(2 * math.Pi * x)
+		}
+		value := -20*math.Exp(-0.2*math.Sqrt(squares/float64(n))) - math.Exp(cosines/float64(n)) + 20 + math.E
+		return &ga.Phenotype{Fitness: -value}
+	}
+}
+
+// RosenbrockEval returns an evaluator of the n-dimensional Rosenbrock function
+// sum(100 (x_{i+1} - x_i^2)^2 + (1 - x_i)^2), whose global minimum of 0 at the all-ones vector
+// lies in a long, narrow, curved valley. It is usually searched over [-5, 10] in every dimension.
+//
+// Parameters:
+// - n: the number of dimensions, which are the first n genes of a real-valued genotype.
+//
+// Returns:
+// - An evaluator whose fitness is the negated function value, so that maximizing the fitness
+// minimizes the function.
+func RosenbrockEval(n int) func(*ga.Genotype) *ga.Phenotype {
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		value := 0.0
+		for i := 0; i < n-1; i++ {
+			x, next := genotype.GetRealValue(i), genotype.GetRealValue(i+1)
+			value += 100*(next-x*x)*(next-x*x) + (1-x)*(1-x)
+		}
+		return &ga.Phenotype{Fitness: -value}
+	}
+}
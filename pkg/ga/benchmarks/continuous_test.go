@@ -0,0 +1,86 @@
+package benchmarks
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func TestContinuousOptima(t *testing.T) {
+	const n = 3
+	cases := []struct {
+		name    string
+		eval    func(*ga.Genotype) *ga.Phenotype
+		optimum float64
+		at      float64
+		lower   float64
+		upper   float64
+	}{
+		{name: "Rastrigin", eval: RastriginEval(n), optimum: RastriginOptimum, at: 0, lower: -5.12, upper: 5.12},
+		{name: "Ackley", eval: AckleyEval(n), optimum: AckleyOptimum, at: 0, lower: -32.768, upper: 32.768},
+		{name: "Rosenbrock", eval: RosenbrockEval(n), optimum: RosenbrockOptimum, at: 1, lower: -5, upper: 10},
+	}
+
+	for _, tc := range cases {
+		genotype := ga.MustNewFloat64Genotype(n, uniform(n, tc.lower), uniform(n, tc.upper))
+		for i := 0; i < n; i++ {
+			genotype.SetRealValue(i, tc.at)
+		}
+		if fitness := tc.eval(genotype).Fitness; math.Abs(fitness-tc.optimum) > 1e-12 {
+			t.Errorf("%s: Expected fitness %f at the optimum, but got %f", tc.name, tc.optimum, fitness)
+		}
+		genotype.SetRealValue(0, tc.at+0.5)
+		if fitness := tc.eval(genotype).Fitness; fitness >= tc.optimum {
+			t.Errorf("%s: Expected fitness below %f away from the optimum, but got %f", tc.name, tc.optimum, fitness)
+		}
+	}
+}
+
+func TestEvolveContinuousBenchmarks(t *testing.T) {
+	const n = 2
+	cases := []struct {
+		name    string
+		eval    func(*ga.Genotype) *ga.Phenotype
+		optimum float64
+		lower   float64
+		upper   float64
+	}{
+		{name: "Rastrigin", eval: RastriginEval(n), optimum: RastriginOptimum, lower: -5.12, upper: 5.12},
+		{name: "Ackley", eval: AckleyEval(n), optimum: AckleyOptimum, lower: -32.768, upper: 32.768},
+		{name: "Rosenbrock", eval: RosenbrockEval(n), optimum: RosenbrockOptimum, lower: -5, upper: 10},
+	}
+
+	for _, tc := range cases {
+		g := &ga.GA{
+			Selection: func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 3) },
+			Crossover: func(population []*ga.Individual, crossoverRate float64) []*ga.Individual {
+				return ga.BlendCrossover(population, crossoverRate, 0.5)
+			},
+			Mutation:       ga.PolynomialMutation,
+			CrossoverRate:  0.9,
+			MutationRate:   0.5,
+			Generations:    1000,
+			HallOfFameSize: 1,
+		}
+		g.Initialize(50, func() *ga.Genotype {
+			return ga.MustNewFloat64Genotype(n, uniform(n, tc.lower), uniform(n, tc.upper))
+		}, tc.eval)
+		initial := g.HallOfFame[0].Phenotype.Fitness
+		g.Evolve(tc.eval)
+
+		// The optima are zero, so the tolerance is 5% of the initial distance from the optimum.
+		if best := g.HallOfFame[0].Phenotype.Fitness; tc.optimum-best > 0.05*(tc.optimum-initial) {
+			t.Errorf("%s: Expected best fitness within 5%% of the optimum %f, starting from %f, but got %f", tc.name, tc.optimum, initial, best)
+		}
+	}
+}
+
+// uniform returns a slice of n copies of value.
+func uniform(n int, value float64) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
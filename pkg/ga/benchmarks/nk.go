@@ -0,0 +1,48 @@
+// Package benchmarks provides fitness functions for benchmarking genetic algorithms,
+// including NK landscapes of tunable ruggedness.
+package benchmarks
+
+import (
+	"math/rand"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// NewNKFitnessFunc returns the fitness function of a random NK landscape over binary
+// genotypes of n bits.
+//
+// The fitness contribution of each bit depends on its own value and on the values of the k
+// bits following it, wrapping around the end of the genome. Each bit has a lookup table of
+// 2^(k+1) contributions drawn uniformly from [0, 1), one for every combination of these
+// values, and the fitness is the average contribution over all bits. With k = 0 the landscape
+// is additive and smooth; as k approaches n-1 it becomes increasingly rugged. The tables
+// take n * 2^(k+1) values, so k must be kept small enough for them to fit in memory.
+//
+// Parameters:
+// - n: the number of bits of the genotypes.
+// - k: the number of epistatic neighbors of each bit, between 0 and n-1.
+// - rng: the source of randomness for generating the lookup tables.
+//
+// Returns:
+// - The fitness function, which evaluates only the first n genes of a genotype.
+func NewNKFitnessFunc(n, k int, rng *rand.Rand) func(*ga.Genotype) *ga.Phenotype {
+	tables := make([][]float64, n)
+	for i := range tables {
+		tables[i] = make([]float64, 1<<(k+1))
+		for j := range tables[i] {
+			tables[i][j] = rng.Float64()
+		}
+	}
+
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		fitness := 0.0
+		for i, table := range tables {
+			index := 0
+			for j := 0; j <= k; j++ {
+				index = index<<1 | int(genotype.Genome[(i+j)%n]&1)
+			}
+			fitness += table[index]
+		}
+		return &ga.Phenotype{Fitness: fitness / float64(n)}
+	}
+}
@@ -0,0 +1,35 @@
+package benchmarks
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"github.com/Okabe-Junya/gago/pkg/ga/analysis"
+)
+
+func TestNewNKFitnessFunc(t *testing.T) {
+	const n = 12
+	cases := []struct {
+		k           int
+		minExpected float64
+		maxExpected float64
+	}{
+		{k: 0, minExpected: 0.7, maxExpected: 1},
+		{k: n - 1, minExpected: -0.2, maxExpected: 0.2},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range cases {
+		fitnessFunc := NewNKFitnessFunc(n, tc.k, rng)
+
+		start := ga.MustVariableLengthBinaryGenotype(n, n)
+		if fitness := fitnessFunc(start).Fitness; fitness < 0 || fitness >= 1 {
+			t.Errorf("Expected fitness in [0, 1) for k = %d, but got %f", tc.k, fitness)
+		}
+		correlation := analysis.RandomWalkCorrelation(fitnessFunc, start, 2000, rng)
+		if correlation < tc.minExpected || correlation > tc.maxExpected {
+			t.Errorf("Expected a random walk correlation in [%.1f, %.1f] for k = %d, but got %f", tc.minExpected, tc.maxExpected, tc.k, correlation)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package cache provides a fitness cache for genetic algorithms, allowing
+// expensive fitness evaluations to be memoized by genome.
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// FitnessCache stores fitness values keyed by genome, each optionally with the value it was
+// evaluated from, such as a complete phenotype. It is safe for concurrent use.
+//
+// CacheHits and CacheMisses count the number of lookups that were answered from
+// the cache and the number that were not, respectively. They are updated atomically.
+type FitnessCache struct {
+	entries     sync.Map
+	CacheHits   int64
+	CacheMisses int64
+}
+
+// entry is a fitness value stored in a FitnessCache with its accompanying value.
+type entry struct {
+	fitness float64
+	value   interface{}
+}
+
+// NewFitnessCache creates a new, empty FitnessCache.
+//
+// Returns:
+// - A pointer to the newly created FitnessCache.
+func NewFitnessCache() *FitnessCache {
+	return &FitnessCache{}
+}
+
+// Get looks up the fitness value stored for the given genome.
+//
+// Parameters:
+// - genome: the genome to look up.
+//
+// Returns:
+// - The cached fitness value, and true if the genome was found in the cache.
+func (c *FitnessCache) Get(genome []byte) (float64, bool) {
+	fitness, _, ok := c.GetValue(genome)
+	return fitness, ok
+}
+
+// GetValue looks up the fitness value and the accompanying value stored for the given genome.
+//
+// Parameters:
+// - genome: the genome to look up.
+//
+// Returns:
+// - The cached fitness value.
+// - The value stored with it by PutValue, or nil if it was stored by Put.
+// - Whether the genome was found in the cache.
+func (c *FitnessCache) GetValue(genome []byte) (float64, interface{}, bool) {
+	if stored, ok := c.entries.Load(string(genome)); ok {
+		atomic.AddInt64(&c.CacheHits, 1)
+		e := stored.(entry)
+		return e.fitness, e.value, true
+	}
+	atomic.AddInt64(&c.CacheMisses, 1)
+	return 0, nil, false
+}
+
+// Put stores the fitness value for the given genome, replacing any existing entry.
+//
+// Parameters:
+// - genome: the genome whose fitness is stored.
+// - fitness: the fitness value to store.
+func (c *FitnessCache) Put(genome []byte, fitness float64) {
+	c.PutValue(genome, fitness, nil)
+}
+
+// PutValue stores the fitness value for the given genome together with an accompanying value,
+// replacing any existing entry. The value is stored as is, so it must not be modified
+// afterwards.
+//
+// Parameters:
+// - genome: the genome whose fitness is stored.
+// - fitness: the fitness value to store.
+// - value: the value to store with it.
+func (c *FitnessCache) PutValue(genome []byte, fitness float64, value interface{}) {
+	c.entries.Store(string(genome), entry{fitness: fitness, value: value})
+}
+
+// Hits returns the number of lookups answered from the cache.
+func (c *FitnessCache) Hits() int64 {
+	return atomic.LoadInt64(&c.CacheHits)
+}
+
+// Misses returns the number of lookups that were not found in the cache.
+func (c *FitnessCache) Misses() int64 {
+	return atomic.LoadInt64(&c.CacheMisses)
+}
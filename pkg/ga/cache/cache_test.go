@@ -0,0 +1,58 @@
+package cache
+
+import "testing"
+
+func TestFitnessCache(t *testing.T) {
+	cases := []struct {
+		genome  []byte
+		fitness float64
+	}{
+		{genome: []byte{1, 0, 1, 0}, fitness: 2.0},
+		{genome: []byte{1, 1, 1, 1}, fitness: 4.0},
+	}
+
+	c := NewFitnessCache()
+	for _, tc := range cases {
+		if _, ok := c.Get(tc.genome); ok {
+			t.Fatalf("Expected genome %v to be missing from the cache", tc.genome)
+		}
+
+		c.Put(tc.genome, tc.fitness)
+
+		fitness, ok := c.Get(tc.genome)
+		if !ok {
+			t.Fatalf("Expected genome %v to be found in the cache", tc.genome)
+		}
+		if fitness != tc.fitness {
+			t.Errorf("Expected cached fitness %f, but got %f", tc.fitness, fitness)
+		}
+	}
+
+	if c.Hits() != int64(len(cases)) {
+		t.Errorf("Expected %d cache hits, but got %d", len(cases), c.Hits())
+	}
+	if c.Misses() != int64(len(cases)) {
+		t.Errorf("Expected %d cache misses, but got %d", len(cases), c.Misses())
+	}
+}
+
+func TestFitnessCacheValue(t *testing.T) {
+	c := NewFitnessCache()
+	genome := []byte{1, 0, 1}
+
+	c.Put(genome, 2.0)
+	if fitness, value, ok := c.GetValue(genome); !ok || fitness != 2.0 || value != nil {
+		t.Errorf("Expected fitness 2.0 without a value, but got %f, %v, %v", fitness, value, ok)
+	}
+
+	c.PutValue(genome, 3.0, "phenotype")
+	if fitness, value, ok := c.GetValue(genome); !ok || fitness != 3.0 || value != "phenotype" {
+		t.Errorf("Expected fitness 3.0 with value %q, but got %f, %v, %v", "phenotype", fitness, value, ok)
+	}
+	if fitness, ok := c.Get(genome); !ok || fitness != 3.0 {
+		t.Errorf("Expected Get to return fitness 3.0, but got %f, %v", fitness, ok)
+	}
+	if _, _, ok := c.GetValue([]byte{0}); ok {
+		t.Errorf("Expected a missing genome not to be found")
+	}
+}
@@ -0,0 +1,174 @@
+// Package coevo provides cooperative coevolution for genetic algorithms, in which the genome
+// is partitioned into segments that are evolved by separate sub-populations.
+package coevo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// ErrIncompatibleSegments is returned by Assemble when the segments cannot be concatenated
+// into a single genotype.
+var ErrIncompatibleSegments = errors.New("coevo: incompatible segments")
+
+// CooperativeGA evolves a genome partitioned into segments, one per sub-population.
+//
+// The fitness of an individual of a sub-population is the fitness of the complete genome
+// assembled from the individual and the current representative of every other sub-population.
+// The genomes of the segments are concatenated in the order of SubPopulations, so every
+// segment must have the same encoding, as required by Assemble.
+//
+// Each sub-population is a GA with its own operators and rates; its Generations field is
+// overwritten by Run. SegmentLengths holds the genome length of each sub-population and
+// PopulationSize the number of individuals in each. RepresentativeSelector chooses the
+// representative of a sub-population and defaults to BestRepresentative. NewSegment creates
+// the genotype of a new individual of a sub-population and defaults to a random binary genotype.
+type CooperativeGA struct {
+	SubPopulations         []*ga.GA
+	SegmentLengths         []int
+	PopulationSize         int
+	RepresentativeSelector func(*ga.Population) *ga.Individual
+	NewSegment             func(length int) *ga.Genotype
+
+	representatives []*ga.Individual
+}
+
+// BestRepresentative returns the individual with the best fitness in the population: the
+// highest, or the lowest if the population's Minimize is set.
+//
+// Parameters:
+// - population: the population to choose from.
+//
+// Returns:
+// - A pointer to the individual with the best fitness, or nil if the population is empty.
+func BestRepresentative(population *ga.Population) *ga.Individual {
+	var best *ga.Individual
+	for _, ind := range population.Individuals {
+		if best == nil || fitter(ind.Phenotype.Fitness, best.Phenotype.Fitness, population.Minimize) {
+			best = ind
+		}
+	}
+	return best
+}
+
+// fitter reports whether fitness a is strictly better than fitness b.
+func fitter(a, b float64, minimize bool) bool {
+	if minimize {
+		return a < b
+	}
+	return a > b
+}
+
+// Assemble concatenates the genes of the given individuals, one per segment, into a complete
+// genotype of the same encoding, concatenating the per-gene bounds of real-valued and integer
+// segments as well.
+//
+// Parameters:
+// - segments: the individuals holding the segments, in order.
+//
+// Returns:
+// - A pointer to the assembled Genotype.
+// - An error wrapping ErrIncompatibleSegments if the segments have different encodings or are
+// permutations, whose concatenation is not a permutation.
+func Assemble(segments []*ga.Individual) (*ga.Genotype, error) {
+	genotype := &ga.Genotype{}
+	for i, segment := range segments {
+		encoding := segment.Genotype.GenomeType
+		if encoding == ga.LargePermutationEncoding {
+			return nil, fmt.Errorf("%w: segment %d is a permutation", ErrIncompatibleSegments, i)
+		}
+		if i == 0 {
+			genotype.GenomeType = encoding
+		} else if encoding != genotype.GenomeType {
+			return nil, fmt.Errorf("%w: segment %d has encoding %d, but segment 0 has %d", ErrIncompatibleSegments, i, encoding, genotype.GenomeType)
+		}
+		genotype.Genome = append(genotype.Genome, segment.Genotype.Genome...)
+		genotype.Float64Genome = append(genotype.Float64Genome, segment.Genotype.Float64Genome...)
+		genotype.Int32Genome = append(genotype.Int32Genome, segment.Genotype.Int32Genome...)
+		genotype.MinValues = append(genotype.MinValues, segment.Genotype.MinValues...)
+		genotype.MaxValues = append(genotype.MaxValues, segment.Genotype.MaxValues...)
+	}
+	return genotype, nil
+}
+
+// Run initializes every sub-population and evolves them in turn for the given number of
+// generations, updating the representatives after each round.
+//
+// Parameters:
+// - evalFunc: a function to evaluate a complete Genotype and return its Phenotype.
+// - generations: the number of generations to evolve each sub-population.
+//
+// Returns:
+// - The best individual of each sub-population, in the order of SubPopulations.
+// - An error if there is not one segment length per sub-population, if the segments cannot
+// be assembled, or the error of a sub-population's Initialize or Evolve.
+func (c *CooperativeGA) Run(evalFunc func(*ga.Genotype) *ga.Phenotype, generations int) ([]*ga.Individual, error) {
+	if len(c.SegmentLengths) != len(c.SubPopulations) {
+		return nil, fmt.Errorf("coevo: %d segment lengths for %d sub-populations", len(c.SegmentLengths), len(c.SubPopulations))
+	}
+	selector := c.RepresentativeSelector
+	if selector == nil {
+		selector = BestRepresentative
+	}
+	newSegment := c.NewSegment
+	if newSegment == nil {
+		newSegment = func(length int) *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(length, length) }
+	}
+
+	// Until the sub-populations are evaluated, random segments stand in for their representatives.
+	c.representatives = make([]*ga.Individual, len(c.SubPopulations))
+	for i := range c.representatives {
+		c.representatives[i] = &ga.Individual{Genotype: newSegment(c.SegmentLengths[i])}
+	}
+	if _, err := Assemble(c.representatives); err != nil {
+		return nil, err
+	}
+	for i, sub := range c.SubPopulations {
+		length := c.SegmentLengths[i]
+		if err := sub.Initialize(c.PopulationSize, func() *ga.Genotype { return newSegment(length) }, c.segmentEvaluator(i, evalFunc)); err != nil {
+			return nil, err
+		}
+	}
+	c.updateRepresentatives(selector)
+
+	for gen := 0; gen < generations; gen++ {
+		for i, sub := range c.SubPopulations {
+			sub.Generations = 1
+			if _, _, err := sub.Evolve(c.segmentEvaluator(i, evalFunc)); err != nil {
+				return nil, err
+			}
+		}
+		c.updateRepresentatives(selector)
+	}
+
+	best := make([]*ga.Individual, len(c.SubPopulations))
+	for i, sub := range c.SubPopulations {
+		best[i] = BestRepresentative(population(sub))
+	}
+	return best, nil
+}
+
+// segmentEvaluator returns a function evaluating a segment of the given sub-population by
+// assembling it with the representatives of the other sub-populations.
+func (c *CooperativeGA) segmentEvaluator(index int, evalFunc func(*ga.Genotype) *ga.Phenotype) func(*ga.Genotype) *ga.Phenotype {
+	return func(segment *ga.Genotype) *ga.Phenotype {
+		segments := append([]*ga.Individual(nil), c.representatives...)
+		segments[index] = &ga.Individual{Genotype: segment}
+		genotype, err := Assemble(segments)
+		if err != nil {
+			// Run checks that the segments assemble, so this only happens if NewSegment
+			// changes encoding; the GA reports the panic as an EvaluationError.
+			panic(err)
+		}
+		return evalFunc(genotype)
+	}
+}
+
+// updateRepresentatives chooses the representative of every sub-population.
+func (c *CooperativeGA) updateRepresentatives(selector func(*ga.Population) *ga.Individual) {
+	for i, sub := range c.SubPopulations {
+		c.representatives[i] = selector(population(sub)).Clone()
+	}
+}
@@ -0,0 +1,179 @@
+package coevo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// oneMax returns the number of ones in the genome as the fitness.
+func oneMax(genotype *ga.Genotype) *ga.Phenotype {
+	fitness := 0.0
+	for _, gene := range genotype.Genome {
+		fitness += float64(gene)
+	}
+	return &ga.Phenotype{Fitness: fitness}
+}
+
+func newSubPopulation() *ga.GA {
+	return &ga.GA{
+		Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 3) },
+		Crossover:     ga.SinglePointCrossover,
+		Mutation:      ga.BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.05,
+	}
+}
+
+func TestCooperativeGA(t *testing.T) {
+	segmentLengths := []int{10, 10, 10}
+	c := &CooperativeGA{
+		SubPopulations: []*ga.GA{newSubPopulation(), newSubPopulation(), newSubPopulation()},
+		SegmentLengths: segmentLengths,
+		PopulationSize: 20,
+	}
+
+	best, err := c.Run(oneMax, 30)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if len(best) != len(segmentLengths) {
+		t.Fatalf("Expected %d best individuals, but got %d", len(segmentLengths), len(best))
+	}
+	for i, ind := range best {
+		if len(ind.Genotype.Genome) != segmentLengths[i] {
+			t.Errorf("Expected segment %d of length %d, but got %d", i, segmentLengths[i], len(ind.Genotype.Genome))
+		}
+	}
+
+	// No single segment can reach more than its own length in ones.
+	genotype, err := Assemble(best)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	combined := oneMax(genotype).Fitness
+	for i, length := range segmentLengths {
+		if combined <= float64(length) {
+			t.Errorf("Expected the combined fitness %f to exceed the best of sub-population %d alone (%d)", combined, i, length)
+		}
+	}
+	if combined < 25 {
+		t.Errorf("Expected the combined solution to approach %d, but got %f", 30, combined)
+	}
+}
+
+func TestAssemble(t *testing.T) {
+	segments := []*ga.Individual{
+		{Genotype: &ga.Genotype{Genome: []byte{1, 0}}},
+		{Genotype: &ga.Genotype{Genome: []byte{0}}},
+		{Genotype: &ga.Genotype{Genome: []byte{1, 1, 0}}},
+	}
+	expected := []byte{1, 0, 0, 1, 1, 0}
+
+	genotype, err := Assemble(segments)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if string(genotype.Genome) != string(expected) {
+		t.Errorf("Expected %v, but got %v", expected, genotype.Genome)
+	}
+}
+
+func TestAssembleRealSegments(t *testing.T) {
+	segments := []*ga.Individual{
+		{Genotype: ga.MustNewRealGenotype(2, []float64{0, 0}, []float64{1, 1})},
+		{Genotype: ga.MustNewRealGenotype(1, []float64{-5}, []float64{5})},
+	}
+
+	genotype, err := Assemble(segments)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if genotype.GenomeType != ga.RealEncoding {
+		t.Errorf("Expected encoding %d, but got %d", ga.RealEncoding, genotype.GenomeType)
+	}
+	if !reflect.DeepEqual(genotype.Genome, append(append([]byte{}, segments[0].Genotype.Genome...), segments[1].Genotype.Genome...)) {
+		t.Errorf("Expected the genes of both segments, but got %v", genotype.Genome)
+	}
+	if expected := []float64{0, 0, -5}; !reflect.DeepEqual(genotype.MinValues, expected) {
+		t.Errorf("Expected MinValues %v, but got %v", expected, genotype.MinValues)
+	}
+	if expected := []float64{1, 1, 5}; !reflect.DeepEqual(genotype.MaxValues, expected) {
+		t.Errorf("Expected MaxValues %v, but got %v", expected, genotype.MaxValues)
+	}
+	for i := range 3 {
+		if value := genotype.GetRealValue(i); value < genotype.MinValues[i] || value > genotype.MaxValues[i] {
+			t.Errorf("Expected gene %d within [%f, %f], but got %f", i, genotype.MinValues[i], genotype.MaxValues[i], value)
+		}
+	}
+}
+
+func TestAssembleIncompatibleSegments(t *testing.T) {
+	cases := []struct {
+		name     string
+		segments []*ga.Individual
+	}{
+		{
+			name: "mixed encodings",
+			segments: []*ga.Individual{
+				{Genotype: &ga.Genotype{Genome: []byte{1, 0}}},
+				{Genotype: ga.MustNewRealGenotype(1, []float64{0}, []float64{1})},
+			},
+		},
+		{
+			name: "permutations",
+			segments: []*ga.Individual{
+				{Genotype: &ga.Genotype{Int32Genome: []int32{0, 1}, GenomeType: ga.LargePermutationEncoding}},
+				{Genotype: &ga.Genotype{Int32Genome: []int32{1, 0}, GenomeType: ga.LargePermutationEncoding}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		if _, err := Assemble(tc.segments); !errors.Is(err, ErrIncompatibleSegments) {
+			t.Errorf("%s: Expected ErrIncompatibleSegments, but got %v", tc.name, err)
+		}
+	}
+}
+
+func TestBestRepresentativeMinimize(t *testing.T) {
+	individuals := []*ga.Individual{
+		{Phenotype: &ga.Phenotype{Fitness: 2}},
+		{Phenotype: &ga.Phenotype{Fitness: 1}},
+		{Phenotype: &ga.Phenotype{Fitness: 3}},
+	}
+
+	if best := BestRepresentative(&ga.Population{Individuals: individuals}); best != individuals[2] {
+		t.Errorf("Expected the individual with fitness 3, but got fitness %f", best.Phenotype.Fitness)
+	}
+	if best := BestRepresentative(&ga.Population{Individuals: individuals, Minimize: true}); best != individuals[1] {
+		t.Errorf("Expected the individual with fitness 1, but got fitness %f", best.Phenotype.Fitness)
+	}
+}
+
+func TestCooperativeGAErrors(t *testing.T) {
+	c := &CooperativeGA{
+		SubPopulations: []*ga.GA{newSubPopulation(), newSubPopulation()},
+		SegmentLengths: []int{4, 4},
+		PopulationSize: 10,
+	}
+	c.SubPopulations[1].EvaluationBudget = 5
+
+	if _, err := c.Run(oneMax, 5); !errors.Is(err, ga.ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded, but got %v", err)
+	}
+
+	c = &CooperativeGA{
+		SubPopulations: []*ga.GA{newSubPopulation(), newSubPopulation()},
+		SegmentLengths: []int{4},
+		PopulationSize: 10,
+	}
+	if _, err := c.Run(oneMax, 5); err == nil {
+		t.Error("Expected an error for a missing segment length, but got nil")
+	}
+}
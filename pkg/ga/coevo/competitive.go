@@ -0,0 +1,115 @@
+// Package coevo provides coevolution for genetic algorithms, including competitive
+// coevolution, in which two populations are evaluated by competing against each other.
+package coevo
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// CompetitiveCoevolution evolves two populations whose individuals are evaluated by competing
+// against members of the opposing population.
+//
+// A and B are GAs with their own operators and rates, which must be initialized before Run;
+// their Generations fields are overwritten by Run. CompeteFn returns the score of its first
+// argument against its second, higher being better. The fitness of an individual is its mean
+// score against SampleSize opponents drawn without replacement, or against the whole opposing
+// population if SampleSize is not positive. Rng, if set, draws the opponents; it is not safe
+// for concurrent use, so it must be nil if either GA evaluates in parallel.
+type CompetitiveCoevolution struct {
+	A, B       *ga.GA
+	CompeteFn  func(a, b *ga.Individual) float64
+	SampleSize int
+	Rng        *rand.Rand
+}
+
+// FitnessAgainstSample returns the mean score of the individual against sampleSize opponents
+// drawn at random without replacement from the population, or against every member of the
+// population if it holds fewer.
+//
+// Parameters:
+// - pop: the opposing population.
+// - individual: the individual to evaluate.
+// - sampleSize: the number of opponents.
+// - rng: the source of randomness, or nil to use a new source seeded from math/rand.
+//
+// Returns:
+// - The mean score of the individual, or 0 if there are no opponents.
+func (c *CompetitiveCoevolution) FitnessAgainstSample(pop *ga.Population, individual *ga.Individual, sampleSize int, rng *rand.Rand) float64 {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+	// The opponents need not have been evaluated, so they are drawn by a partial shuffle of
+	// their indices rather than with Population.Sample, which calculates their statistics.
+	n := min(max(sampleSize, 0), len(pop.Individuals))
+	if n == 0 {
+		return 0
+	}
+	order := rng.Perm(len(pop.Individuals))
+	score := 0.0
+	for _, i := range order[:n] {
+		score += c.CompeteFn(individual, pop.Individuals[i])
+	}
+	return score / float64(n)
+}
+
+// Run co-evolves both populations for the given number of generations. Both populations are
+// first evaluated against each other, and in each generation A evolves against the current B
+// and then B against the evolved A.
+//
+// Parameters:
+// - generations: the number of generations to evolve each population.
+//
+// Returns:
+// - The individuals with the best fitness in A and in B after the last generation.
+// - An error if a population is empty or CompeteFn is nil, or the error of a GA's Evolve.
+func (c *CompetitiveCoevolution) Run(generations int) (bestA, bestB *ga.Individual, err error) {
+	if c.CompeteFn == nil {
+		return nil, nil, errors.New("coevo: CompeteFn must be set")
+	}
+	if c.A == nil || c.B == nil || len(c.A.Population) == 0 || len(c.B.Population) == 0 {
+		return nil, nil, errors.New("coevo: A and B must be initialized")
+	}
+
+	// The fitness of the initial populations was not measured against each other, so both
+	// are evaluated against the other's initial population.
+	initialA, initialB := population(c.A), population(c.B)
+	for _, pair := range [][2]*ga.Population{{initialA, initialB}, {initialB, initialA}} {
+		evaluator := c.evaluator(pair[1])
+		for _, ind := range pair[0].Individuals {
+			ind.Phenotype = evaluator(ind.Genotype)
+		}
+	}
+
+	for gen := 0; gen < generations; gen++ {
+		for _, pair := range [][2]*ga.GA{{c.A, c.B}, {c.B, c.A}} {
+			sub, opponent := pair[0], pair[1]
+			sub.Generations = 1
+			if _, _, err := sub.Evolve(c.evaluator(population(opponent))); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return BestRepresentative(population(c.A)), BestRepresentative(population(c.B)), nil
+}
+
+// evaluator returns a function evaluating a genotype by its mean score against a sample of
+// the opponents.
+func (c *CompetitiveCoevolution) evaluator(opponents *ga.Population) func(*ga.Genotype) *ga.Phenotype {
+	sampleSize := c.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = len(opponents.Individuals)
+	}
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		fitness := c.FitnessAgainstSample(opponents, &ga.Individual{Genotype: genotype}, sampleSize, c.Rng)
+		return &ga.Phenotype{Fitness: fitness}
+	}
+}
+
+// population returns the current population of the GA, with the GA's Minimize.
+func population(sub *ga.GA) *ga.Population {
+	return &ga.Population{Individuals: sub.Population, Minimize: sub.Minimize}
+}
@@ -0,0 +1,115 @@
+package coevo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// strategy decodes the move of a rock-paper-scissors player, 0 for rock, 1 for paper, and 2
+// for scissors, as the number of ones in its genome modulo 3.
+func strategy(ind *ga.Individual) int {
+	ones := 0
+	for _, gene := range ind.Genotype.Genome {
+		ones += int(gene)
+	}
+	return ones % 3
+}
+
+// rockPaperScissors scores 1 for a win, 0.5 for a draw, and 0 for a loss.
+func rockPaperScissors(a, b *ga.Individual) float64 {
+	switch (strategy(a) - strategy(b) + 3) % 3 {
+	case 0:
+		return 0.5
+	case 1:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func TestFitnessAgainstSample(t *testing.T) {
+	players := make([]*ga.Individual, 3)
+	for move := range players {
+		genome := make([]byte, 8)
+		for i := 0; i < move; i++ {
+			genome[i] = 1
+		}
+		players[move] = &ga.Individual{Genotype: &ga.Genotype{Genome: genome}}
+	}
+	c := &CompetitiveCoevolution{CompeteFn: rockPaperScissors}
+	opponents := &ga.Population{Individuals: players}
+
+	cases := []struct {
+		sampleSize int
+		expected   float64
+	}{
+		{sampleSize: 3, expected: 0.5},
+		{sampleSize: 10, expected: 0.5},
+		{sampleSize: 0, expected: 0},
+	}
+
+	for _, tc := range cases {
+		for move, player := range players {
+			if fitness := c.FitnessAgainstSample(opponents, player, tc.sampleSize, rand.New(rand.NewSource(1))); fitness != tc.expected {
+				t.Errorf("Expected move %d to score %f against %d opponents, but got %f", move, tc.expected, tc.sampleSize, fitness)
+			}
+		}
+	}
+}
+
+func TestCompetitiveCoevolution(t *testing.T) {
+	c := &CompetitiveCoevolution{A: newSubPopulation(), B: newSubPopulation(), CompeteFn: rockPaperScissors, SampleSize: 10}
+	for _, sub := range []*ga.GA{c.A, c.B} {
+		sub.Initialize(30, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+	}
+
+	// meanScore returns the mean score of the population of a against every member of b.
+	meanScore := func(a, b *ga.GA) float64 {
+		score := 0.0
+		for _, x := range a.Population {
+			for _, y := range b.Population {
+				score += rockPaperScissors(x, y)
+			}
+		}
+		return score / float64(len(a.Population)*len(b.Population))
+	}
+
+	leads := map[string]int{}
+	for round := 0; round < 40; round++ {
+		bestA, bestB, err := c.Run(1)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if bestA == nil || bestB == nil {
+			t.Fatalf("Expected best individuals of both populations")
+		}
+		switch score := meanScore(c.A, c.B); {
+		case score > 0.5:
+			leads["A"]++
+		case score < 0.5:
+			leads["B"]++
+		}
+	}
+
+	if leads["A"] == 0 || leads["B"] == 0 {
+		t.Errorf("Expected neither population to dominate unconditionally, but the leads were %v", leads)
+	}
+}
+
+func TestCompetitiveCoevolutionErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		c    *CompetitiveCoevolution
+	}{
+		{name: "missing CompeteFn", c: &CompetitiveCoevolution{A: newSubPopulation(), B: newSubPopulation()}},
+		{name: "uninitialized", c: &CompetitiveCoevolution{A: newSubPopulation(), B: newSubPopulation(), CompeteFn: rockPaperScissors}},
+	}
+
+	for _, tc := range cases {
+		if _, _, err := tc.c.Run(1); err == nil {
+			t.Errorf("%s: Expected an error, but got nil", tc.name)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package config provides loading of genetic algorithm parameters from JSON and YAML,
+// allowing GA runs to be configured without recompiling.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"gopkg.in/yaml.v3"
+)
+
+// GAConfig holds the parameters of a GA run. Operators are named as registered in the
+// operator registries passed to Apply.
+type GAConfig struct {
+	PopulationSize      int            `json:"populationSize" yaml:"populationSize"`
+	Generations         int            `json:"generations" yaml:"generations"`
+	CrossoverRate       float64        `json:"crossoverRate" yaml:"crossoverRate"`
+	MutationRate        float64        `json:"mutationRate" yaml:"mutationRate"`
+	EnableLogger        bool           `json:"enableLogger" yaml:"enableLogger"`
+	HallOfFameSize      int            `json:"hallOfFameSize" yaml:"hallOfFameSize"`
+	ImmigrationRate     float64        `json:"immigrationRate" yaml:"immigrationRate"`
+	ImmigrationInterval int            `json:"immigrationInterval" yaml:"immigrationInterval"`
+	MinDiversity        float64        `json:"minDiversity" yaml:"minDiversity"`
+	RestartFraction     float64        `json:"restartFraction" yaml:"restartFraction"`
+	ElitismCount        int            `json:"elitismCount" yaml:"elitismCount"`
+	Selection           string         `json:"selection" yaml:"selection"`
+	Crossover           string         `json:"crossover" yaml:"crossover"`
+	Mutation            string         `json:"mutation" yaml:"mutation"`
+	AdaptiveParams      AdaptiveParams `json:"adaptiveParams" yaml:"adaptiveParams"`
+}
+
+// AdaptiveParams holds the parameters with which the GA adapts its mutation rate, mirroring
+// the AdaptiveStrategy, SuccessRateFactor, MaxMutationRate, and ConvergenceEpsilon fields of
+// ga.GA.
+type AdaptiveParams struct {
+	Strategy           string  `json:"strategy" yaml:"strategy"`
+	SuccessRateFactor  float64 `json:"successRateFactor" yaml:"successRateFactor"`
+	MaxMutationRate    float64 `json:"maxMutationRate" yaml:"maxMutationRate"`
+	ConvergenceEpsilon float64 `json:"convergenceEpsilon" yaml:"convergenceEpsilon"`
+}
+
+// DefaultConfig returns the configuration used for any fields omitted when loading.
+//
+// Returns:
+// - A pointer to a new GAConfig holding the default parameters.
+func DefaultConfig() *GAConfig {
+	return &GAConfig{
+		PopulationSize: 50,
+		Generations:    100,
+		CrossoverRate:  0.7,
+		MutationRate:   0.01,
+		Selection:      "tournament",
+		Crossover:      "single_point",
+		Mutation:       "bit_flip",
+		AdaptiveParams: AdaptiveParams{
+			Strategy:           ga.AdaptiveStrategyDiversity,
+			SuccessRateFactor:  ga.DefaultSuccessRateFactor,
+			ConvergenceEpsilon: ga.DefaultConvergenceEpsilon,
+		},
+	}
+}
+
+// LoadConfigJSON reads a GAConfig from JSON. Omitted fields take their default values,
+// and unknown fields are rejected.
+//
+// Parameters:
+// - r: the reader to read the JSON from.
+//
+// Returns:
+// - The loaded configuration.
+// - An error if the JSON is malformed or the configuration is invalid.
+func LoadConfigJSON(r io.Reader) (*GAConfig, error) {
+	cfg := DefaultConfig()
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decoding JSON config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadConfigYAML reads a GAConfig from YAML. Omitted fields take their default values,
+// and unknown fields are rejected.
+//
+// Parameters:
+// - r: the reader to read the YAML from.
+//
+// Returns:
+// - The loaded configuration.
+// - An error if the YAML is malformed or the configuration is invalid.
+func LoadConfigYAML(r io.Reader) (*GAConfig, error) {
+	cfg := DefaultConfig()
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decoding YAML config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that the numeric parameters of the configuration are within range.
+//
+// Returns:
+// - An error describing the first invalid parameter, or nil if the configuration is valid.
+func (cfg *GAConfig) Validate() error {
+	switch {
+	case cfg.PopulationSize <= 0:
+		return fmt.Errorf("populationSize must be positive, got %d", cfg.PopulationSize)
+	case cfg.Generations < 0:
+		return fmt.Errorf("generations must not be negative, got %d", cfg.Generations)
+	case cfg.CrossoverRate < 0 || cfg.CrossoverRate > 1:
+		return fmt.Errorf("crossoverRate must be in [0, 1], got %f", cfg.CrossoverRate)
+	case cfg.MutationRate < 0 || cfg.MutationRate > 1:
+		return fmt.Errorf("mutationRate must be in [0, 1], got %f", cfg.MutationRate)
+	case cfg.HallOfFameSize < 0:
+		return fmt.Errorf("hallOfFameSize must not be negative, got %d", cfg.HallOfFameSize)
+	case cfg.ImmigrationRate < 0 || cfg.ImmigrationRate > 1:
+		return fmt.Errorf("immigrationRate must be in [0, 1], got %f", cfg.ImmigrationRate)
+	case cfg.ImmigrationInterval < 0:
+		return fmt.Errorf("immigrationInterval must not be negative, got %d", cfg.ImmigrationInterval)
+	case cfg.RestartFraction < 0 || cfg.RestartFraction > 1:
+		return fmt.Errorf("restartFraction must be in [0, 1], got %f", cfg.RestartFraction)
+	case cfg.ElitismCount < 0 || cfg.ElitismCount > cfg.PopulationSize:
+		return fmt.Errorf("elitismCount must be in [0, populationSize], got %d", cfg.ElitismCount)
+	case cfg.AdaptiveParams.Strategy != "" && cfg.AdaptiveParams.Strategy != ga.AdaptiveStrategyDiversity && cfg.AdaptiveParams.Strategy != ga.AdaptiveStrategySuccessRate:
+		return fmt.Errorf("adaptiveParams.strategy must be %q or %q, got %q", ga.AdaptiveStrategyDiversity, ga.AdaptiveStrategySuccessRate, cfg.AdaptiveParams.Strategy)
+	case cfg.AdaptiveParams.SuccessRateFactor < 0 || cfg.AdaptiveParams.SuccessRateFactor > 1:
+		return fmt.Errorf("adaptiveParams.successRateFactor must be in [0, 1], got %f", cfg.AdaptiveParams.SuccessRateFactor)
+	case cfg.AdaptiveParams.MaxMutationRate < 0 || cfg.AdaptiveParams.MaxMutationRate > 1:
+		return fmt.Errorf("adaptiveParams.maxMutationRate must be in [0, 1], got %f", cfg.AdaptiveParams.MaxMutationRate)
+	case cfg.AdaptiveParams.ConvergenceEpsilon < 0:
+		return fmt.Errorf("adaptiveParams.convergenceEpsilon must not be negative, got %f", cfg.AdaptiveParams.ConvergenceEpsilon)
+	}
+	return nil
+}
+
+// Apply resolves the configured operators in the given registries and sets them, along with
+// all other parameters except PopulationSize, on the GA. PopulationSize is passed to
+// ga.GA.Initialize by the caller. If an operator cannot be resolved, an error is returned and
+// the GA is left unchanged.
+//
+// Parameters:
+// - g: the GA to configure.
+// - registry: the registry.OperatorRegistry of the GA's operator types, ga.OperatorRegistry,
+// to resolve the operator names in, or nil for ga.SelectionOperators, ga.CrossoverOperators,
+// and ga.MutationOperators.
+//
+// Returns:
+// - An error if the configuration is invalid or an operator name is not registered.
+func (cfg *GAConfig) Apply(g *ga.GA, registry *ga.OperatorRegistry) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	err := g.LoadConfigFrom(ga.OperatorConfig{
+		Selection: cfg.Selection,
+		Crossover: cfg.Crossover,
+		Mutation:  cfg.Mutation,
+	}, registry)
+	if err != nil {
+		return fmt.Errorf("applying config: %w", err)
+	}
+
+	g.Generations = cfg.Generations
+	g.CrossoverRate = cfg.CrossoverRate
+	g.MutationRate = cfg.MutationRate
+	g.EnableLogger = cfg.EnableLogger
+	g.HallOfFameSize = cfg.HallOfFameSize
+	g.ImmigrationRate = cfg.ImmigrationRate
+	g.ImmigrationInterval = cfg.ImmigrationInterval
+	g.MinDiversity = cfg.MinDiversity
+	g.RestartFraction = cfg.RestartFraction
+	g.ElitismCount = cfg.ElitismCount
+	g.AdaptiveStrategy = cfg.AdaptiveParams.Strategy
+	g.SuccessRateFactor = cfg.AdaptiveParams.SuccessRateFactor
+	g.MaxMutationRate = cfg.AdaptiveParams.MaxMutationRate
+	g.ConvergenceEpsilon = cfg.AdaptiveParams.ConvergenceEpsilon
+	return nil
+}
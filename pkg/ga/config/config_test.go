@@ -0,0 +1,186 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"github.com/Okabe-Junya/gago/pkg/ga/registry"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		name string
+		load func(string) (*GAConfig, error)
+		data string
+	}{
+		{
+			name: "JSON",
+			load: func(data string) (*GAConfig, error) { return LoadConfigJSON(strings.NewReader(data)) },
+			data: `{"populationSize": 20, "generations": 5, "mutationRate": 0.05, "crossover": "uniform", "elitismCount": 2, "adaptiveParams": {"strategy": "success_rate"}}`,
+		},
+		{
+			name: "YAML",
+			load: func(data string) (*GAConfig, error) { return LoadConfigYAML(strings.NewReader(data)) },
+			data: "populationSize: 20\ngenerations: 5\nmutationRate: 0.05\ncrossover: uniform\nelitismCount: 2\nadaptiveParams:\n  strategy: success_rate\n",
+		},
+	}
+
+	for _, tc := range cases {
+		cfg, err := tc.load(tc.data)
+		if err != nil {
+			t.Fatalf("%s: expected no error, but got %v", tc.name, err)
+		}
+
+		expected := DefaultConfig()
+		expected.PopulationSize = 20
+		expected.Generations = 5
+		expected.MutationRate = 0.05
+		expected.Crossover = "uniform"
+		expected.ElitismCount = 2
+		expected.AdaptiveParams.Strategy = ga.AdaptiveStrategySuccessRate
+		if *cfg != *expected {
+			t.Errorf("%s: expected config %+v, but got %+v", tc.name, *expected, *cfg)
+		}
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	cases := []struct {
+		name string
+		load func(string) (*GAConfig, error)
+	}{
+		{name: "JSON", load: func(data string) (*GAConfig, error) { return LoadConfigJSON(strings.NewReader(data)) }},
+		{name: "YAML", load: func(data string) (*GAConfig, error) { return LoadConfigYAML(strings.NewReader(data)) }},
+	}
+
+	for _, tc := range cases {
+		for _, data := range []string{"", "{}"} {
+			cfg, err := tc.load(data)
+			if err != nil {
+				t.Fatalf("%s: expected no error for %q, but got %v", tc.name, data, err)
+			}
+			if *cfg != *DefaultConfig() {
+				t.Errorf("%s: expected default config for %q, but got %+v", tc.name, data, *cfg)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("%s: expected default config to be valid, but got %v", tc.name, err)
+			}
+		}
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	cases := []struct {
+		data string
+	}{
+		{data: `{"populationSize": 0}`},
+		{data: `{"mutationRate": 1.5}`},
+		{data: `{"crossoverRate": -0.1}`},
+		{data: `{"populationSize": "ten"}`},
+		{data: `{"mutationRat": 0.1}`},
+		{data: `{"elitismCount": -1}`},
+		{data: `{"populationSize": 5, "elitismCount": 6}`},
+		{data: `{"adaptiveParams": {"strategy": "annealing"}}`},
+		{data: `{"adaptiveParams": {"successRateFactor": 1.5}}`},
+		{data: `{"adaptiveParams": {"maxMutationRate": -0.5}}`},
+		{data: `{"adaptiveParams": {"convergenceEpsilon": -1}}`},
+		{data: `{"adaptiveParams": {"strateg": "diversity"}}`},
+	}
+
+	for _, tc := range cases {
+		if _, err := LoadConfigJSON(strings.NewReader(tc.data)); err == nil {
+			t.Errorf("Expected an error for %s, but got nil", tc.data)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Generations = 3
+	cfg.HallOfFameSize = 2
+	cfg.ElitismCount = 1
+	cfg.AdaptiveParams = AdaptiveParams{
+		Strategy:           ga.AdaptiveStrategySuccessRate,
+		SuccessRateFactor:  0.9,
+		MaxMutationRate:    0.2,
+		ConvergenceEpsilon: 0.01,
+	}
+
+	g := &ga.GA{}
+	if err := cfg.Apply(g, nil); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if g.Selection == nil || g.Crossover == nil || g.Mutation == nil {
+		t.Fatalf("Expected all operators to be set")
+	}
+	if g.Generations != cfg.Generations || g.CrossoverRate != cfg.CrossoverRate || g.HallOfFameSize != cfg.HallOfFameSize || g.ElitismCount != cfg.ElitismCount {
+		t.Errorf("Expected GA parameters to match config %+v, but got %+v", *cfg, *g)
+	}
+	adaptive := AdaptiveParams{
+		Strategy:           g.AdaptiveStrategy,
+		SuccessRateFactor:  g.SuccessRateFactor,
+		MaxMutationRate:    g.MaxMutationRate,
+		ConvergenceEpsilon: g.ConvergenceEpsilon,
+	}
+	if adaptive != cfg.AdaptiveParams {
+		t.Errorf("Expected adaptive parameters %+v, but got %+v", cfg.AdaptiveParams, adaptive)
+	}
+
+	g.Initialize(cfg.PopulationSize, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) }, func(genotype *ga.Genotype) *ga.Phenotype {
+		return &ga.Phenotype{Fitness: float64(genotype.Genome[0])}
+	})
+	g.Evolve(func(genotype *ga.Genotype) *ga.Phenotype { return &ga.Phenotype{Fitness: float64(genotype.Genome[0])} })
+}
+
+func TestApplyUnknownOperator(t *testing.T) {
+	cases := []struct {
+		cfg     func(*GAConfig)
+		unknown string
+	}{
+		{cfg: func(cfg *GAConfig) { cfg.Selection = "lottery" }, unknown: "lottery"},
+		{cfg: func(cfg *GAConfig) { cfg.Crossover = "three_point" }, unknown: "three_point"},
+		{cfg: func(cfg *GAConfig) { cfg.Mutation = "scramble" }, unknown: "scramble"},
+	}
+
+	for _, tc := range cases {
+		cfg := DefaultConfig()
+		tc.cfg(cfg)
+
+		g := &ga.GA{}
+		err := cfg.Apply(g, nil)
+		if !errors.Is(err, registry.ErrUnknownOperator) || !strings.Contains(err.Error(), tc.unknown) {
+			t.Errorf("Expected a descriptive error naming %q, but got %v", tc.unknown, err)
+		}
+		if g.Generations != 0 || g.Selection != nil {
+			t.Errorf("Expected the GA to be unchanged after a failed apply")
+		}
+	}
+}
+
+func TestApplyRegistry(t *testing.T) {
+	mutated := false
+	mutations := registry.New[func([]*ga.Individual, float64)]("mutation")
+	mutations.Register("mark", func([]*ga.Individual, float64) { mutated = true })
+	operators := &registry.OperatorRegistry[func([]*ga.Individual) []*ga.Individual, func([]*ga.Individual, float64) []*ga.Individual, func([]*ga.Individual, float64)]{Mutation: mutations}
+
+	cfg := DefaultConfig()
+	cfg.Mutation = "mark"
+	g := &ga.GA{}
+	if err := cfg.Apply(g, operators); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if g.Selection == nil || g.Crossover == nil || g.Mutation == nil {
+		t.Fatalf("Expected all operators to be set")
+	}
+	g.Mutation(nil, 0)
+	if !mutated {
+		t.Errorf("Expected the mutation operator to be resolved in the given registry")
+	}
+
+	cfg.Mutation = "bit_flip"
+	if err := cfg.Apply(&ga.GA{}, operators); !errors.Is(err, registry.ErrUnknownOperator) {
+		t.Errorf("Expected an error wrapping %v for an operator missing from the given registry, but got %v", registry.ErrUnknownOperator, err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the detection of convergence and the switch to exploration it triggers.
+package ga
+
+// HasConverged reports whether the population has converged, that is, whether its diversity
+// is below ConvergenceEpsilon, as of the most recently recorded statistics.
+func (ga *GA) HasConverged() bool {
+	return ga.CurrentStatistics().Converged
+}
+
+// updateAdaptiveParams adapts MutationRate according to AdaptiveStrategy. With the diversity
+// strategy, it switches the GA to pure exploration, raising MutationRate to MaxMutationRate,
+// when the population has converged, and restores the previous MutationRate once it no longer
+// has; it does nothing unless MaxMutationRate is positive.
+func (ga *GA) updateAdaptiveParams() {
+	if ga.AdaptiveStrategy == AdaptiveStrategySuccessRate {
+		ga.applySuccessRule()
+		return
+	}
+	if ga.MaxMutationRate <= 0 {
+		return
+	}
+	converged := ga.HasConverged()
+	switch {
+	case converged && !ga.exploring:
+		ga.log("Population converged, switching to exploration", "MutationRate", ga.MaxMutationRate)
+		ga.baseMutationRate = ga.MutationRate
+		ga.MutationRate = ga.MaxMutationRate
+		ga.exploring = true
+	case !converged && ga.exploring:
+		ga.leaveExploration()
+	}
+}
+
+// leaveExploration restores the MutationRate in effect before the GA switched to pure
+// exploration, if it has.
+func (ga *GA) leaveExploration() {
+	if ga.exploring {
+		ga.MutationRate = ga.baseMutationRate
+		ga.exploring = false
+	}
+}
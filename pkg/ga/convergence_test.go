@@ -0,0 +1,59 @@
+package ga
+
+import "testing"
+
+func TestHasConverged(t *testing.T) {
+	cases := []struct {
+		name                 string
+		genotype             func() *Genotype
+		epsilon              float64
+		expectedConverged    bool
+		expectedMutationRate float64
+	}{
+		{name: "identical", genotype: func() *Genotype { return MustNewGenotype(64) }, expectedConverged: true, expectedMutationRate: 0.5},
+		{name: "diverse", genotype: func() *Genotype { return MustVariableLengthBinaryGenotype(64, 64) }, expectedConverged: false, expectedMutationRate: 0.01},
+		{name: "diverse with large epsilon", genotype: func() *Genotype { return MustVariableLengthBinaryGenotype(64, 64) }, epsilon: 100, expectedConverged: true, expectedMutationRate: 0.5},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:          func(population []*Individual) []*Individual { return population },
+			Crossover:          func(population []*Individual, _ float64) []*Individual { return population },
+			Mutation:           func([]*Individual, float64) {},
+			MutationRate:       0.01,
+			Generations:        1,
+			ConvergenceEpsilon: tc.epsilon,
+			MaxMutationRate:    0.5,
+		}
+		ga.Initialize(20, tc.genotype, oneMax)
+		ga.Evolve(oneMax)
+
+		if converged := ga.HasConverged(); converged != tc.expectedConverged {
+			t.Errorf("%s: Expected HasConverged to be %v, but got %v", tc.name, tc.expectedConverged, converged)
+		}
+		if ga.MutationRate != tc.expectedMutationRate {
+			t.Errorf("%s: Expected mutation rate %f, but got %f", tc.name, tc.expectedMutationRate, ga.MutationRate)
+		}
+	}
+}
+
+func TestLeaveExploration(t *testing.T) {
+	ga := &GA{
+		Selection:       func(population []*Individual) []*Individual { return population },
+		Crossover:       func(population []*Individual, _ float64) []*Individual { return population },
+		Mutation:        func([]*Individual, float64) {},
+		MutationRate:    0.01,
+		Generations:     1,
+		MaxMutationRate: 0.5,
+	}
+	ga.Initialize(20, func() *Genotype { return MustNewGenotype(64) }, oneMax)
+	ga.Evolve(oneMax)
+	if ga.MutationRate != ga.MaxMutationRate {
+		t.Fatalf("Expected mutation rate %f, but got %f", ga.MaxMutationRate, ga.MutationRate)
+	}
+
+	ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(64, 64) }, oneMax)
+	if ga.MutationRate != 0.01 {
+		t.Errorf("Expected Initialize to restore mutation rate %f, but got %f", 0.01, ga.MutationRate)
+	}
+}
@@ -2,7 +2,22 @@
 // including crossover operations for generating offspring from parent individuals.
 package ga
 
-import "math/rand"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// ErrTooManyCutPoints is returned by MultiPointCrossover when the number of cut points
+// is not less than the genome length, so that some segments would be empty.
+var ErrTooManyCutPoints = errors.New("number of cut points must be less than the genome length")
+
+// ErrNegativeCutPoints is returned by MultiPointCrossover when the number of cut points is
+// negative.
+var ErrNegativeCutPoints = errors.New("number of cut points must not be negative")
 
 // SinglePointCrossover performs a single-point crossover on the given population.
 //
@@ -17,32 +32,236 @@ import "math/rand"
 // Returns:
 // - A new population of offspring generated from the input population.
 func SinglePointCrossover(population []*Individual, crossoverRate float64) []*Individual {
+	return singlePointCrossover(population, crossoverRate, nil)
+}
+
+// singlePointCrossover performs a single-point crossover, passing the crossover point of each
+// crossed pair to record, if it is non-nil.
+func singlePointCrossover(population []*Individual, crossoverRate float64, record func(points ...int)) []*Individual {
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		if length := population[2*i].Genotype.Len(); length > 0 && rand.Float64() < crossoverRate {
+			point := rand.Intn(length)
+			child1, child2 := singlePointChildren(population[2*i].Genotype, population[2*i+1].Genotype, point)
+			if record != nil {
+				record(point)
+			}
+
+			offspring[2*i] = newOffspring(child1, population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(child2, population[2*i], population[2*i+1])
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// passUnpaired copies the last individual of an odd-sized population, which has no mate,
+// to the offspring unchanged.
+func passUnpaired(population, offspring []*Individual) {
+	if len(population)%2 == 1 {
+		offspring[len(offspring)-1] = population[len(population)-1]
+	}
+}
+
+// genomePool holds *[]byte genome buffers recycled by GA.recycleGenome, so that crossover
+// operators can reuse them for offspring genomes instead of allocating new ones.
+var genomePool sync.Pool
+
+// newGenome returns a genome of the given length, reusing a recycled buffer if one is large
+// enough. The contents of the genome are undefined.
+func newGenome(length int) []byte {
+	if buffer, ok := genomePool.Get().(*[]byte); ok && cap(*buffer) >= length {
+		return (*buffer)[:length]
+	}
+	return make([]byte, length)
+}
+
+// pooledClone returns a copy of the genotype whose genome is taken from genomePool.
+func pooledClone(g *Genotype) *Genotype {
+	clone := *g
+	clone.Genome = append(newGenome(len(g.Genome))[:0], g.Genome...)
+	if g.Float64Genome != nil {
+		clone.Float64Genome = append([]float64(nil), g.Float64Genome...)
+	}
+	if g.Int32Genome != nil {
+		clone.Int32Genome = append([]int32(nil), g.Int32Genome...)
+	}
+	return &clone
+}
+
+// singlePointChildren creates two offspring genotypes by exchanging the genes of the parents
+// after the given crossover point. Each child is a copy of one parent, so that it keeps the
+// encoding and bounds of its parents.
+func singlePointChildren(parent1, parent2 *Genotype, point int) (*Genotype, *Genotype) {
+	child1, child2 := pooledClone(parent1), pooledClone(parent2)
+	exchangeGenes(child1, child2, func(j int) bool { return j >= point })
+	return child1, child2
+}
+
+// exchangeGenes exchanges the gene at each position j shared by two genotypes of the same
+// encoding if swap(j) is true, operating on the genome that holds the genes of the encoding.
+func exchangeGenes(g1, g2 *Genotype, swap func(j int) bool) {
+	switch g1.GenomeType {
+	case Float64Encoding:
+		exchangeElements(g1.Float64Genome, g2.Float64Genome, swap)
+	case LargePermutationEncoding, IntegerEncoding:
+		exchangeElements(g1.Int32Genome, g2.Int32Genome, swap)
+	default:
+		exchangeElements(g1.Genome, g2.Genome, swap)
+	}
+}
+
+// exchangeElements exchanges the elements at each position j shared by a and b if swap(j) is true.
+func exchangeElements[T any](a, b []T, swap func(j int) bool) {
+	for j := 0; j < min(len(a), len(b)); j++ {
+		if swap(j) {
+			a[j], b[j] = b[j], a[j]
+		}
+	}
+}
+
+// MultiChromosomeSinglePointCrossover performs a single-point crossover on a population of
+// multi-chromosome individuals.
+//
+// Each chromosome of the first parent is crossed with the corresponding chromosome of the second
+// parent at its own random crossover point, independently of the other chromosomes.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func MultiChromosomeSinglePointCrossover(population []*Individual, crossoverRate float64) []*Individual {
 	offspring := make([]*Individual, len(population))
 
 	for i := 0; i < len(population)/2; i++ {
 		if rand.Float64() < crossoverRate {
-			parent1 := population[2*i].Genotype
-			parent2 := population[2*i+1].Genotype
-			point := rand.Intn(len(parent1.Genome))
+			parent1 := population[2*i].Chromosomes
+			parent2 := population[2*i+1].Chromosomes
+
+			children1 := make([]*Genotype, len(parent1))
+			children2 := make([]*Genotype, len(parent1))
+			for k := range parent1 {
+				children1[k], children2[k] = singlePointChildren(parent1[k], parent2[k], rand.Intn(max(parent1[k].Len(), 1)))
+			}
+
+			offspring[2*i] = newOffspring(nil, population[2*i], population[2*i+1])
+			offspring[2*i].Chromosomes = children1
+			offspring[2*i+1] = newOffspring(nil, population[2*i], population[2*i+1])
+			offspring[2*i+1].Chromosomes = children2
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// MultiPointCrossover performs an n-point crossover on the given population.
+//
+// In n-point crossover, numPoints distinct cut points are selected at random, dividing the
+// parents' genomes into numPoints+1 non-empty segments. The offspring are created by
+// exchanging every other segment, starting with the second.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+// - numPoints: the number of cut points.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+// - An error wrapping ErrNegativeCutPoints if numPoints is negative, or ErrTooManyCutPoints if
+// numPoints is not less than the genome length of a pair of parents.
+func MultiPointCrossover(population []*Individual, crossoverRate float64, numPoints int) ([]*Individual, error) {
+	return multiPointCrossover(population, crossoverRate, numPoints, nil)
+}
+
+// multiPointCrossover performs an n-point crossover, passing the cut points of each crossed
+// pair to record, if it is non-nil.
+func multiPointCrossover(population []*Individual, crossoverRate float64, numPoints int, record func(points ...int)) ([]*Individual, error) {
+	if numPoints < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrNegativeCutPoints, numPoints)
+	}
+	for i := 0; i < len(population)/2; i++ {
+		length := min(population[2*i].Genotype.Len(), population[2*i+1].Genotype.Len())
+		if numPoints >= length {
+			return nil, fmt.Errorf("%w: %d cut points, genome length %d", ErrTooManyCutPoints, numPoints, length)
+		}
+	}
 
-			child1 := &Genotype{Genome: make([]byte, len(parent1.Genome))}
-			child2 := &Genotype{Genome: make([]byte, len(parent1.Genome))}
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		if rand.Float64() < crossoverRate {
+			child1 := pooledClone(population[2*i].Genotype)
+			child2 := pooledClone(population[2*i+1].Genotype)
 
-			copy(child1.Genome[:point], parent1.Genome[:point])
-			copy(child1.Genome[point:], parent2.Genome[point:])
-			copy(child2.Genome[:point], parent2.Genome[:point])
-			copy(child2.Genome[point:], parent1.Genome[point:])
+			points := cutPoints(min(child1.Len(), child2.Len()), numPoints)
+			if record != nil {
+				record(points[:numPoints]...)
+			}
+			// Genes in the odd-numbered segments, after an odd number of cut points, are exchanged.
+			exchangeGenes(child1, child2, func(j int) bool {
+				return sort.SearchInts(points, j+1)%2 == 1
+			})
 
-			offspring[2*i] = &Individual{Genotype: child1}
-			offspring[2*i+1] = &Individual{Genotype: child2}
+			offspring[2*i] = newOffspring(child1, population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(child2, population[2*i], population[2*i+1])
 		} else {
 			offspring[2*i] = population[2*i]
 			offspring[2*i+1] = population[2*i+1]
 		}
 	}
+	passUnpaired(population, offspring)
+	return offspring, nil
+}
+
+// TwoPointCrossover performs a two-point crossover on the given population, exchanging
+// the segment of the parents' genomes between two distinct random cut points. Pairs whose
+// genomes have fewer than three genes are passed through unchanged.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func TwoPointCrossover(population []*Individual, crossoverRate float64) []*Individual {
+	offspring := make([]*Individual, len(population))
+	for i := 0; i < len(population)/2; i++ {
+		pair, err := MultiPointCrossover(population[2*i:2*i+2], crossoverRate, 2)
+		if err != nil {
+			pair = population[2*i : 2*i+2]
+		}
+		copy(offspring[2*i:], pair)
+	}
+	passUnpaired(population, offspring)
 	return offspring
 }
 
+// cutPoints samples numPoints distinct cut points from the genome positions 1 to length-1
+// with a partial Fisher-Yates shuffle, and returns them in ascending order followed by length,
+// so that consecutive elements delimit the segments after the first.
+func cutPoints(length, numPoints int) []int {
+	positions := make([]int, length-1)
+	for i := range positions {
+		positions[i] = i + 1
+	}
+	for i := 0; i < numPoints; i++ {
+		j := i + rand.Intn(len(positions)-i)
+		positions[i], positions[j] = positions[j], positions[i]
+	}
+	points := append(positions[:numPoints:numPoints], length)
+	sort.Ints(points)
+	return points
+}
+
 // UniformCrossover performs a uniform crossover on the given population.
 //
 // In uniform crossover, each gene from the parent individuals is independently
@@ -56,6 +275,229 @@ func SinglePointCrossover(population []*Individual, crossoverRate float64) []*In
 // Returns:
 // - A new population of offspring generated from the input population.
 func UniformCrossover(population []*Individual, crossoverRate float64) []*Individual {
+	return uniformCrossover(population, crossoverRate, func(int) float64 { return 0.5 })
+}
+
+// PositionalUniformCrossover performs a uniform crossover with position-specific rates on the
+// given population.
+//
+// Every pair of parents is crossed, and the gene at each position i is exchanged between the
+// offspring with probability rates[i] instead of 50%, so that positions with a rate of 0 are
+// always inherited from the same parent. Positions beyond the end of rates are exchanged
+// with a 50% probability, as in UniformCrossover.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - rates: the probability with which the gene at each position is exchanged.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func PositionalUniformCrossover(population []*Individual, rates []float64) []*Individual {
+	return uniformCrossover(population, 1.0, func(j int) float64 {
+		if j < len(rates) {
+			return rates[j]
+		}
+		return 0.5
+	})
+}
+
+// uniformCrossover crosses each pair of parents with probability crossoverRate, exchanging
+// the gene at each position j between the offspring with probability exchange(j).
+func uniformCrossover(population []*Individual, crossoverRate float64, exchange func(j int) float64) []*Individual {
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		if rand.Float64() < crossoverRate {
+			child1 := pooledClone(population[2*i].Genotype)
+			child2 := pooledClone(population[2*i+1].Genotype)
+
+			exchangeGenes(child1, child2, func(j int) bool { return rand.Float64() < exchange(j) })
+
+			offspring[2*i] = newOffspring(child1, population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(child2, population[2*i], population[2*i+1])
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// CrossoverHeatMap estimates from the history of a binary-encoded GA how much each gene
+// position benefits from crossover, as a rate suitable for GA.CrossoverRateMap.
+//
+// Statistics do not record the parents of the offspring, so the benefit is estimated from
+// the AlleleFrequencies of consecutive generations: positions whose allele frequency moved
+// the most over the history are those where selection favored the genes of one parent over
+// the other, and are given the highest rates. The mean absolute change in allele frequency of
+// each position is scaled so that the most active position has a rate of 1. Generations
+// without allele frequencies, or whose genomes are shorter than genomeLength, are skipped.
+//
+// Parameters:
+// - history: the statistics of successive generations, such as GA.GetHistory.
+// - genomeLength: the number of gene positions.
+//
+// Returns:
+// - The rate of each position in [0, 1], all zero if no allele frequency changed.
+func CrossoverHeatMap(history []*Statistics, genomeLength int) []float64 {
+	heat := make([]float64, genomeLength)
+	var previous []float64
+	for _, stats := range history {
+		frequencies := stats.AlleleFrequencies
+		if len(frequencies) < genomeLength {
+			continue
+		}
+		if previous != nil {
+			for j := range heat {
+				heat[j] += math.Abs(frequencies[j] - previous[j])
+			}
+		}
+		previous = frequencies
+	}
+
+	hottest := 0.0
+	for _, h := range heat {
+		hottest = math.Max(hottest, h)
+	}
+	if hottest > 0 {
+		for j := range heat {
+			heat[j] /= hottest
+		}
+	}
+	return heat
+}
+
+// VariableLengthCrossover performs a crossover on variable-length genomes.
+//
+// A crossover point is chosen independently for each parent, and the offspring are
+// created by exchanging the segments after these points. As a result, the offspring
+// may differ in length from each other and from their parents. Offspring longer than
+// the parent's MaxLength are truncated to it.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func VariableLengthCrossover(population []*Individual, crossoverRate float64) []*Individual {
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		if rand.Float64() < crossoverRate {
+			parent1 := population[2*i].Genotype
+			parent2 := population[2*i+1].Genotype
+			point1 := rand.Intn(len(parent1.Genome))
+			point2 := rand.Intn(len(parent2.Genome))
+
+			child1 := &Genotype{MaxLength: parent1.MaxLength}
+			child2 := &Genotype{MaxLength: parent2.MaxLength}
+
+			child1.Genome = append(append(child1.Genome, parent1.Genome[:point1]...), parent2.Genome[point2:]...)
+			child2.Genome = append(append(child2.Genome, parent2.Genome[:point2]...), parent1.Genome[point1:]...)
+
+			if child1.MaxLength > 0 && len(child1.Genome) > child1.MaxLength {
+				child1.Genome = child1.Genome[:child1.MaxLength]
+			}
+			if child2.MaxLength > 0 && len(child2.Genome) > child2.MaxLength {
+				child2.Genome = child2.Genome[:child2.MaxLength]
+			}
+
+			offspring[2*i] = newOffspring(child1, population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(child2, population[2*i], population[2*i+1])
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// BlendCrossover performs a blend crossover (BLX-alpha) on a population of real-valued genotypes.
+//
+// For each pair of parent genes p1 and p2, the offspring genes are sampled uniformly from the
+// interval [min(p1,p2) - alpha*|p1-p2|, max(p1,p2) + alpha*|p1-p2|] and clamped to the gene
+// bounds. The alpha parameter, typically 0.5, controls how far beyond the parents the offspring
+// may explore; with alpha equal to zero the offspring always lie between the parents.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+// - alpha: the fraction of the parents' distance by which the sampling interval is extended.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func BlendCrossover(population []*Individual, crossoverRate float64, alpha float64) []*Individual {
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		if rand.Float64() < crossoverRate {
+			parent1 := population[2*i].Genotype
+			parent2 := population[2*i+1].Genotype
+
+			child1 := parent1.Clone()
+			child2 := parent2.Clone()
+
+			for j := 0; j < parent1.Len(); j++ {
+				p1 := parent1.GetRealValue(j)
+				p2 := parent2.GetRealValue(j)
+				d := math.Abs(p1 - p2)
+				lower := math.Min(p1, p2) - alpha*d
+				upper := math.Max(p1, p2) + alpha*d
+
+				child1.SetRealValue(j, lower+rand.Float64()*(upper-lower))
+				child2.SetRealValue(j, lower+rand.Float64()*(upper-lower))
+			}
+
+			offspring[2*i] = newOffspring(child1, population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(child2, population[2*i], population[2*i+1])
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// ArithmeticCrossover performs an arithmetic crossover on a population of real-valued genotypes.
+//
+// For each pair of parent genes p1 and p2, the offspring genes are alpha*p1 + (1-alpha)*p2 and
+// (1-alpha)*p1 + alpha*p2, clamped to the gene bounds. An alpha of 0.5 makes both offspring the
+// average of their parents. If alpha is negative, a new alpha is sampled uniformly from [0, 1]
+// for every gene pair.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+// - alpha: the weight of the first parent in the first offspring, or a negative value to sample it per gene.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func ArithmeticCrossover(population []*Individual, crossoverRate float64, alpha float64) []*Individual {
+	return arithmeticCrossover(population, crossoverRate, alpha, true)
+}
+
+// WholeArithmeticCrossover performs a whole arithmetic crossover on a population of real-valued
+// genotypes. It behaves like ArithmeticCrossover, except that a negative alpha is sampled once
+// per pair of parents and applied to every gene.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+// - alpha: the weight of the first parent in the first offspring, or a negative value to sample it per pair.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func WholeArithmeticCrossover(population []*Individual, crossoverRate float64, alpha float64) []*Individual {
+	return arithmeticCrossover(population, crossoverRate, alpha, false)
+}
+
+// arithmeticCrossover implements ArithmeticCrossover and WholeArithmeticCrossover.
+// If perGene is set, a negative alpha is sampled for every gene pair rather than for every pair of parents.
+func arithmeticCrossover(population []*Individual, crossoverRate float64, alpha float64, perGene bool) []*Individual {
 	offspring := make([]*Individual, len(population))
 
 	for i := 0; i < len(population)/2; i++ {
@@ -63,25 +505,496 @@ func UniformCrossover(population []*Individual, crossoverRate float64) []*Indivi
 			parent1 := population[2*i].Genotype
 			parent2 := population[2*i+1].Genotype
 
-			child1 := &Genotype{Genome: make([]byte, len(parent1.Genome))}
-			child2 := &Genotype{Genome: make([]byte, len(parent1.Genome))}
+			child1 := parent1.Clone()
+			child2 := parent2.Clone()
 
-			for j := range parent1.Genome {
-				if rand.Float64() < 0.5 {
-					child1.Genome[j] = parent1.Genome[j]
-					child2.Genome[j] = parent2.Genome[j]
-				} else {
-					child1.Genome[j] = parent2.Genome[j]
-					child2.Genome[j] = parent1.Genome[j]
+			weight := alpha
+			if alpha < 0 {
+				weight = rand.Float64()
+			}
+			for j := 0; j < parent1.Len(); j++ {
+				if alpha < 0 && perGene {
+					weight = rand.Float64()
 				}
+				p1 := parent1.GetRealValue(j)
+				p2 := parent2.GetRealValue(j)
+
+				child1.SetRealValue(j, weight*p1+(1-weight)*p2)
+				child2.SetRealValue(j, (1-weight)*p1+weight*p2)
+			}
+
+			offspring[2*i] = newOffspring(child1, population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(child2, population[2*i], population[2*i+1])
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// OrderBasedCrossover performs an order crossover (OX) on a population of permutation-encoded
+// genomes, including genotypes with LargePermutationEncoding.
+//
+// In order crossover, the first offspring copies the segment of the first parent between two
+// random cut points and fills the remaining positions, starting after the segment and wrapping
+// around, with the missing genes in the order they appear in the second parent from the same
+// position; the second offspring is built the same way with the parents' roles reversed. Every
+// value of the permutation remains present exactly once.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func OrderBasedCrossover(population []*Individual, crossoverRate float64) []*Individual {
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		parent1 := population[2*i].Genotype
+		parent2 := population[2*i+1].Genotype
+		if rand.Float64() < crossoverRate && parent1.Len() > 1 {
+			start, end := rand.Intn(parent1.Len()), rand.Intn(parent1.Len())
+			if start > end {
+				start, end = end, start
+			}
+
+			child1, child2 := parent1.Clone(), parent2.Clone()
+			if parent1.GenomeType == LargePermutationEncoding {
+				child1.Int32Genome = orderChild(parent1.Int32Genome, parent2.Int32Genome, start, end+1)
+				child2.Int32Genome = orderChild(parent2.Int32Genome, parent1.Int32Genome, start, end+1)
+			} else {
+				child1.Genome = orderChild(parent1.Genome, parent2.Genome, start, end+1)
+				child2.Genome = orderChild(parent2.Genome, parent1.Genome, start, end+1)
+			}
+
+			offspring[2*i] = newOffspring(child1, population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(child2, population[2*i], population[2*i+1])
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// orderChild builds an order crossover offspring that keeps parent1[start:end] and fills the
+// remaining positions, from end onwards and wrapping around, with the genes of parent2 not in
+// the segment, in the order they appear in parent2 from end onwards.
+func orderChild[T comparable](parent1, parent2 []T, start, end int) []T {
+	n := len(parent1)
+	child := make([]T, n)
+	kept := make(map[T]bool, end-start)
+	for j := start; j < end; j++ {
+		child[j] = parent1[j]
+		kept[parent1[j]] = true
+	}
+
+	position := end % n
+	for k := 0; k < n; k++ {
+		gene := parent2[(end+k)%n]
+		if kept[gene] {
+			continue
+		}
+		child[position] = gene
+		position = (position + 1) % n
+	}
+	return child
+}
+
+// PositionBasedCrossover performs a position-based crossover (PBX) on a population of
+// permutation-encoded genomes, including genotypes with LargePermutationEncoding.
+//
+// In position-based crossover, each position is independently selected with a 50% probability.
+// The first offspring copies the genes at the selected positions from the first parent and fills
+// the remaining positions with the missing genes in the order they appear in the second parent;
+// the second offspring is built the same way with the parents' roles reversed. Every value of
+// the permutation remains present exactly once.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func PositionBasedCrossover(population []*Individual, crossoverRate float64) []*Individual {
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		if rand.Float64() < crossoverRate {
+			parent1 := population[2*i].Genotype
+			parent2 := population[2*i+1].Genotype
+
+			selected := make([]bool, parent1.Len())
+			for j := range selected {
+				selected[j] = rand.Intn(2) == 0
 			}
 
-			offspring[2*i] = &Individual{Genotype: child1}
-			offspring[2*i+1] = &Individual{Genotype: child2}
+			offspring[2*i] = newOffspring(positionBasedChild(parent1, parent2, selected), population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(positionBasedChild(parent2, parent1, selected), population[2*i], population[2*i+1])
+		} else {
+			offspring[2*i] = population[2*i]
+			offspring[2*i+1] = population[2*i+1]
+		}
+	}
+	passUnpaired(population, offspring)
+	return offspring
+}
+
+// positionBasedChild builds a position-based crossover offspring that keeps the genes of
+// parent1 at the selected positions and takes the remaining genes in the order they appear
+// in parent2.
+func positionBasedChild(parent1, parent2 *Genotype, selected []bool) *Genotype {
+	child := parent1.Clone()
+	if parent1.GenomeType == LargePermutationEncoding {
+		fillPositionBased(child.Int32Genome, parent1.Int32Genome, parent2.Int32Genome, selected)
+	} else {
+		fillPositionBased(child.Genome, parent1.Genome, parent2.Genome, selected)
+	}
+	return child
+}
+
+// fillPositionBased fills the positions of child that are not selected with the genes of
+// parent2 that parent1 does not hold at a selected position, in the order they appear in
+// parent2. The selected positions of child must already hold the genes of parent1.
+func fillPositionBased[T comparable](child, parent1, parent2 []T, selected []bool) {
+	kept := make(map[T]bool, len(child))
+	for j, gene := range parent1 {
+		if selected[j] {
+			kept[gene] = true
+		}
+	}
+
+	next := 0
+	for j := range child {
+		if selected[j] {
+			continue
+		}
+		for kept[parent2[next]] {
+			next++
+		}
+		child[j] = parent2[next]
+		next++
+	}
+}
+
+// EdgeAssemblyCrossover performs an edge assembly crossover (EAX) on a population of
+// permutation-encoded tours, such as those of the traveling salesman problem, including
+// genotypes with LargePermutationEncoding.
+//
+// For each pair of parent tours A and B, the edges of both parents are decomposed into
+// AB-cycles, which alternate between edges of A and edges of B. The offspring starts from the
+// edges of A, and for a random, non-empty subset of the AB-cycles, the A edges of each cycle are
+// replaced by its B edges. This yields a set of subtours, which are merged into a single tour by
+// repeatedly exchanging a pair of edges between the smallest subtour and another one, preferring
+// exchanges that introduce edges of the parents. The second offspring is built the same way with
+// the parents' roles reversed. Since no distances are known to the operator, the exchanges are
+// otherwise chosen at random.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - crossoverRate: the probability with which crossover will occur.
+//
+// Returns:
+// - A new population of offspring generated from the input population.
+func EdgeAssemblyCrossover(population []*Individual, crossoverRate float64) []*Individual {
+	offspring := make([]*Individual, len(population))
+
+	for i := 0; i < len(population)/2; i++ {
+		if rand.Float64() < crossoverRate {
+			parent1 := population[2*i].Genotype
+			parent2 := population[2*i+1].Genotype
+
+			offspring[2*i] = newOffspring(edgeAssemblyChild(parent1, parent2), population[2*i], population[2*i+1])
+			offspring[2*i+1] = newOffspring(edgeAssemblyChild(parent2, parent1), population[2*i], population[2*i+1])
 		} else {
 			offspring[2*i] = population[2*i]
 			offspring[2*i+1] = population[2*i+1]
 		}
 	}
+	passUnpaired(population, offspring)
 	return offspring
 }
+
+// BuildEdgeTable returns the edge table of a tour, mapping each city to its two neighbors,
+// the preceding and the following city, in the tour. The tour is closed, so the first and
+// last cities are neighbors.
+//
+// Parameters:
+// - permutation: the order in which the tour visits the cities.
+//
+// Returns:
+// - The edge table of the tour.
+func BuildEdgeTable(permutation []int) map[int][]int {
+	edgeTable := make(map[int][]int, len(permutation))
+	for i, city := range permutation {
+		previous := permutation[(i+len(permutation)-1)%len(permutation)]
+		next := permutation[(i+1)%len(permutation)]
+		edgeTable[city] = []int{previous, next}
+	}
+	return edgeTable
+}
+
+// FindABCycles decomposes the edges of two tours A and B into AB-cycles, closed walks that
+// alternate between an edge of A and an edge of B. Edges shared by both tours are ignored.
+//
+// The edge table must map each city to its two neighbors in A followed by its two neighbors
+// in B, as obtained by appending the entries of the BuildEdgeTable results for B to those for A.
+//
+// Parameters:
+// - edgeTable: the combined edge table of tours A and B.
+//
+// Returns:
+// - The AB-cycles. Each cycle lists its cities in order, such that the edge from the first
+// city to the second belongs to A, the edge from the second to the third belongs to B, and so
+// on, with the closing edge from the last city to the first belonging to B.
+func FindABCycles(edgeTable map[int][]int) [][]int {
+	cities := make([]int, 0, len(edgeTable))
+	for city := range edgeTable {
+		cities = append(cities, city)
+	}
+	sort.Ints(cities)
+
+	// Collect the unused A and B edges of each city, dropping the shared ones.
+	unused := [2]map[int][]int{make(map[int][]int), make(map[int][]int)}
+	for _, city := range cities {
+		neighbors := edgeTable[city]
+		a, b := append([]int(nil), neighbors[:2]...), append([]int(nil), neighbors[2:4]...)
+		for _, n := range neighbors[:2] {
+			if containsCity(b, n) {
+				a = removeCity(a, n)
+				b = removeCity(b, n)
+			}
+		}
+		unused[0][city], unused[1][city] = a, b
+	}
+
+	var cycles [][]int
+	for _, start := range cities {
+		// path[j] to path[j+1] is an A edge for even j and a B edge for odd j.
+		var path []int
+		for len(unused[0][start]) > 0 || len(path) > 1 {
+			if len(path) == 0 {
+				path = []int{start}
+			}
+			current := path[len(path)-1]
+			side := (len(path) - 1) % 2
+			next := unused[side][current][rand.Intn(len(unused[side][current]))]
+			unused[side][current] = removeCity(unused[side][current], next)
+			unused[side][next] = removeCity(unused[side][next], current)
+			path = append(path, next)
+
+			if side == 1 {
+				// Having just taken a B edge, the walk closes an AB-cycle if it returns to a
+				// city from which it previously left along an A edge.
+				for j := len(path) - 3; j >= 0; j -= 2 {
+					if path[j] == next {
+						cycles = append(cycles, append([]int(nil), path[j:len(path)-1]...))
+						path = path[:j+1]
+						break
+					}
+				}
+			}
+			if len(path) == 1 && len(unused[0][start]) == 0 {
+				break
+			}
+		}
+	}
+	return cycles
+}
+
+// edgeAssemblyChild builds an edge assembly crossover offspring from parents a and b.
+func edgeAssemblyChild(a, b *Genotype) *Genotype {
+	child := a.Clone()
+	tourA, tourB := genotypeToTour(a), genotypeToTour(b)
+	if len(tourA) < 3 {
+		return child
+	}
+	tableA, tableB := BuildEdgeTable(tourA), BuildEdgeTable(tourB)
+
+	combined := make(map[int][]int, len(tableA))
+	for city, neighbors := range tableA {
+		combined[city] = append(append([]int(nil), neighbors...), tableB[city]...)
+	}
+	cycles := FindABCycles(combined)
+	if len(cycles) == 0 {
+		return child
+	}
+
+	// Replace the A edges of a random, non-empty subset of the AB-cycles by their B edges.
+	adjacency := make(map[int][]int, len(tableA))
+	for city, neighbors := range tableA {
+		adjacency[city] = append([]int(nil), neighbors...)
+	}
+	forced := rand.Intn(len(cycles))
+	for k, cycle := range cycles {
+		if k != forced && rand.Intn(2) == 0 {
+			continue
+		}
+		for j := range cycle {
+			u, v := cycle[j], cycle[(j+1)%len(cycle)]
+			if j%2 == 0 {
+				adjacency[u] = removeCity(adjacency[u], v)
+				adjacency[v] = removeCity(adjacency[v], u)
+			} else {
+				adjacency[u] = append(adjacency[u], v)
+				adjacency[v] = append(adjacency[v], u)
+			}
+		}
+	}
+
+	tour := mergeSubtours(adjacency, tourA[0], func(u, v int) bool {
+		return containsCity(tableA[u], v) || containsCity(tableB[u], v)
+	})
+	for j, city := range tour {
+		if child.GenomeType == LargePermutationEncoding {
+			child.Int32Genome[j] = int32(city)
+		} else {
+			child.Genome[j] = byte(city)
+		}
+	}
+	return child
+}
+
+// mergeSubtours merges the subtours described by an adjacency map, in which every city has
+// exactly two neighbors, into a single tour. Subtours are merged by exchanging an edge of the
+// smallest subtour and an edge of another subtour for two edges connecting them, preferring
+// exchanges whose new edges are parental edges as reported by isParentEdge.
+//
+// Returns:
+// - The merged tour, starting at the given city.
+func mergeSubtours(adjacency map[int][]int, start int, isParentEdge func(u, v int) bool) []int {
+	for {
+		subtours := findSubtours(adjacency)
+		if len(subtours) == 1 {
+			return walkTour(adjacency, start)
+		}
+		smallest := 0
+		for k, subtour := range subtours {
+			if len(subtour) < len(subtours[smallest]) {
+				smallest = k
+			}
+		}
+
+		type exchange struct{ s1, s2, t1, t2 int }
+		var best []exchange
+		bestScore := -1
+		for _, s := range pairsOfTour(subtours[smallest]) {
+			for k, other := range subtours {
+				if k == smallest {
+					continue
+				}
+				for _, t := range pairsOfTour(other) {
+					for _, e := range []exchange{{s[0], s[1], t[0], t[1]}, {s[0], s[1], t[1], t[0]}} {
+						score := 0
+						if isParentEdge(e.s1, e.t1) {
+							score++
+						}
+						if isParentEdge(e.s2, e.t2) {
+							score++
+						}
+						if score > bestScore {
+							best, bestScore = nil, score
+						}
+						if score == bestScore {
+							best = append(best, e)
+						}
+					}
+				}
+			}
+		}
+
+		e := best[rand.Intn(len(best))]
+		adjacency[e.s1] = append(removeCity(adjacency[e.s1], e.s2), e.t1)
+		adjacency[e.s2] = append(removeCity(adjacency[e.s2], e.s1), e.t2)
+		adjacency[e.t1] = append(removeCity(adjacency[e.t1], e.t2), e.s1)
+		adjacency[e.t2] = append(removeCity(adjacency[e.t2], e.t1), e.s2)
+	}
+}
+
+// findSubtours returns the subtours described by an adjacency map, each in visiting order.
+func findSubtours(adjacency map[int][]int) [][]int {
+	cities := make([]int, 0, len(adjacency))
+	for city := range adjacency {
+		cities = append(cities, city)
+	}
+	sort.Ints(cities)
+
+	visited := make(map[int]bool, len(adjacency))
+	var subtours [][]int
+	for _, city := range cities {
+		if visited[city] {
+			continue
+		}
+		subtour := walkTour(adjacency, city)
+		for _, c := range subtour {
+			visited[c] = true
+		}
+		subtours = append(subtours, subtour)
+	}
+	return subtours
+}
+
+// walkTour follows the adjacency map from the given city until it returns to it.
+func walkTour(adjacency map[int][]int, start int) []int {
+	tour := []int{start}
+	previous, current := start, adjacency[start][0]
+	for current != start {
+		tour = append(tour, current)
+		next := adjacency[current][0]
+		if next == previous {
+			next = adjacency[current][1]
+		}
+		previous, current = current, next
+	}
+	return tour
+}
+
+// pairsOfTour returns the edges of a closed tour as pairs of consecutive cities.
+func pairsOfTour(tour []int) [][2]int {
+	pairs := make([][2]int, len(tour))
+	for i, city := range tour {
+		pairs[i] = [2]int{city, tour[(i+1)%len(tour)]}
+	}
+	return pairs
+}
+
+// genotypeToTour converts a permutation-encoded genotype into a tour of city indices.
+func genotypeToTour(g *Genotype) []int {
+	if g.GenomeType == LargePermutationEncoding {
+		return genomeToTour(g.Int32Genome)
+	}
+	return genomeToTour(g.Genome)
+}
+
+// genomeToTour converts a permutation-encoded genome into a tour of city indices.
+func genomeToTour[T byte | int32](genome []T) []int {
+	tour := make([]int, len(genome))
+	for i, gene := range genome {
+		tour[i] = int(gene)
+	}
+	return tour
+}
+
+// containsCity reports whether the city is in the given list of cities.
+func containsCity(cities []int, city int) bool {
+	for _, c := range cities {
+		if c == city {
+			return true
+		}
+	}
+	return false
+}
+
+// removeCity returns the list of cities with the first occurrence of city removed.
+func removeCity(cities []int, city int) []int {
+	for i, c := range cities {
+		if c == city {
+			return append(cities[:i:i], cities[i+1:]...)
+		}
+	}
+	return cities
+}
@@ -1,7 +1,12 @@
 package ga
 
 import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -90,3 +95,727 @@ func TestUniformCrossover(t *testing.T) {
 		}
 	}
 }
+
+func TestPositionalUniformCrossover(t *testing.T) {
+	cases := []struct {
+		name     string
+		rates    []float64
+		expected [][]byte
+	}{
+		{name: "all zeros", rates: []float64{0, 0, 0, 0}, expected: [][]byte{{1, 1, 1, 1}, {0, 0, 0, 0}}},
+		{name: "all ones", rates: []float64{1, 1, 1, 1}, expected: [][]byte{{0, 0, 0, 0}, {1, 1, 1, 1}}},
+		{name: "mixed", rates: []float64{0, 1, 0, 1}, expected: [][]byte{{1, 0, 1, 0}, {0, 1, 0, 1}}},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{CrossoverRateMap: tc.rates}
+		population := []*Individual{
+			{Genotype: &Genotype{Genome: []byte{1, 1, 1, 1}}},
+			{Genotype: &Genotype{Genome: []byte{0, 0, 0, 0}}},
+		}
+
+		offspring := ga.UniformCrossover()(population, 0.0)
+
+		for i, ind := range offspring {
+			if !reflect.DeepEqual(ind.Genotype.Genome, tc.expected[i]) {
+				t.Errorf("%s: Expected offspring %d to have genome %v, but got %v", tc.name, i, tc.expected[i], ind.Genotype.Genome)
+			}
+		}
+	}
+}
+
+func TestCrossoverHeatMap(t *testing.T) {
+	history := []*Statistics{
+		{AlleleFrequencies: []float64{0.5, 0.5, 0.5}},
+		{},
+		{AlleleFrequencies: []float64{0.5, 0.7, 0.9}},
+		{AlleleFrequencies: []float64{0.5, 0.8, 1.0}},
+	}
+	expected := []float64{0, 0.6, 1}
+
+	heat := CrossoverHeatMap(history, 3)
+
+	for j := range expected {
+		if math.Abs(heat[j]-expected[j]) > 1e-9 {
+			t.Errorf("Expected heat %f at position %d, but got %f", expected[j], j, heat[j])
+		}
+	}
+}
+
+func TestVariableLengthCrossover(t *testing.T) {
+	const maxLength = 8
+
+	population := make([]*Individual, 20)
+	for i := range population {
+		population[i] = &Individual{Genotype: MustVariableLengthBinaryGenotype(1+i%maxLength, maxLength)}
+	}
+
+	for gen := 0; gen < 50; gen++ {
+		population = VariableLengthCrossover(population, 1.0)
+
+		for i, ind := range population {
+			length := len(ind.Genotype.Genome)
+			if length < 1 || length > maxLength {
+				t.Fatalf("Expected genome length in [1, %d], but got %d for individual %d", maxLength, length, i)
+			}
+		}
+	}
+}
+
+func TestBlendCrossover(t *testing.T) {
+	minValues := []float64{0, -5, 10}
+	maxValues := []float64{1, 5, 20}
+
+	population := make([]*Individual, 10)
+	for i := range population {
+		population[i] = &Individual{Genotype: MustNewRealGenotype(len(minValues), minValues, maxValues)}
+	}
+
+	offspring := BlendCrossover(population, 1.0, 0.0)
+
+	if len(offspring) != len(population) {
+		t.Fatalf("Expected offspring length %d, but got %d", len(population), len(offspring))
+	}
+
+	for i := 0; i < len(population)/2; i++ {
+		parent1 := population[2*i].Genotype
+		parent2 := population[2*i+1].Genotype
+		for _, child := range []*Genotype{offspring[2*i].Genotype, offspring[2*i+1].Genotype} {
+			for j := range child.Genome {
+				lower := math.Min(parent1.GetRealValue(j), parent2.GetRealValue(j))
+				upper := math.Max(parent1.GetRealValue(j), parent2.GetRealValue(j))
+				if value := child.GetRealValue(j); value < lower || value > upper {
+					t.Errorf("Expected gene %d of pair %d in [%f, %f], but got %f", j, i, lower, upper, value)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkBlendCrossover(b *testing.B) {
+	const (
+		genomeLength   = 100
+		populationSize = 1000
+	)
+
+	minValues := make([]float64, genomeLength)
+	maxValues := make([]float64, genomeLength)
+	for i := range maxValues {
+		maxValues[i] = 1
+	}
+	population := make([]*Individual, populationSize)
+	for i := range population {
+		population[i] = &Individual{Genotype: MustNewRealGenotype(genomeLength, minValues, maxValues)}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BlendCrossover(population, 1.0, 0.5)
+	}
+}
+
+func TestArithmeticCrossover(t *testing.T) {
+	minValues := []float64{0, 0, 0}
+	maxValues := []float64{10, 10, 10}
+
+	cases := []struct {
+		name      string
+		crossover func([]*Individual, float64, float64) []*Individual
+	}{
+		{name: "ArithmeticCrossover", crossover: ArithmeticCrossover},
+		{name: "WholeArithmeticCrossover", crossover: WholeArithmeticCrossover},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 10)
+		for i := range population {
+			population[i] = &Individual{Genotype: MustNewFloat64Genotype(len(minValues), minValues, maxValues)}
+		}
+
+		offspring := tc.crossover(population, 1.0, 0.5)
+
+		for i := 0; i < len(population)/2; i++ {
+			parent1 := population[2*i].Genotype
+			parent2 := population[2*i+1].Genotype
+			for _, child := range []*Genotype{offspring[2*i].Genotype, offspring[2*i+1].Genotype} {
+				for j := 0; j < child.Len(); j++ {
+					expected := (parent1.GetRealValue(j) + parent2.GetRealValue(j)) / 2
+					if value := child.GetRealValue(j); math.Abs(value-expected) > 1e-9 {
+						t.Errorf("%s: Expected gene %d of pair %d to be %f, but got %f", tc.name, j, i, expected, value)
+					}
+				}
+			}
+		}
+
+		// With a sampled alpha, offspring still lie between their parents.
+		offspring = tc.crossover(population, 1.0, -1)
+
+		for i := 0; i < len(population)/2; i++ {
+			parent1 := population[2*i].Genotype
+			parent2 := population[2*i+1].Genotype
+			for _, child := range []*Genotype{offspring[2*i].Genotype, offspring[2*i+1].Genotype} {
+				for j := 0; j < child.Len(); j++ {
+					lower := math.Min(parent1.GetRealValue(j), parent2.GetRealValue(j))
+					upper := math.Max(parent1.GetRealValue(j), parent2.GetRealValue(j))
+					if value := child.GetRealValue(j); value < lower-1e-9 || value > upper+1e-9 {
+						t.Errorf("%s: Expected gene %d of pair %d in [%f, %f], but got %f", tc.name, j, i, lower, upper, value)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMultiPointCrossover(t *testing.T) {
+	cases := []struct {
+		genomeLength int
+		numPoints    int
+		expectedErr  error
+	}{
+		{genomeLength: 10, numPoints: 1, expectedErr: nil},
+		{genomeLength: 10, numPoints: 4, expectedErr: nil},
+		{genomeLength: 10, numPoints: 9, expectedErr: nil},
+		{genomeLength: 10, numPoints: 10, expectedErr: ErrTooManyCutPoints},
+		{genomeLength: 10, numPoints: -1, expectedErr: ErrNegativeCutPoints},
+	}
+
+	for _, tc := range cases {
+		population := []*Individual{
+			{Genotype: &Genotype{Genome: make([]byte, tc.genomeLength)}},
+			{Genotype: &Genotype{Genome: bytes.Repeat([]byte{1}, tc.genomeLength)}},
+		}
+
+		offspring, err := MultiPointCrossover(population, 1.0, tc.numPoints)
+
+		if !errors.Is(err, tc.expectedErr) {
+			t.Fatalf("Expected error %v, but got %v", tc.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		// Distinct cut points leave no segment empty, so the genome switches parents exactly numPoints times.
+		switches := 0
+		for j := 1; j < tc.genomeLength; j++ {
+			if offspring[0].Genotype.Genome[j] != offspring[0].Genotype.Genome[j-1] {
+				switches++
+			}
+			if offspring[0].Genotype.Genome[j]+offspring[1].Genotype.Genome[j] != 1 {
+				t.Errorf("Expected offspring to be complementary at gene %d", j)
+			}
+		}
+		if switches != tc.numPoints {
+			t.Errorf("Expected %d segment boundaries, but got %d in %v", tc.numPoints, switches, offspring[0].Genotype.Genome)
+		}
+	}
+}
+
+func TestTrackCrossoverPoints(t *testing.T) {
+	const genomeLength, numPoints = 8, 3
+	cases := []struct {
+		name            string
+		crossover       func(ga *GA) func([]*Individual, float64) []*Individual
+		pointsPerCross  int
+		minPoint        int
+		trackingEnabled bool
+	}{
+		{name: "single point", crossover: (*GA).SinglePointCrossover, pointsPerCross: 1, minPoint: 0, trackingEnabled: true},
+		{name: "multi point", crossover: func(ga *GA) func([]*Individual, float64) []*Individual { return ga.MultiPointCrossover(numPoints) }, pointsPerCross: numPoints, minPoint: 1, trackingEnabled: true},
+		{name: "disabled", crossover: (*GA).SinglePointCrossover},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:            func(population []*Individual) []*Individual { return population },
+			Mutation:             func([]*Individual, float64) {},
+			CrossoverRate:        1.0,
+			Generations:          3,
+			TrackCrossoverPoints: tc.trackingEnabled,
+		}
+		ga.Crossover = tc.crossover(ga)
+		ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(genomeLength, genomeLength) }, oneMax)
+		ga.Evolve(oneMax)
+
+		if !tc.trackingEnabled {
+			if ga.CrossoverPointHistory != nil {
+				t.Errorf("%s: Expected no crossover point history, but got %v", tc.name, ga.CrossoverPointHistory)
+			}
+			continue
+		}
+		if len(ga.CrossoverPointHistory) != ga.Generations {
+			t.Fatalf("%s: Expected crossover points for %d generations, but got %d", tc.name, ga.Generations, len(ga.CrossoverPointHistory))
+		}
+		for gen, points := range ga.CrossoverPointHistory {
+			if expected := 5 * tc.pointsPerCross; len(points) != expected {
+				t.Errorf("%s: Expected %d crossover points in generation %d, but got %d", tc.name, expected, gen, len(points))
+			}
+			for _, point := range points {
+				if point < tc.minPoint || point >= genomeLength {
+					t.Errorf("%s: Expected crossover points in [%d, %d), but got %d", tc.name, tc.minPoint, genomeLength, point)
+				}
+			}
+		}
+	}
+}
+
+func TestTwoPointCrossover(t *testing.T) {
+	for trial := 0; trial < 100; trial++ {
+		population := []*Individual{
+			{Genotype: &Genotype{Genome: make([]byte, 8)}},
+			{Genotype: &Genotype{Genome: bytes.Repeat([]byte{1}, 8)}},
+			{Genotype: &Genotype{Genome: []byte{0, 0}}},
+			{Genotype: &Genotype{Genome: []byte{1, 1}}},
+		}
+
+		offspring := TwoPointCrossover(population, 1.0)
+
+		// The first child takes exactly the middle segment from the second parent.
+		child1 := string(offspring[0].Genotype.Genome)
+		start := strings.IndexByte(child1, 1)
+		end := strings.LastIndexByte(child1, 1) + 1
+		if start <= 0 || end >= len(child1) || strings.Count(child1, "\x01") != end-start {
+			t.Errorf("Expected a single exchanged middle segment, but got %v", offspring[0].Genotype.Genome)
+		}
+		for j := range offspring[0].Genotype.Genome {
+			if offspring[0].Genotype.Genome[j]+offspring[1].Genotype.Genome[j] != 1 {
+				t.Errorf("Expected offspring to be complementary at gene %d", j)
+			}
+		}
+		if offspring[2] != population[2] || offspring[3] != population[3] {
+			t.Errorf("Expected parents with too short genomes to pass through unchanged")
+		}
+	}
+}
+
+func TestPositionBasedCrossover(t *testing.T) {
+	const genomeLength = 10
+	population := make([]*Individual, 20)
+	for i := range population {
+		genome := make([]byte, genomeLength)
+		for j, k := range rand.Perm(genomeLength) {
+			genome[j] = byte(k)
+		}
+		population[i] = &Individual{Genotype: &Genotype{Genome: genome}}
+	}
+
+	offspring := PositionBasedCrossover(population, 1.0)
+
+	for i, ind := range offspring {
+		seen := make(map[byte]bool, genomeLength)
+		for _, gene := range ind.Genotype.Genome {
+			seen[gene] = true
+		}
+		if len(ind.Genotype.Genome) != genomeLength || len(seen) != genomeLength {
+			t.Errorf("Expected offspring %d to be a permutation of %d values, but got %v", i, genomeLength, ind.Genotype.Genome)
+		}
+	}
+}
+
+func TestPositionBasedChild(t *testing.T) {
+	cases := []struct {
+		parent1  []byte
+		parent2  []byte
+		selected []bool
+		expected []byte
+	}{
+		{
+			parent1:  []byte{0, 1, 2, 3, 4, 5},
+			parent2:  []byte{5, 4, 3, 2, 1, 0},
+			selected: []bool{true, false, true, false, false, true},
+			expected: []byte{0, 4, 2, 3, 1, 5},
+		},
+		{
+			parent1:  []byte{0, 1, 2, 3},
+			parent2:  []byte{3, 2, 1, 0},
+			selected: []bool{false, false, false, false},
+			expected: []byte{3, 2, 1, 0},
+		},
+		{
+			parent1:  []byte{0, 1, 2, 3},
+			parent2:  []byte{3, 2, 1, 0},
+			selected: []bool{true, true, true, true},
+			expected: []byte{0, 1, 2, 3},
+		},
+	}
+
+	for _, tc := range cases {
+		child := positionBasedChild(&Genotype{Genome: tc.parent1}, &Genotype{Genome: tc.parent2}, tc.selected)
+
+		if !reflect.DeepEqual(child.Genome, tc.expected) {
+			t.Errorf("Expected %v, but got %v", tc.expected, child.Genome)
+		}
+		for j, keep := range tc.selected {
+			if keep && child.Genome[j] != tc.parent1[j] {
+				t.Errorf("Expected selected position %d to keep gene %d, but got %d", j, tc.parent1[j], child.Genome[j])
+			}
+		}
+	}
+}
+
+func TestPositionBasedCrossoverLargePermutation(t *testing.T) {
+	const genomeLength = 300
+	population := make([]*Individual, 10)
+	for i := range population {
+		genome := make([]int32, genomeLength)
+		for j, k := range rand.Perm(genomeLength) {
+			genome[j] = int32(k)
+		}
+		population[i] = &Individual{Genotype: &Genotype{Int32Genome: genome, GenomeType: LargePermutationEncoding}}
+	}
+
+	offspring := PositionBasedCrossover(population, 1.0)
+
+	for i, ind := range offspring {
+		seen := make(map[int32]bool, genomeLength)
+		for _, gene := range ind.Genotype.Int32Genome {
+			seen[gene] = true
+		}
+		if len(ind.Genotype.Int32Genome) != genomeLength || len(seen) != genomeLength {
+			t.Errorf("Expected offspring %d to be a permutation of %d values", i, genomeLength)
+		}
+		// About half of the genes come from the other parent.
+		if reflect.DeepEqual(ind.Genotype.Int32Genome, population[i].Genotype.Int32Genome) {
+			t.Errorf("Expected offspring %d to differ from its parent", i)
+		}
+	}
+}
+
+func TestEdgeAssemblyCrossover(t *testing.T) {
+	for _, cities := range []int{10, 20} {
+		population := make([]*Individual, 20)
+		for i := range population {
+			genome := make([]byte, cities)
+			for j, k := range rand.Perm(cities) {
+				genome[j] = byte(k)
+			}
+			population[i] = &Individual{Genotype: &Genotype{Genome: genome}}
+		}
+
+		offspring := EdgeAssemblyCrossover(population, 1.0)
+
+		for i, ind := range offspring {
+			seen := make(map[byte]bool, cities)
+			for _, gene := range ind.Genotype.Genome {
+				seen[gene] = true
+			}
+			if len(ind.Genotype.Genome) != cities || len(seen) != cities {
+				t.Errorf("Expected offspring %d to visit each of %d cities once, but got %v", i, cities, ind.Genotype.Genome)
+			}
+		}
+	}
+}
+
+func TestEdgeAssemblyCrossoverLargePermutation(t *testing.T) {
+	const cities = 40
+	population := make([]*Individual, 10)
+	for i := range population {
+		genome := make([]int32, cities)
+		for j, k := range rand.Perm(cities) {
+			genome[j] = int32(k)
+		}
+		population[i] = &Individual{Genotype: &Genotype{Int32Genome: genome, GenomeType: LargePermutationEncoding}}
+	}
+
+	offspring := EdgeAssemblyCrossover(population, 1.0)
+
+	for i, ind := range offspring {
+		seen := make(map[int32]bool, cities)
+		for _, gene := range ind.Genotype.Int32Genome {
+			seen[gene] = true
+		}
+		if len(ind.Genotype.Int32Genome) != cities || len(seen) != cities {
+			t.Errorf("Expected offspring %d to visit each of %d cities once, but got %v", i, cities, ind.Genotype.Int32Genome)
+		}
+		// Random parents share few edges, so at least one AB-cycle changes the tour.
+		if reflect.DeepEqual(ind.Genotype.Int32Genome, population[i].Genotype.Int32Genome) {
+			t.Errorf("Expected offspring %d to differ from its parent", i)
+		}
+	}
+}
+
+func TestBuildEdgeTable(t *testing.T) {
+	edgeTable := BuildEdgeTable([]int{2, 0, 3, 1})
+	expected := map[int][]int{2: {1, 0}, 0: {2, 3}, 3: {0, 1}, 1: {3, 2}}
+
+	if !reflect.DeepEqual(edgeTable, expected) {
+		t.Errorf("Expected %v, but got %v", expected, edgeTable)
+	}
+}
+
+func TestFindABCycles(t *testing.T) {
+	cases := []struct {
+		tourA          []int
+		tourB          []int
+		expectedEdges  int
+		expectedCycles int
+	}{
+		{tourA: []int{0, 1, 2, 3, 4, 5}, tourB: []int{0, 1, 2, 3, 4, 5}, expectedEdges: 0, expectedCycles: 0},
+		// B reverses the segment 1..4 of A, so the AB-cycle exchanges edges 0-1 and 4-5 for 0-4 and 1-5.
+		{tourA: []int{0, 1, 2, 3, 4, 5}, tourB: []int{0, 4, 3, 2, 1, 5}, expectedEdges: 4, expectedCycles: 1},
+		// The closing edge 9-0 is shared by both tours, leaving 9 edges of each.
+		{tourA: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, tourB: []int{0, 2, 4, 6, 8, 1, 3, 5, 7, 9}, expectedEdges: 18, expectedCycles: -1},
+	}
+
+	for _, tc := range cases {
+		tableA, tableB := BuildEdgeTable(tc.tourA), BuildEdgeTable(tc.tourB)
+		combined := make(map[int][]int, len(tableA))
+		for city, neighbors := range tableA {
+			combined[city] = append(append([]int(nil), neighbors...), tableB[city]...)
+		}
+
+		cycles := FindABCycles(combined)
+
+		if tc.expectedCycles >= 0 && len(cycles) != tc.expectedCycles {
+			t.Errorf("Expected %d AB-cycles, but got %d", tc.expectedCycles, len(cycles))
+		}
+		edges := 0
+		for _, cycle := range cycles {
+			if len(cycle)%2 != 0 {
+				t.Errorf("Expected an even number of edges in AB-cycle %v", cycle)
+			}
+			for j := range cycle {
+				u, v := cycle[j], cycle[(j+1)%len(cycle)]
+				if j%2 == 0 && !containsCity(tableA[u], v) {
+					t.Errorf("Expected edge %d-%d of AB-cycle %v to belong to A", u, v, cycle)
+				}
+				if j%2 == 1 && !containsCity(tableB[u], v) {
+					t.Errorf("Expected edge %d-%d of AB-cycle %v to belong to B", u, v, cycle)
+				}
+			}
+			edges += len(cycle)
+		}
+		if edges != tc.expectedEdges {
+			t.Errorf("Expected AB-cycles to cover %d edges, but got %d", tc.expectedEdges, edges)
+		}
+	}
+}
+
+func TestMultiChromosomeSinglePointCrossover(t *testing.T) {
+	lengths := []int{3, 8, 5}
+	newIndividual := func(gene byte) *Individual {
+		chromosomes := make([]*Genotype, len(lengths))
+		for k, length := range lengths {
+			chromosomes[k] = &Genotype{Genome: bytes.Repeat([]byte{gene}, length)}
+		}
+		return NewMultiChromosomeIndividual(chromosomes...)
+	}
+	population := []*Individual{newIndividual(0), newIndividual(1), newIndividual(0), newIndividual(1)}
+
+	offspring := MultiChromosomeSinglePointCrossover(population, 1.0)
+
+	if len(offspring) != len(population) {
+		t.Fatalf("Expected offspring length %d, but got %d", len(population), len(offspring))
+	}
+	for i := 0; i < len(offspring)/2; i++ {
+		child1, child2 := offspring[2*i], offspring[2*i+1]
+		if len(child1.Chromosomes) != len(lengths) || len(child2.Chromosomes) != len(lengths) {
+			t.Fatalf("Expected %d chromosomes, but got %d and %d", len(lengths), len(child1.Chromosomes), len(child2.Chromosomes))
+		}
+		for k, length := range lengths {
+			if len(child1.Chromosomes[k].Genome) != length || len(child2.Chromosomes[k].Genome) != length {
+				t.Errorf("Expected chromosome %d of length %d, but got %d and %d", k, length, len(child1.Chromosomes[k].Genome), len(child2.Chromosomes[k].Genome))
+			}
+			for j := range child1.Chromosomes[k].Genome {
+				if child1.Chromosomes[k].Genome[j]+child2.Chromosomes[k].Genome[j] != 1 {
+					t.Errorf("Expected offspring chromosome %d to be complementary at gene %d", k, j)
+				}
+			}
+		}
+	}
+}
+
+func TestCrossoverOddPopulation(t *testing.T) {
+	cases := []struct {
+		name      string
+		crossover func([]*Individual, float64) []*Individual
+	}{
+		{name: "single point", crossover: SinglePointCrossover},
+		{name: "uniform", crossover: UniformCrossover},
+		{name: "two point", crossover: TwoPointCrossover},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 5)
+		for i := range population {
+			population[i] = &Individual{Genotype: MustVariableLengthBinaryGenotype(8, 8), Phenotype: &Phenotype{}}
+		}
+		offspring := tc.crossover(population, 1.0)
+
+		if offspring[4] != population[4] {
+			t.Errorf("Expected %s crossover to pass the unpaired individual through, but got %v", tc.name, offspring[4])
+		}
+	}
+}
+
+func TestCrossoverPreservesEncoding(t *testing.T) {
+	minValues, maxValues := []float64{-1, 0, 5}, []float64{1, 10, 6}
+	cases := []struct {
+		name      string
+		crossover func([]*Individual, float64) []*Individual
+	}{
+		{name: "single point", crossover: SinglePointCrossover},
+		{name: "uniform", crossover: UniformCrossover},
+		{name: "two point", crossover: TwoPointCrossover},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 10)
+		for i := range population {
+			population[i] = &Individual{Genotype: MustNewRealGenotype(3, minValues, maxValues), Phenotype: &Phenotype{}}
+		}
+		offspring := tc.crossover(population, 1.0)
+
+		for i, ind := range offspring {
+			genotype := ind.Genotype
+			if genotype.GenomeType != RealEncoding {
+				t.Fatalf("%s: Expected offspring %d to be real-encoded, but got genome type %v", tc.name, i, genotype.GenomeType)
+			}
+			if !reflect.DeepEqual(genotype.MinValues, minValues) || !reflect.DeepEqual(genotype.MaxValues, maxValues) {
+				t.Fatalf("%s: Expected offspring %d to keep bounds %v and %v, but got %v and %v", tc.name, i, minValues, maxValues, genotype.MinValues, genotype.MaxValues)
+			}
+			for k := range minValues {
+				if value := genotype.GetRealValue(k); value < minValues[k] || value > maxValues[k] {
+					t.Errorf("%s: Expected gene %d of offspring %d within [%f, %f], but got %f", tc.name, k, i, minValues[k], maxValues[k], value)
+				}
+			}
+		}
+	}
+}
+
+func TestCrossoverNonByteEncodings(t *testing.T) {
+	genes := func(g *Genotype) []float64 {
+		if g.GenomeType == Float64Encoding {
+			return g.Float64Genome
+		}
+		values := make([]float64, len(g.Int32Genome))
+		for i, value := range g.Int32Genome {
+			values[i] = float64(value)
+		}
+		return values
+	}
+	bounds := func(value float64) []float64 {
+		return []float64{value, value, value, value, value, value, value, value}
+	}
+	encodings := []struct {
+		name     string
+		genotype func() *Genotype
+	}{
+		{name: "float64", genotype: func() *Genotype { return MustNewFloat64Genotype(8, bounds(-5), bounds(5)) }},
+		{name: "integer", genotype: func() *Genotype { return MustNewIntegerGenotype(8, bounds(-100), bounds(100)) }},
+	}
+	crossovers := []struct {
+		name      string
+		crossover func([]*Individual, float64) []*Individual
+	}{
+		{name: "single point", crossover: SinglePointCrossover},
+		{name: "uniform", crossover: UniformCrossover},
+		{name: "two point", crossover: TwoPointCrossover},
+		{name: "multi point", crossover: func(population []*Individual, rate float64) []*Individual {
+			offspring, err := MultiPointCrossover(population, rate, 3)
+			if err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			return offspring
+		}},
+	}
+
+	for _, encoding := range encodings {
+		for _, tc := range crossovers {
+			population := make([]*Individual, 10)
+			for i := range population {
+				population[i] = &Individual{Genotype: encoding.genotype(), Phenotype: &Phenotype{}}
+			}
+			offspring := tc.crossover(population, 1.0)
+
+			for i := 0; i < len(population); i += 2 {
+				p1, p2 := genes(population[i].Genotype), genes(population[i+1].Genotype)
+				c1, c2 := genes(offspring[i].Genotype), genes(offspring[i+1].Genotype)
+				if offspring[i].Genotype.GenomeType != population[i].Genotype.GenomeType || len(c1) != len(p1) || len(c2) != len(p2) {
+					t.Fatalf("%s %s: Expected offspring with the encoding and length of their parents, but got %+v", encoding.name, tc.name, offspring[i].Genotype)
+				}
+				for j := range p1 {
+					if !(c1[j] == p1[j] && c2[j] == p2[j]) && !(c1[j] == p2[j] && c2[j] == p1[j]) {
+						t.Errorf("%s %s: Expected genes %f and %f at position %d to come from the parents' %f and %f", encoding.name, tc.name, c1[j], c2[j], j, p1[j], p2[j])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCrossoverImmutability(t *testing.T) {
+	for _, name := range CrossoverOperators.Names() {
+		crossover, _ := CrossoverOperators.Get(name)
+
+		// Permutation genomes are valid input for every registered crossover.
+		population := make([]*Individual, 10)
+		for i := range population {
+			genotype := &Genotype{
+				Genome:     []byte{0, 1, 2, 3, 4, 5, 6, 7},
+				GenomeType: RealEncoding,
+				MinValues:  make([]float64, 8),
+				MaxValues:  []float64{255, 255, 255, 255, 255, 255, 255, 255},
+			}
+			rand.Shuffle(len(genotype.Genome), func(a, b int) {
+				genotype.Genome[a], genotype.Genome[b] = genotype.Genome[b], genotype.Genome[a]
+			})
+			population[i] = &Individual{Genotype: genotype, Phenotype: &Phenotype{}}
+		}
+		original := append([]*Individual(nil), population...)
+		genomes := make([][]byte, len(population))
+		for i, ind := range population {
+			genomes[i] = append([]byte(nil), ind.Genotype.Genome...)
+		}
+
+		crossover(population, 1.0)
+
+		for i, ind := range population {
+			if ind != original[i] {
+				t.Errorf("%s: expected the input population to be unchanged, but individual %d was replaced", name, i)
+				break
+			}
+			if !bytes.Equal(ind.Genotype.Genome, genomes[i]) {
+				t.Errorf("%s: expected parent genomes to be unchanged, but individual %d changed from %v to %v", name, i, genomes[i], ind.Genotype.Genome)
+				break
+			}
+		}
+	}
+}
+
+func TestOrderBasedCrossover(t *testing.T) {
+	cases := []struct {
+		parent1 []byte
+		parent2 []byte
+	}{
+		{parent1: []byte{0, 1, 2, 3, 4, 5, 6, 7}, parent2: []byte{7, 6, 5, 4, 3, 2, 1, 0}},
+		{parent1: []byte{3, 1, 4, 0, 2}, parent2: []byte{0, 1, 2, 3, 4}},
+	}
+
+	for _, tc := range cases {
+		population := []*Individual{
+			{Genotype: &Genotype{Genome: append([]byte(nil), tc.parent1...)}},
+			{Genotype: &Genotype{Genome: append([]byte(nil), tc.parent2...)}},
+		}
+		offspring := OrderBasedCrossover(population, 1.0)
+
+		for _, child := range offspring {
+			seen := make(map[byte]bool)
+			for _, gene := range child.Genotype.Genome {
+				seen[gene] = true
+			}
+			if len(child.Genotype.Genome) != len(tc.parent1) || len(seen) != len(tc.parent1) {
+				t.Errorf("Expected a permutation of %v, but got %v", tc.parent1, child.Genotype.Genome)
+			}
+		}
+	}
+}
+
+func TestOrderChild(t *testing.T) {
+	// The segment 4, 5, 6 of the first parent is kept, and the remaining genes are filled from
+	// position 6 onwards in the order they appear in the second parent from position 6 onwards.
+	child := orderChild([]int32{1, 2, 3, 4, 5, 6, 7, 8, 9}, []int32{9, 3, 7, 8, 2, 6, 5, 1, 4}, 3, 6)
+	expected := []int32{7, 8, 2, 4, 5, 6, 1, 9, 3}
+
+	if !reflect.DeepEqual(child, expected) {
+		t.Errorf("Expected %v, but got %v", expected, child)
+	}
+}
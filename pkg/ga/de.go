@@ -0,0 +1,200 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including differential evolution as an alternative to selection, crossover, and mutation.
+package ga
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// ErrNotRealValued is returned by Evolve in DE mode when an individual's genotype is not
+// real-valued, that is, neither RealEncoding nor Float64Encoding with bounds for every gene.
+var ErrNotRealValued = errors.New("differential evolution requires real-valued genotypes")
+
+const (
+	// DefaultDEF is the differential evolution scale factor used when GA.DEF is zero.
+	DefaultDEF = 0.8
+	// DefaultDECR is the differential evolution crossover rate used when GA.DECR is zero.
+	DefaultDECR = 0.9
+	// DefaultDETau is the probability of resetting a self-adaptive scale factor or crossover
+	// rate used when GA.DETau1 or GA.DETau2 is zero.
+	DefaultDETau = 0.1
+
+	// deScaleParam and deCrossoverRateParam are the StrategyParams keys holding an
+	// individual's own scale factor and crossover rate in self-adaptive DE mode.
+	deScaleParam         = "deF"
+	deCrossoverRateParam = "deCR"
+
+	minDEScale = 0.1
+	maxDEScale = 0.9
+)
+
+// differentialEvolution performs one generation of DE/rand/1/bin differential evolution.
+//
+// For each target individual, three distinct other individuals r1, r2, and r3 are chosen
+// at random and a mutant r1 + F*(r2 - r3) is computed. A trial individual takes each gene
+// from the mutant with probability CR, and at least one gene from the mutant, and the rest
+// from the target. The trial replaces the target only if it is at least as fit, so no
+// individual ever gets worse. A trial that replaces its target records the target and r1 as
+// its parents. Populations of fewer than four individuals are left unchanged.
+//
+// If DEAdaptive is set, F and CR are self-adapted per individual as in jDE: each individual
+// carries its own F and CR in StrategyParams, drawn uniformly from [0.1, 0.9] and [0, 1] when
+// missing. The trial of a target is built with the target's F, reset to a new uniform value
+// with probability DETau1, and its CR, reset with probability DETau2, and inherits them if it
+// replaces the target, so that parameters producing successful trials survive.
+//
+// The trials of a generation are built from the same population and then evaluated together
+// like the offspring of breed, through the BatchEvaluator if set or by NumParallelEvals
+// workers otherwise, with the Cache and Surrogate. A panicking evaluation is recovered and
+// reported, and its trial discarded, and targets are kept without building a trial once the
+// EvaluationBudget cannot cover another evaluation.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - The errors of the trial evaluations that panicked.
+func (ga *GA) differentialEvolution(evaluatePhenotype func(*Genotype) *Phenotype) []EvaluationError {
+	if len(ga.Population) < 4 {
+		return nil
+	}
+	scale, crossoverRate := ga.DEF, ga.DECR
+	if scale == 0 {
+		scale = DefaultDEF
+	}
+	if crossoverRate == 0 {
+		crossoverRate = DefaultDECR
+	}
+
+	targets := ga.Population
+	trials := make([]*Individual, 0, len(targets))
+	for i, target := range targets {
+		if !ga.withinBudget(len(trials) + 1) {
+			break
+		}
+		if ga.DEAdaptive {
+			scale, crossoverRate = ga.adaptDEParams(target)
+		}
+		r1, r2, r3 := distinctOthers(len(targets), i)
+		a, b, c := targets[r1].Genotype, targets[r2].Genotype, targets[r3].Genotype
+
+		trial := target.Genotype.Clone()
+		forced := rand.Intn(trial.Len())
+		for j := 0; j < trial.Len(); j++ {
+			if j == forced || rand.Float64() < crossoverRate {
+				trial.SetRealValue(j, a.GetRealValue(j)+scale*(b.GetRealValue(j)-c.GetRealValue(j)))
+			}
+		}
+
+		offspring := target.Clone()
+		offspring.Genotype = trial
+		offspring.Phenotype = nil
+		offspring.Age = 0
+		offspring.ID = NextIndividualID()
+		offspring.ParentIDs = [2]int64{target.ID, targets[r1].ID}
+		if ga.DEAdaptive {
+			offspring.StrategyParams[deScaleParam] = scale
+			offspring.StrategyParams[deCrossoverRateParam] = crossoverRate
+		}
+		trials = append(trials, offspring)
+	}
+	if len(trials) == 0 {
+		return nil
+	}
+
+	// The trial of target i is at index i, so the evaluation errors refer to the targets.
+	ga.Population = trials
+	evaluationErrors := ga.evaluatePopulation(evaluatePhenotype)
+	failed := make(map[int]bool, len(evaluationErrors))
+	for _, evaluationError := range evaluationErrors {
+		failed[evaluationError.IndividualIndex] = true
+	}
+
+	next := append([]*Individual(nil), targets...)
+	for i, trial := range trials {
+		if !failed[i] && !fitter(targets[i].Phenotype.Fitness, trial.Phenotype.Fitness, ga.Minimize) {
+			next[i] = trial
+		}
+	}
+	ga.Population = next
+	return evaluationErrors
+}
+
+// checkRealValued checks that every individual of the population has a real-valued genotype
+// with bounds for every gene, as differential evolution requires.
+//
+// Returns:
+// - An error wrapping ErrNotRealValued naming the first offending individual, or nil.
+func (ga *GA) checkRealValued() error {
+	for i, ind := range ga.Population {
+		genotype := ind.Genotype
+		if genotype == nil || (genotype.GenomeType != RealEncoding && genotype.GenomeType != Float64Encoding) {
+			return fmt.Errorf("%w: individual %d", ErrNotRealValued, i)
+		}
+		if len(genotype.MinValues) < genotype.Len() || len(genotype.MaxValues) < genotype.Len() {
+			return fmt.Errorf("%w: individual %d lacks gene bounds", ErrNotRealValued, i)
+		}
+	}
+	return nil
+}
+
+// adaptDEParams returns the scale factor and crossover rate with which to build the trial of
+// the target in self-adaptive DE mode, initializing the target's own parameters if missing.
+//
+// Parameters:
+// - target: the individual whose trial is built.
+//
+// Returns:
+// - The scale factor and the crossover rate of the trial.
+func (ga *GA) adaptDEParams(target *Individual) (float64, float64) {
+	tau1, tau2 := ga.DETau1, ga.DETau2
+	if tau1 == 0 {
+		tau1 = DefaultDETau
+	}
+	if tau2 == 0 {
+		tau2 = DefaultDETau
+	}
+	if target.StrategyParams == nil {
+		target.StrategyParams = make(map[string]float64)
+	}
+	scale, ok := target.StrategyParams[deScaleParam]
+	if !ok {
+		scale = minDEScale + rand.Float64()*(maxDEScale-minDEScale)
+		target.StrategyParams[deScaleParam] = scale
+	}
+	crossoverRate, ok := target.StrategyParams[deCrossoverRateParam]
+	if !ok {
+		crossoverRate = rand.Float64()
+		target.StrategyParams[deCrossoverRateParam] = crossoverRate
+	}
+
+	if rand.Float64() < tau1 {
+		scale = minDEScale + rand.Float64()*(maxDEScale-minDEScale)
+	}
+	if rand.Float64() < tau2 {
+		crossoverRate = rand.Float64()
+	}
+	return scale, crossoverRate
+}
+
+// distinctOthers returns three distinct random indices in [0, n) that all differ from exclude.
+// n must be at least 4.
+func distinctOthers(n, exclude int) (int, int, int) {
+	picked := make([]int, 0, 3)
+	for len(picked) < 3 {
+		candidate := rand.Intn(n)
+		if candidate == exclude {
+			continue
+		}
+		duplicate := false
+		for _, p := range picked {
+			duplicate = duplicate || p == candidate
+		}
+		if !duplicate {
+			picked = append(picked, candidate)
+		}
+	}
+	return picked[0], picked[1], picked[2]
+}
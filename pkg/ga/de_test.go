@@ -0,0 +1,177 @@
+package ga
+
+import (
+	"errors"
+	"testing"
+)
+
+// negativeSphere returns the negated sum of squared genes as the fitness.
+func negativeSphere(genotype *Genotype) *Phenotype {
+	fitness := 0.0
+	for i := 0; i < genotype.Len(); i++ {
+		fitness -= genotype.GetRealValue(i) * genotype.GetRealValue(i)
+	}
+	return &Phenotype{Fitness: fitness}
+}
+
+func TestDifferentialEvolutionNeverDegrades(t *testing.T) {
+	minValues, maxValues := []float64{-5, -5, -5}, []float64{5, 5, 5}
+	cases := []struct {
+		name     string
+		genotype func() *Genotype
+	}{
+		{name: "float64", genotype: func() *Genotype { return MustNewFloat64Genotype(3, minValues, maxValues) }},
+		{name: "real", genotype: func() *Genotype { return MustNewRealGenotype(3, minValues, maxValues) }},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{DEMode: true, Generations: 1}
+		ga.Initialize(20, tc.genotype, negativeSphere)
+
+		for gen := 0; gen < 20; gen++ {
+			before := make([]float64, len(ga.Population))
+			for i, ind := range ga.Population {
+				before[i] = ind.Phenotype.Fitness
+			}
+			ga.Evolve(negativeSphere)
+			for i, ind := range ga.Population {
+				if ind.Phenotype.Fitness < before[i] {
+					t.Errorf("%s: Expected individual %d to be at least as fit as %f, but got %f", tc.name, i, before[i], ind.Phenotype.Fitness)
+				}
+			}
+		}
+	}
+}
+
+func TestDifferentialEvolutionConverges(t *testing.T) {
+	minValues, maxValues := []float64{-5, -5, -5}, []float64{5, 5, 5}
+	ga := &GA{DEMode: true, DEF: 0.5, DECR: 0.9, Generations: 100}
+	ga.Initialize(30, func() *Genotype { return MustNewFloat64Genotype(3, minValues, maxValues) }, negativeSphere)
+	initial := ga.History[0].BestFitness
+
+	ga.Evolve(negativeSphere)
+
+	best := findBestIndividual(ga.Population).Phenotype.Fitness
+	if best <= initial || best < -1e-3 {
+		t.Errorf("Expected best fitness to improve from %f to near 0, but got %f", initial, best)
+	}
+}
+
+func TestSelfAdaptiveDifferentialEvolutionConverges(t *testing.T) {
+	const dimensions, populationSize, budget = 10, 20, 10000
+	minValues, maxValues := make([]float64, dimensions), make([]float64, dimensions)
+	for i := range minValues {
+		minValues[i], maxValues[i] = -5, 5
+	}
+	ga := &GA{DEMode: true, DEAdaptive: true, Generations: budget, EvaluationBudget: budget}
+	ga.Initialize(populationSize, func() *Genotype { return MustNewFloat64Genotype(dimensions, minValues, maxValues) }, negativeSphere)
+
+	ga.Evolve(negativeSphere)
+
+	if ga.EvaluationsUsed > budget {
+		t.Errorf("Expected at most %d evaluations, but got %d", budget, ga.EvaluationsUsed)
+	}
+	if best := findBestIndividual(ga.Population).Phenotype.Fitness; best < -1e-6 {
+		t.Errorf("Expected best fitness within 1e-6 of the optimum 0, but got %g", best)
+	}
+	for i, ind := range ga.Population {
+		scale, crossoverRate := ind.StrategyParams[deScaleParam], ind.StrategyParams[deCrossoverRateParam]
+		if scale < minDEScale || scale > maxDEScale || crossoverRate < 0 || crossoverRate > 1 {
+			t.Errorf("Expected individual %d to carry F in [%g, %g] and CR in [0, 1], but got %g and %g", i, minDEScale, maxDEScale, scale, crossoverRate)
+		}
+	}
+}
+
+func TestDifferentialEvolutionRecoversEvaluationPanics(t *testing.T) {
+	minValues, maxValues := []float64{-5, -5, -5}, []float64{5, 5, 5}
+	ga := &GA{DEMode: true, Generations: 3}
+	if err := ga.Initialize(10, func() *Genotype { return MustNewFloat64Genotype(3, minValues, maxValues) }, negativeSphere); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	before := make([]float64, len(ga.Population))
+	for i, ind := range ga.Population {
+		before[i] = ind.Phenotype.Fitness
+	}
+
+	best, evaluationErrors, err := ga.Evolve(func(*Genotype) *Phenotype { panic("evaluation failed") })
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if expected := 3 * len(ga.Population); len(evaluationErrors) != expected {
+		t.Errorf("Expected %d evaluation errors, but got %d", expected, len(evaluationErrors))
+	}
+	for i, ind := range ga.Population {
+		if ind.Phenotype.Fitness != before[i] {
+			t.Errorf("Expected individual %d to keep fitness %f, but got %f", i, before[i], ind.Phenotype.Fitness)
+		}
+	}
+	if best == nil || best.Phenotype.Fitness != findBestIndividual(ga.Population).Phenotype.Fitness {
+		t.Errorf("Expected the best individual of the population to be returned")
+	}
+}
+
+func TestDifferentialEvolutionRequiresRealValued(t *testing.T) {
+	cases := []struct {
+		name     string
+		genotype func() *Genotype
+	}{
+		{name: "binary", genotype: func() *Genotype { return MustNewGenotype(8) }},
+		{name: "missing bounds", genotype: func() *Genotype { return &Genotype{Genome: make([]byte, 3), GenomeType: RealEncoding} }},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{DEMode: true, Generations: 1}
+		ga.Initialize(10, tc.genotype, oneMax)
+		population := append([]*Individual(nil), ga.Population...)
+
+		if _, _, err := ga.Evolve(oneMax); !errors.Is(err, ErrNotRealValued) {
+			t.Errorf("%s: Expected ErrNotRealValued, but got %v", tc.name, err)
+		}
+		for i, ind := range ga.Population {
+			if ind != population[i] {
+				t.Errorf("%s: Expected individual %d to be left unchanged", tc.name, i)
+			}
+		}
+	}
+}
+
+func TestDifferentialEvolutionBatchEvaluator(t *testing.T) {
+	minValues, maxValues := []float64{-5, -5, -5}, []float64{5, 5, 5}
+	batches := 0
+	ga := &GA{
+		DEMode:      true,
+		Generations: 10,
+		BatchEvaluator: BatchEvaluatorFunc(func(genotypes []*Genotype) []*Phenotype {
+			batches++
+			phenotypes := make([]*Phenotype, len(genotypes))
+			for i, genotype := range genotypes {
+				phenotypes[i] = negativeSphere(genotype)
+			}
+			return phenotypes
+		}),
+	}
+	ga.Initialize(10, func() *Genotype { return MustNewFloat64Genotype(3, minValues, maxValues) }, negativeSphere)
+	initial := ga.History[0].BestFitness
+
+	ga.Evolve(func(*Genotype) *Phenotype {
+		t.Fatal("Expected the trials to be evaluated by the BatchEvaluator, but the fitness function was called")
+		return nil
+	})
+
+	if batches != ga.Generations {
+		t.Errorf("Expected %d batches, one per generation, but got %d", ga.Generations, batches)
+	}
+	if best := findBestIndividual(ga.Population).Phenotype.Fitness; best < initial {
+		t.Errorf("Expected best fitness to be at least %f, but got %f", initial, best)
+	}
+}
+
+func TestDistinctOthers(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		exclude := i % 4
+		r1, r2, r3 := distinctOthers(4, exclude)
+		if r1 == r2 || r1 == r3 || r2 == r3 || r1 == exclude || r2 == exclude || r3 == exclude {
+			t.Errorf("Expected distinct indices other than %d, but got %d, %d, %d", exclude, r1, r2, r3)
+		}
+	}
+}
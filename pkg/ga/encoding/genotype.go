@@ -0,0 +1,62 @@
+// Package encoding provides alternative genotype encodings for genetic algorithms,
+// such as permutations too large for the byte genes of ga.Genotype.
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// ErrInvalidPermutationSize is returned by NewLargePermutationGenotype when the size is not positive.
+var ErrInvalidPermutationSize = errors.New("permutation size must be positive")
+
+// LargePermutationGenotype is a permutation of 0..n-1 held in the Int32Genome of a ga.Genotype
+// with LargePermutationEncoding, so that it can hold permutations of more than 256 elements,
+// such as the tours of large TSP instances. The embedded Genotype is what a GA evolves, with
+// operators that support the encoding, such as ga.OrderBasedCrossover and ga.SwapMutation.
+type LargePermutationGenotype struct {
+	*ga.Genotype
+}
+
+// NewLargePermutationGenotype creates a new LargePermutationGenotype holding a random permutation.
+//
+// Parameters:
+// - size: the number of elements of the permutation.
+//
+// Returns:
+// - A pointer to the newly created LargePermutationGenotype.
+// - An error wrapping ErrInvalidPermutationSize if size is not positive.
+func NewLargePermutationGenotype(size int) (*LargePermutationGenotype, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidPermutationSize, size)
+	}
+	permutation := make([]int32, size)
+	for i, j := range rand.Perm(size) {
+		permutation[i] = int32(j)
+	}
+	return &LargePermutationGenotype{Genotype: &ga.Genotype{
+		Int32Genome: permutation,
+		GenomeType:  ga.LargePermutationEncoding,
+	}}, nil
+}
+
+// MustNewLargePermutationGenotype is like NewLargePermutationGenotype but panics if the size is invalid.
+func MustNewLargePermutationGenotype(size int) *LargePermutationGenotype {
+	genotype, err := NewLargePermutationGenotype(size)
+	if err != nil {
+		panic(err)
+	}
+	return genotype
+}
+
+// GetPermutationInt32 returns the permutation held by the genotype. The returned slice is
+// the genotype's own, so changes to it change the genotype.
+//
+// Returns:
+// - The permutation of 0..n-1.
+func (g *LargePermutationGenotype) GetPermutationInt32() []int32 {
+	return g.Int32Genome
+}
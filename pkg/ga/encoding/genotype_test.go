@@ -0,0 +1,66 @@
+package encoding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// isPermutation reports whether genome holds each of 0..len(genome)-1 exactly once.
+func isPermutation(genome []int32) bool {
+	seen := make([]bool, len(genome))
+	for _, gene := range genome {
+		if gene < 0 || int(gene) >= len(genome) || seen[gene] {
+			return false
+		}
+		seen[gene] = true
+	}
+	return true
+}
+
+func TestNewLargePermutationGenotype(t *testing.T) {
+	cases := []struct {
+		size        int
+		expectedErr error
+	}{
+		{size: 500, expectedErr: nil},
+		{size: 1, expectedErr: nil},
+		{size: 0, expectedErr: ErrInvalidPermutationSize},
+	}
+
+	for _, tc := range cases {
+		genotype, err := NewLargePermutationGenotype(tc.size)
+		if !errors.Is(err, tc.expectedErr) {
+			t.Fatalf("Expected error %v for size %d, but got %v", tc.expectedErr, tc.size, err)
+		}
+		if err != nil {
+			continue
+		}
+		if permutation := genotype.GetPermutationInt32(); len(permutation) != tc.size || !isPermutation(permutation) {
+			t.Errorf("Expected a permutation of %d elements, but got %v", tc.size, permutation)
+		}
+		if genotype.Len() != tc.size {
+			t.Errorf("Expected length %d, but got %d", tc.size, genotype.Len())
+		}
+	}
+}
+
+func TestLargePermutationOperators(t *testing.T) {
+	const size = 500
+	population := make([]*ga.Individual, 20)
+	for i := range population {
+		population[i] = &ga.Individual{Genotype: MustNewLargePermutationGenotype(size).Genotype}
+	}
+
+	for generation := 0; generation < 10; generation++ {
+		population = ga.OrderBasedCrossover(population, 1.0)
+		ga.SwapMutation(population, 0.01)
+
+		for i, ind := range population {
+			if len(ind.Genotype.Int32Genome) != size || !isPermutation(ind.Genotype.Int32Genome) {
+				t.Fatalf("Expected individual %d to remain a permutation of %d elements after generation %d", i, size, generation)
+			}
+		}
+	}
+}
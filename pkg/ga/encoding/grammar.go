@@ -0,0 +1,113 @@
+// Package encoding provides alternative genotype encodings for genetic algorithms,
+// such as the codon sequences of grammatical evolution.
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+var (
+	// ErrNoCodons is returned by Derive when the genotype has no codons to choose productions with.
+	ErrNoCodons = errors.New("genotype has no codons")
+	// ErrWrapsExceeded is returned by Derive when the codons run out after the allowed number of
+	// wraps before the derivation is complete.
+	ErrWrapsExceeded = errors.New("derivation did not complete within the allowed wraps")
+	// ErrNegativeWraps is returned by Derive when the number of allowed wraps is negative.
+	ErrNegativeWraps = errors.New("number of wraps must not be negative")
+	// ErrInvalidCodons is returned by NewGrammaticalGenotype when the codon count or range
+	// is not positive.
+	ErrInvalidCodons = errors.New("codon count and range must be positive")
+)
+
+// GrammaticalGenotype is a grammatical evolution genotype: a sequence of integer codons that
+// is mapped to a string of the language described by Grammar.
+//
+// Grammar maps each non-terminal symbol to the alternatives of its production rule, each
+// alternative being a sequence of symbols. Symbols that are not keys of Grammar are terminals.
+type GrammaticalGenotype struct {
+	Codons  []int
+	Grammar map[string][][]string
+}
+
+// NewGrammaticalGenotype creates a new GrammaticalGenotype with random codons and no grammar.
+//
+// Parameters:
+// - codonCount: the number of codons to be created.
+// - codonRange: the exclusive upper bound of the codon values.
+//
+// Returns:
+// - A pointer to the newly created GrammaticalGenotype.
+// - An error wrapping ErrInvalidCodons if codonCount or codonRange is not positive.
+func NewGrammaticalGenotype(codonCount, codonRange int) (*GrammaticalGenotype, error) {
+	if codonCount <= 0 || codonRange <= 0 {
+		return nil, fmt.Errorf("%w: %d codons in range %d", ErrInvalidCodons, codonCount, codonRange)
+	}
+	genotype := &GrammaticalGenotype{Codons: make([]int, codonCount)}
+	for i := range genotype.Codons {
+		genotype.Codons[i] = rand.Intn(codonRange)
+	}
+	return genotype, nil
+}
+
+// MustNewGrammaticalGenotype is like NewGrammaticalGenotype but panics if the arguments are invalid.
+func MustNewGrammaticalGenotype(codonCount, codonRange int) *GrammaticalGenotype {
+	genotype, err := NewGrammaticalGenotype(codonCount, codonRange)
+	if err != nil {
+		panic(err)
+	}
+	return genotype
+}
+
+// Derive maps the codons to a string of the grammar's language by a leftmost derivation from
+// the start symbol. Each expansion of a non-terminal consumes the next codon and chooses the
+// alternative at the codon's value modulo the number of alternatives. When the codons run out,
+// they are reused from the start, up to wraps times.
+//
+// Parameters:
+// - startSymbol: the symbol the derivation starts from.
+// - wraps: the number of times the codons may be reused; it must not be negative.
+//
+// Returns:
+// - The concatenation of the terminals of the derivation.
+// - An error wrapping ErrNegativeWraps if wraps is negative, or ErrNoCodons or
+// ErrWrapsExceeded if the derivation cannot be completed.
+func (g *GrammaticalGenotype) Derive(startSymbol string, wraps int) (string, error) {
+	if wraps < 0 {
+		return "", fmt.Errorf("%w: %d", ErrNegativeWraps, wraps)
+	}
+	var derived strings.Builder
+	stack := []string{startSymbol}
+	codon, wrapped := 0, 0
+
+	for len(stack) > 0 {
+		symbol := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		alternatives, ok := g.Grammar[symbol]
+		if !ok {
+			derived.WriteString(symbol)
+			continue
+		}
+		if len(g.Codons) == 0 {
+			return "", ErrNoCodons
+		}
+		if codon == len(g.Codons) {
+			if wrapped == wraps {
+				return "", fmt.Errorf("%w: %d wraps, expanding %q", ErrWrapsExceeded, wraps, symbol)
+			}
+			codon = 0
+			wrapped++
+		}
+
+		production := alternatives[g.Codons[codon]%len(alternatives)]
+		codon++
+		// Push the production in reverse so that its leftmost symbol is expanded first.
+		for i := len(production) - 1; i >= 0; i-- {
+			stack = append(stack, production[i])
+		}
+	}
+	return derived.String(), nil
+}
@@ -0,0 +1,109 @@
+package encoding
+
+import (
+	"errors"
+	"testing"
+)
+
+// expressionGrammar describes arithmetic expressions over x and y, recursively.
+var expressionGrammar = map[string][][]string{
+	"<expr>": {{"<expr>", "<op>", "<expr>"}, {"<var>"}},
+	"<op>":   {{"+"}, {"-"}, {"*"}},
+	"<var>":  {{"x"}, {"y"}},
+}
+
+// assignmentGrammar describes a single assignment, non-recursively.
+var assignmentGrammar = map[string][][]string{
+	"<stmt>": {{"<var>", "=", "<var>", "<op>", "<var>"}},
+	"<op>":   {{"+"}, {"-"}},
+	"<var>":  {{"a"}, {"b"}, {"c"}},
+}
+
+func TestDerive(t *testing.T) {
+	cases := []struct {
+		grammar     map[string][][]string
+		start       string
+		codons      []int
+		wraps       int
+		expected    string
+		expectedErr error
+	}{
+		{grammar: assignmentGrammar, start: "<stmt>", codons: []int{0, 0, 1, 0, 2}, wraps: 0, expected: "a=b+c"},
+		{grammar: assignmentGrammar, start: "<stmt>", codons: []int{7, 3, 4, 5, 6}, wraps: 0, expected: "a=b-a"},
+		{grammar: expressionGrammar, start: "<expr>", codons: []int{1, 1}, wraps: 0, expected: "y"},
+		{grammar: expressionGrammar, start: "<expr>", codons: []int{0, 1, 0, 2, 1, 1}, wraps: 0, expected: "x*y"},
+		// The codons run out while expanding the last <var>, and wrap around to reuse codon 0.
+		{grammar: expressionGrammar, start: "<expr>", codons: []int{0, 1, 0, 2, 1}, wraps: 1, expected: "x*x"},
+		{grammar: expressionGrammar, start: "<expr>", codons: []int{0, 1, 0, 2, 1}, wraps: 0, expectedErr: ErrWrapsExceeded},
+		{grammar: expressionGrammar, start: "<expr>", codons: []int{0}, wraps: 3, expectedErr: ErrWrapsExceeded},
+		{grammar: expressionGrammar, start: "<expr>", codons: nil, wraps: 3, expectedErr: ErrNoCodons},
+		{grammar: expressionGrammar, start: "<expr>", codons: []int{0, 1, 0, 2, 1}, wraps: -1, expectedErr: ErrNegativeWraps},
+	}
+
+	for _, tc := range cases {
+		genotype := &GrammaticalGenotype{Codons: tc.codons, Grammar: tc.grammar}
+
+		derived, err := genotype.Derive(tc.start, tc.wraps)
+
+		if !errors.Is(err, tc.expectedErr) {
+			t.Errorf("Expected error %v for codons %v, but got %v", tc.expectedErr, tc.codons, err)
+		}
+		if derived != tc.expected {
+			t.Errorf("Expected %q for codons %v, but got %q", tc.expected, tc.codons, derived)
+		}
+	}
+}
+
+func TestDeriveTerminatesForNonRecursiveGrammar(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		genotype := MustNewGrammaticalGenotype(5, 256)
+		genotype.Grammar = assignmentGrammar
+
+		derived, err := genotype.Derive("<stmt>", 0)
+
+		if err != nil {
+			t.Fatalf("Expected derivation to terminate, but got %v", err)
+		}
+		if len(derived) != 5 {
+			t.Errorf("Expected a derived statement of length %d, but got %q", 5, derived)
+		}
+	}
+}
+
+func TestDeriveSingleCodonChange(t *testing.T) {
+	genotype := &GrammaticalGenotype{Codons: []int{0, 0, 1, 0, 2}, Grammar: assignmentGrammar}
+	original, _ := genotype.Derive("<stmt>", 0)
+
+	// Codon 0 is consumed by the single <stmt> alternative; every later codon chooses a production.
+	for i := 1; i < len(genotype.Codons); i++ {
+		mutated := &GrammaticalGenotype{Codons: append([]int(nil), genotype.Codons...), Grammar: assignmentGrammar}
+		mutated.Codons[i]++
+
+		derived, err := mutated.Derive("<stmt>", 0)
+
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if derived == original {
+			t.Errorf("Expected changing codon %d to change %q", i, original)
+		}
+	}
+}
+
+func TestNewGrammaticalGenotypeErrors(t *testing.T) {
+	cases := []struct {
+		codonCount  int
+		codonRange  int
+		expectedErr error
+	}{
+		{codonCount: 5, codonRange: 256, expectedErr: nil},
+		{codonCount: 0, codonRange: 256, expectedErr: ErrInvalidCodons},
+		{codonCount: 5, codonRange: 0, expectedErr: ErrInvalidCodons},
+	}
+
+	for _, tc := range cases {
+		if _, err := NewGrammaticalGenotype(tc.codonCount, tc.codonRange); !errors.Is(err, tc.expectedErr) {
+			t.Errorf("Expected error %v for %d codons in range %d, but got %v", tc.expectedErr, tc.codonCount, tc.codonRange, err)
+		}
+	}
+}
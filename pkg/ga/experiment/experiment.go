@@ -0,0 +1,130 @@
+// Package experiment provides utilities for benchmarking genetic algorithm configurations,
+// such as running a configuration several times and aggregating the results.
+package experiment
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// MultiRun runs a GA configuration several times in parallel and aggregates the results.
+//
+// NewGA must return a fresh, fully configured GA for each run. Each GA is initialized with
+// PopulationSize individuals created by InitFunc and evolved using EvalFunc. At most Workers
+// runs execute concurrently; if Workers is not positive, runtime.NumCPU() is used.
+type MultiRun struct {
+	Runs           int
+	Workers        int
+	PopulationSize int
+	NewGA          func() *ga.GA
+	InitFunc       func() *ga.Genotype
+	EvalFunc       func(*ga.Genotype) *ga.Phenotype
+}
+
+// MultiRunResult holds the aggregated results of a MultiRun.
+//
+// BestFitnessPerRun and GenerationsPerRun hold the best final fitness and the number of
+// generations evolved for each run, in run order. The standard deviations are population
+// standard deviations.
+type MultiRunResult struct {
+	BestFitnessPerRun []float64
+	GenerationsPerRun []int
+	MeanBestFitness   float64
+	StdDevBestFitness float64
+	MeanGenerations   float64
+	StdDevGenerations float64
+}
+
+// Execute performs all runs and aggregates their results.
+//
+// Returns:
+// - The aggregated results.
+// - An error if the MultiRun is misconfigured or a run fails to initialize or to evolve.
+func (m *MultiRun) Execute() (*MultiRunResult, error) {
+	if m.Runs <= 0 {
+		return nil, errors.New("experiment: Runs must be positive")
+	}
+	if m.PopulationSize <= 0 {
+		return nil, errors.New("experiment: PopulationSize must be positive")
+	}
+	if m.NewGA == nil || m.InitFunc == nil || m.EvalFunc == nil {
+		return nil, errors.New("experiment: NewGA, InitFunc, and EvalFunc must be set")
+	}
+
+	workers := m.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	result := &MultiRunResult{
+		BestFitnessPerRun: make([]float64, m.Runs),
+		GenerationsPerRun: make([]int, m.Runs),
+	}
+	errs := make([]error, m.Runs)
+
+	runs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, m.Runs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for run := range runs {
+				result.BestFitnessPerRun[run], result.GenerationsPerRun[run], errs[run] = m.run()
+			}
+		}()
+	}
+	for run := 0; run < m.Runs; run++ {
+		runs <- run
+	}
+	close(runs)
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	generations := make([]float64, m.Runs)
+	for i, g := range result.GenerationsPerRun {
+		generations[i] = float64(g)
+	}
+	result.MeanBestFitness, result.StdDevBestFitness = meanStdDev(result.BestFitnessPerRun)
+	result.MeanGenerations, result.StdDevGenerations = meanStdDev(generations)
+	return result, nil
+}
+
+// run performs a single run.
+//
+// Returns:
+// - The fitness of the best individual returned by Evolve.
+// - The number of generations evolved.
+// - An error if the GA fails to initialize or to evolve.
+func (m *MultiRun) run() (float64, int, error) {
+	g := m.NewGA()
+	if err := g.Initialize(m.PopulationSize, m.InitFunc, m.EvalFunc); err != nil {
+		return 0, 0, err
+	}
+	best, _, err := g.Evolve(m.EvalFunc)
+	if err != nil {
+		return 0, 0, err
+	}
+	return best.Phenotype.Fitness, g.Generation(), nil
+}
+
+// meanStdDev returns the mean and population standard deviation of the given values.
+func meanStdDev(values []float64) (float64, float64) {
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,153 @@
+package experiment
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func newGA(generations int) func() *ga.GA {
+	return func() *ga.GA {
+		return &ga.GA{
+			Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 2) },
+			Crossover:     ga.SinglePointCrossover,
+			Mutation:      ga.BitFlipMutation,
+			CrossoverRate: 0.7,
+			MutationRate:  0.05,
+			Generations:   generations,
+		}
+	}
+}
+
+func TestMultiRunConstantFitness(t *testing.T) {
+	cases := []struct {
+		fitness     float64
+		generations int
+		workers     int
+	}{
+		{fitness: 3.5, generations: 5, workers: 1},
+		{fitness: -2.0, generations: 10, workers: 4},
+	}
+
+	for _, tc := range cases {
+		m := &MultiRun{
+			Runs:           8,
+			Workers:        tc.workers,
+			PopulationSize: 10,
+			NewGA:          newGA(tc.generations),
+			InitFunc:       func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) },
+			EvalFunc:       func(*ga.Genotype) *ga.Phenotype { return &ga.Phenotype{Fitness: tc.fitness} },
+		}
+
+		result, err := m.Execute()
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(result.BestFitnessPerRun) != m.Runs || len(result.GenerationsPerRun) != m.Runs {
+			t.Fatalf("Expected %d results per run, but got %d and %d", m.Runs, len(result.BestFitnessPerRun), len(result.GenerationsPerRun))
+		}
+		if result.MeanBestFitness != tc.fitness || result.StdDevBestFitness != 0 {
+			t.Errorf("Expected mean %f and standard deviation 0, but got %f and %f", tc.fitness, result.MeanBestFitness, result.StdDevBestFitness)
+		}
+		if result.MeanGenerations != float64(tc.generations) || result.StdDevGenerations != 0 {
+			t.Errorf("Expected mean generations %d and standard deviation 0, but got %f and %f", tc.generations, result.MeanGenerations, result.StdDevGenerations)
+		}
+	}
+}
+
+func TestMeanStdDev(t *testing.T) {
+	mean, stdDev := meanStdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+
+	if mean != 5 {
+		t.Errorf("Expected mean %f, but got %f", 5.0, mean)
+	}
+	if math.Abs(stdDev-2) > 1e-12 {
+		t.Errorf("Expected standard deviation %f, but got %f", 2.0, stdDev)
+	}
+}
+
+func TestMultiRunInvalid(t *testing.T) {
+	cases := []struct {
+		m *MultiRun
+	}{
+		{m: &MultiRun{Runs: 0, PopulationSize: 10, NewGA: newGA(1)}},
+		{m: &MultiRun{Runs: 2, PopulationSize: 0, NewGA: newGA(1)}},
+		{m: &MultiRun{Runs: 2, PopulationSize: 10}},
+	}
+
+	for _, tc := range cases {
+		if _, err := tc.m.Execute(); err == nil {
+			t.Errorf("Expected an error for %+v, but got nil", tc.m)
+		}
+	}
+}
+
+func TestMultiRunMinimize(t *testing.T) {
+	const generations = 30
+	// With a single worker, runs execute in order, so the final populations are recorded
+	// in run order.
+	var lowest []float64
+	m := &MultiRun{
+		Runs:           3,
+		Workers:        1,
+		PopulationSize: 10,
+		NewGA: func() *ga.GA {
+			g := newGA(generations)()
+			g.Minimize = true
+			g.HistoryCapacity = 5
+			g.OnGeneration = func(generation int, population []*ga.Individual) {
+				if generation == generations-1 {
+					best := population[0].Phenotype.Fitness
+					for _, ind := range population {
+						best = math.Min(best, ind.Phenotype.Fitness)
+					}
+					lowest = append(lowest, best)
+				}
+			}
+			return g
+		},
+		InitFunc: func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) },
+		EvalFunc: func(genotype *ga.Genotype) *ga.Phenotype {
+			ones := 0
+			for _, gene := range genotype.Genome {
+				ones += int(gene)
+			}
+			return &ga.Phenotype{Fitness: float64(ones)}
+		},
+	}
+
+	result, err := m.Execute()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for run := range result.BestFitnessPerRun {
+		if result.BestFitnessPerRun[run] != lowest[run] {
+			t.Errorf("Expected run %d to report the lowest fitness %f, but got %f", run, lowest[run], result.BestFitnessPerRun[run])
+		}
+		if result.GenerationsPerRun[run] != generations {
+			t.Errorf("Expected run %d to report %d generations, but got %d", run, generations, result.GenerationsPerRun[run])
+		}
+	}
+}
+
+func TestMultiRunEvolveError(t *testing.T) {
+	m := &MultiRun{
+		Runs:           2,
+		PopulationSize: 10,
+		NewGA: func() *ga.GA {
+			g := newGA(5)()
+			// The initial population uses up the budget, so Evolve fails.
+			g.EvaluationBudget = 10
+			return g
+		},
+		InitFunc: func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) },
+		EvalFunc: func(*ga.Genotype) *ga.Phenotype { return &ga.Phenotype{Fitness: 1} },
+	}
+
+	if _, err := m.Execute(); !errors.Is(err, ga.ErrBudgetExceeded) {
+		t.Errorf("Expected an error wrapping %v, but got %v", ga.ErrBudgetExceeded, err)
+	}
+}
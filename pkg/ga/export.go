@@ -0,0 +1,94 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including exporting the statistics history in machine-readable formats.
+package ga
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// ErrEmptyHistory is returned when exporting a history that has no entries.
+var ErrEmptyHistory = errors.New("history is empty")
+
+// historyColumns are the column names used when exporting the history.
+var historyColumns = []string{"generation", "bestFitness", "worstFitness", "averageFitness", "diversity"}
+
+// historyRecord is the JSON representation of one generation of the history.
+type historyRecord struct {
+	Generation     int     `json:"generation"`
+	BestFitness    float64 `json:"bestFitness"`
+	WorstFitness   float64 `json:"worstFitness"`
+	AverageFitness float64 `json:"averageFitness"`
+	Diversity      float64 `json:"diversity"`
+}
+
+// ExportHistoryCSV writes the statistics history as CSV, with a header row followed by one
+// row per generation. The columns are generation, bestFitness, worstFitness, averageFitness,
+// and diversity.
+//
+// Parameters:
+// - w: the writer to write the CSV to.
+//
+// Returns:
+// - ErrEmptyHistory if the history is empty, or an error from writing to w.
+func (ga *GA) ExportHistoryCSV(w io.Writer) error {
+	if len(ga.History) == 0 {
+		return ErrEmptyHistory
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(historyColumns); err != nil {
+		return err
+	}
+	first := ga.firstHistoryGeneration()
+	for i, stats := range ga.GetHistory() {
+		record := []string{
+			strconv.Itoa(first + i),
+			formatFloat(stats.BestFitness),
+			formatFloat(stats.WorstFitness),
+			formatFloat(stats.AverageFitness),
+			formatFloat(stats.Diversity),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportHistoryJSON writes the statistics history as a JSON array with one object per
+// generation. The objects have the fields generation, bestFitness, worstFitness,
+// averageFitness, and diversity.
+//
+// Parameters:
+// - w: the writer to write the JSON to.
+//
+// Returns:
+// - ErrEmptyHistory if the history is empty, or an error from writing to w.
+func (ga *GA) ExportHistoryJSON(w io.Writer) error {
+	if len(ga.History) == 0 {
+		return ErrEmptyHistory
+	}
+
+	first := ga.firstHistoryGeneration()
+	records := make([]historyRecord, len(ga.History))
+	for i, stats := range ga.GetHistory() {
+		records[i] = historyRecord{
+			Generation:     first + i,
+			BestFitness:    stats.BestFitness,
+			WorstFitness:   stats.WorstFitness,
+			AverageFitness: stats.AverageFitness,
+			Diversity:      stats.Diversity,
+		}
+	}
+	return json.NewEncoder(w).Encode(records)
+}
+
+// formatFloat formats a float64 with the minimum number of digits needed to represent it exactly.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,124 @@
+package ga
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newEvolvedGA() *GA {
+	ga := &GA{
+		Selection:     func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:     SinglePointCrossover,
+		Mutation:      BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.05,
+		Generations:   10,
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	ga.Evolve(oneMax)
+	return ga
+}
+
+func TestExportHistoryCSV(t *testing.T) {
+	ga := newEvolvedGA()
+
+	var buf bytes.Buffer
+	if err := ga.ExportHistoryCSV(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected valid CSV, but got error: %v", err)
+	}
+	if len(records) != len(ga.History)+1 {
+		t.Fatalf("Expected %d rows, but got %d", len(ga.History)+1, len(records))
+	}
+	if header := strings.Join(records[0], ","); header != "generation,bestFitness,worstFitness,averageFitness,diversity" {
+		t.Errorf("Expected header row of column names, but got %q", header)
+	}
+
+	for gen, stats := range ga.History {
+		record := records[gen+1]
+		if record[0] != strconv.Itoa(gen) {
+			t.Errorf("Expected generation %d, but got %s", gen, record[0])
+		}
+		for i, expected := range []float64{stats.BestFitness, stats.WorstFitness, stats.AverageFitness, stats.Diversity} {
+			value, err := strconv.ParseFloat(record[i+1], 64)
+			if err != nil {
+				t.Fatalf("Expected a number in column %s, but got %q", records[0][i+1], record[i+1])
+			}
+			if math.Abs(value-expected) > 1e-10 {
+				t.Errorf("Expected %s %f at generation %d, but got %f", records[0][i+1], expected, gen, value)
+			}
+		}
+	}
+}
+
+func TestExportHistoryJSON(t *testing.T) {
+	ga := newEvolvedGA()
+
+	var buf bytes.Buffer
+	if err := ga.ExportHistoryJSON(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	var records []map[string]float64
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("Expected valid JSON, but got error: %v", err)
+	}
+	if len(records) != len(ga.History) {
+		t.Fatalf("Expected %d records, but got %d", len(ga.History), len(records))
+	}
+	for gen, stats := range ga.History {
+		if records[gen]["generation"] != float64(gen) || math.Abs(records[gen]["bestFitness"]-stats.BestFitness) > 1e-10 {
+			t.Errorf("Expected record for generation %d with best fitness %f, but got %v", gen, stats.BestFitness, records[gen])
+		}
+	}
+}
+
+func TestExportEmptyHistory(t *testing.T) {
+	ga := &GA{}
+
+	if err := ga.ExportHistoryCSV(&bytes.Buffer{}); !errors.Is(err, ErrEmptyHistory) {
+		t.Errorf("Expected ErrEmptyHistory from ExportHistoryCSV, but got %v", err)
+	}
+	if err := ga.ExportHistoryJSON(&bytes.Buffer{}); !errors.Is(err, ErrEmptyHistory) {
+		t.Errorf("Expected ErrEmptyHistory from ExportHistoryJSON, but got %v", err)
+	}
+}
+
+func TestExportHistoryJSONWithCapacity(t *testing.T) {
+	ga := &GA{
+		Selection:       func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:       SinglePointCrossover,
+		Mutation:        BitFlipMutation,
+		CrossoverRate:   0.7,
+		MutationRate:    0.05,
+		Generations:     10,
+		HistoryCapacity: 3,
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	ga.Evolve(oneMax)
+
+	var buf bytes.Buffer
+	if err := ga.ExportHistoryJSON(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	var records []historyRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("Expected valid JSON, but got error: %v", err)
+	}
+
+	for i, expected := range []int{8, 9, 10} {
+		if records[i].Generation != expected {
+			t.Errorf("Expected generation %d at index %d, but got %d", expected, i, records[i].Generation)
+		}
+	}
+}
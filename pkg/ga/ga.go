@@ -3,66 +3,1270 @@
 package ga
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Okabe-Junya/gago/internal/logger"
+	"github.com/Okabe-Junya/gago/pkg/ga/adaptive"
+	"github.com/Okabe-Junya/gago/pkg/ga/cache"
+	"github.com/Okabe-Junya/gago/pkg/ga/qd"
+	"github.com/Okabe-Junya/gago/pkg/ga/surrogate"
+	"github.com/Okabe-Junya/gago/pkg/ga/tabu"
 )
 
 // GA represents the genetic algorithm, including its population, genetic operators,
 // and parameters for crossover and mutation rates, and the number of generations to evolve.
+//
+// If Cache is set, phenotypes are memoized by genome so that identical genotypes are
+// evaluated only once, and each cache hit receives its own copy of the stored phenotype.
+// FeasibilityCheck reports whether an individual satisfies the problem's constraints, and is
+// used by the selection operator returned by ConstrainedTournament.
+//
+// If both CrossoverPool and SelectionPolicy are set, the crossover operator is chosen from
+// CrossoverPool each generation by SelectionPolicy instead of using Crossover, and the policy
+// is rewarded with the resulting improvement in average fitness.
 type GA struct {
-	Population    []*Individual
-	Selection     func([]*Individual) []*Individual
-	Crossover     func([]*Individual, float64) []*Individual
-	Mutation      func([]*Individual, float64)
-	CrossoverRate float64
-	MutationRate  float64
-	Generations   int
-	EnableLogger  bool
-	Logger        *logger.Logger
+	Population       []*Individual
+	Selection        func([]*Individual) []*Individual
+	Crossover        func([]*Individual, float64) []*Individual
+	Mutation         func([]*Individual, float64)
+	CrossoverRate    float64
+	MutationRate     float64
+	Generations      int
+	EnableLogger     bool
+	Logger           *logger.Logger
+	Cache            *cache.FitnessCache
+	FeasibilityCheck func(*Individual) bool
+	CrossoverPool    []func([]*Individual, float64) []*Individual
+	SelectionPolicy  adaptive.AdaptiveOperatorSelector
+
+	// SelectionPressureMetric, if set, measures the selection pressure of the Selection
+	// operator on the population, recorded as SelectionPressure in the statistics of every
+	// generation. TournamentSelectionPressure, RouletteWheelSelectionPressure, and
+	// RankSelectionPressure measure it for common selection schemes.
+	SelectionPressureMetric func([]*Individual) float64
+
+	// TrackCrossoverPoints, if set, makes the crossover operators returned by
+	// SinglePointCrossover and MultiPointCrossover record the crossover points they use.
+	// CrossoverPointHistory holds, for every generation since Initialize, the points used
+	// in that generation.
+	TrackCrossoverPoints  bool
+	CrossoverPointHistory [][]int
+	crossoverPoints       []int
+
+	// CrossoverRateMap, if set, holds the probability with which the gene at each position is
+	// exchanged by the crossover operator returned by UniformCrossover, instead of the 50% of
+	// UniformCrossover. CrossoverHeatMap estimates one from History.
+	CrossoverRateMap []float64
+
+	// CrossoverRateSchedule, if set, is used instead of CrossoverRate, which it leaves
+	// unchanged, to choose the crossover rate of every generation: the rate it returns for the
+	// zero-based generation and Generations.
+	CrossoverRateSchedule func(gen, maxGen int) float64
+
+	// ImmigrationRate and ImmigrationInterval, if both positive, replace the worst
+	// ImmigrationRate fraction of the population with freshly initialized individuals
+	// every ImmigrationInterval generations. The best individual is never replaced.
+	ImmigrationRate     float64
+	ImmigrationInterval int
+
+	// TabuList, if set, records the genome of every offspring before it is evaluated.
+	// An offspring whose genome is already tabu is mutated with Mutation and MutationRate
+	// until it escapes the list, up to TabuEscapeAttempts times.
+	TabuList           *tabu.TabuList
+	TabuEscapeAttempts int
+
+	// DeduplicateInterval, if positive, replaces individuals that duplicate the genotype of
+	// another with freshly initialized individuals every DeduplicateInterval generations.
+	DeduplicateInterval int
+
+	// MinDiversity and RestartFraction, if both positive, trigger a catastrophic restart
+	// whenever the population's diversity falls below MinDiversity at the end of a generation:
+	// the worst RestartFraction of the population is replaced with freshly initialized
+	// individuals, preserving the best. RestartCount counts the restarts performed.
+	MinDiversity    float64
+	RestartFraction float64
+	RestartCount    int
+
+	// ElitismCount, if positive, is the number of best individuals carried over unchanged
+	// from each generation to the next, replacing the worst of the evaluated offspring.
+	ElitismCount int
+
+	// ConvergenceEpsilon, if positive, overrides DefaultConvergenceEpsilon as the diversity
+	// below which the population is considered converged. MaxMutationRate, if positive,
+	// switches the GA to pure exploration while the population has converged: MutationRate
+	// is raised to MaxMutationRate at the end of a generation that converged, and restored
+	// at the end of the first generation that no longer has.
+	ConvergenceEpsilon float64
+	MaxMutationRate    float64
+	exploring          bool
+	baseMutationRate   float64
+
+	// AdaptiveStrategy selects how MutationRate is adapted at the end of each generation:
+	// AdaptiveStrategyDiversity (or empty) switches to exploration on convergence as described
+	// above, and AdaptiveStrategySuccessRate applies the 1/5 success rule, scaling MutationRate
+	// by SuccessRateFactor (DefaultSuccessRateFactor if not positive) according to
+	// MutationSuccessRate, the fraction of the mutations of the last generation that improved
+	// on the fitness before mutation. Initialize restores the MutationRate configured before
+	// the rule adapted it and clears MutationSuccessRate.
+	AdaptiveStrategy    string
+	SuccessRateFactor   float64
+	MutationSuccessRate float64
+	adaptingSuccessRate bool
+	successBaseRate     float64
+
+	// SeedIndividuals, if set, are placed at the front of the initial population by
+	// Initialize, with the remainder filled by newly initialized individuals. Each seed
+	// must have a non-nil Genotype and Phenotype.
+	SeedIndividuals []*Individual
+
+	// History holds the statistics of the initial population followed by those of
+	// the population at the end of each generation. If HistoryCapacity is positive, History
+	// is a ring buffer holding only the latest HistoryCapacity entries, and GetHistory must
+	// be used to read them in chronological order.
+	History         []*Statistics
+	HistoryCapacity int
+
+	// SmoothConvergenceCheck, if set, makes termination conditions that detect convergence
+	// from History, such as termination.ConvergenceTermination, use a smoothed history, so
+	// that noise in the fitness on noisy landscapes is not mistaken for progress or
+	// stagnation.
+	SmoothConvergenceCheck bool
+
+	// HallOfFame holds copies of the best individuals seen during evolution, sorted by
+	// descending fitness and capped at HallOfFameSize. It is maintained only if
+	// HallOfFameSize is positive.
+	HallOfFame     []*Individual
+	HallOfFameSize int
+
+	// MAPElitesArchive, if set, is updated with every individual of the initial population
+	// and of the population at the end of each generation. Its Minimize field is set to that
+	// of the GA before each update.
+	MAPElitesArchive *qd.MAPElites[*Individual]
+
+	// OperatorTimings holds the time spent in each phase of Evolve since Initialize, keyed
+	// by PhaseSelection, PhaseCrossover, PhaseMutation, PhaseEvaluation, and PhaseStatistics.
+	OperatorTimings map[string]time.Duration
+
+	// ProfilingEnabled, if set, runs each phase of Evolve timed in OperatorTimings under the
+	// pprof label "phase", with the phase name as its value, so that CPU profiles can be
+	// broken down by phase. For example, with a profile written by pprof.StartCPUProfile,
+	//
+	//	go tool pprof -tags cpu.prof
+	//
+	// reports the share of samples spent in each phase, and
+	//
+	//	go tool pprof -tagfocus=phase=evaluation cpu.prof
+	//
+	// restricts the profile to fitness evaluation. Goroutines started by a phase, such as
+	// the workers of NumParallelEvals, inherit its label.
+	ProfilingEnabled bool
+
+	// Metrics, if set, is updated at the end of each generation of Evolve.
+	Metrics MetricsRecorder
+
+	// EvaluationsUsed counts the calls to the fitness function since Initialize. Fitness
+	// values served by Cache are not counted. It is updated atomically.
+	EvaluationsUsed int64
+
+	// NumParallelEvals, if greater than 1, is the number of workers that evaluate the
+	// population concurrently after each generation's variation. The fitness function and
+	// ConstraintViolation must then be safe for concurrent use.
+	NumParallelEvals int
+
+	// EvalRepeats, if greater than 1, is the number of times Initialize and Evolve evaluate
+	// each genotype with the fitness function, averaging the results with NoisyEval to
+	// reduce the noise of stochastic problems. The BatchEvaluator, if set, likewise evaluates
+	// each batch EvalRepeats times. EvaluationsUsed and EvaluationBudget count each averaged
+	// evaluation once.
+	EvalRepeats int
+
+	// BatchEvaluator, if set, evaluates the offspring of each generation, or its trials in DE
+	// mode, in a single batch in place of the fitness function passed to Evolve, and takes
+	// precedence over NumParallelEvals. Individuals created outside of breeding, such as
+	// immigrants, are still evaluated one at a time by the fitness function.
+	BatchEvaluator BatchEvaluator
+
+	// RecycleGenomes, if set, returns the genomes of the individuals that did not survive a
+	// generation to a pool from which SinglePointCrossover, UniformCrossover, and
+	// MultiPointCrossover take the genomes of their offspring. Callers must then not keep
+	// references to individuals of past generations, for example from OnGeneration.
+	RecycleGenomes bool
+
+	// EvaluationBudget, if positive, caps EvaluationsUsed. Evolve stops before a generation
+	// whose evaluations could exceed the budget, and individuals that already have a
+	// Phenotype keep it instead of being evaluated once the budget is exhausted.
+	EvaluationBudget int
+
+	// PenaltySchedule and ConstraintViolation, if both set, penalize infeasible individuals:
+	// whenever an individual is evaluated, its ConstraintViolation is set to the result of
+	// ConstraintViolation and the penalty given by PenaltySchedule for the current generation
+	// is subtracted from its fitness, or added to it if Minimize is set.
+	PenaltySchedule     PenaltySchedule
+	ConstraintViolation func(*Individual) float64
+
+	// MaxAge and AgeBasedReplacement, if AgeBasedReplacement is set, replace every individual
+	// older than MaxAge generations with a freshly initialized individual at the end of each
+	// generation. See AgeBasedReplacement.
+	MaxAge              int
+	AgeBasedReplacement bool
+
+	// PopSizeAdaptInterval, if positive, adapts the population size every PopSizeAdaptInterval
+	// generations: if the diversity is below LowDiversityThreshold, PopGrowthRate freshly
+	// initialized individuals are added, up to MaxPopSize; if it is above
+	// HighDiversityThreshold, the PopShrinkRate worst individuals are removed, down to
+	// MinPopSize. A non-positive MaxPopSize leaves the growth unbounded.
+	MinPopSize             int
+	MaxPopSize             int
+	PopSizeAdaptInterval   int
+	LowDiversityThreshold  float64
+	HighDiversityThreshold float64
+	PopGrowthRate          int
+	PopShrinkRate          int
+
+	// ObjectiveCount, if positive, is the number of objectives of a multi-objective problem.
+	// Initialize and Evolve then fail with ErrObjectiveCount if an evaluated Phenotype does
+	// not hold exactly ObjectiveCount Objectives. Since Surrogate restores only the Fitness of
+	// a Phenotype, it cannot be combined with ObjectiveCount.
+	ObjectiveCount int
+
+	// Minimize, if set, makes lower fitness better. Selection operators are then applied to
+	// negated fitness values, so operators that require non-negative fitness, such as
+	// RouletteWheelSelection, are not supported unless NormalizeFitnessBeforeSelection is set.
+	// The statistics in History, the hall of fame, catastrophic restarts, immigration, and
+	// differential evolution all treat the lowest fitness as the best, and MAPElitesArchive
+	// keeps the elites with the lowest fitness.
+	Minimize bool
+
+	// NormalizeFitnessBeforeSelection, if set, applies Selection to fitness values rescaled
+	// to [0, 1] by NormalizeFitness, with 1 for the best individual, so that operators that
+	// require non-negative fitness, such as RouletteWheelSelection, can be used with negative
+	// fitness values. The fitness values of the individuals are left unchanged.
+	NormalizeFitnessBeforeSelection bool
+
+	// OnGeneration, if set, is called at the end of each generation of Evolve with the
+	// zero-based generation number and the resulting population.
+	OnGeneration func(generation int, population []*Individual)
+
+	// SchemaTracker, if set, records the schemata of the population at the end of each
+	// generation of Evolve, just before OnGeneration is called. An analysis.SchemaTracker
+	// can be set here.
+	SchemaTracker SchemaRecorder
+
+	// Termination, if set, is evaluated at the end of each generation of Evolve, after
+	// OnGeneration, and stops the evolution early once it is met. Generations still bounds
+	// the number of generations.
+	Termination TerminationCondition
+
+	// DEMode, if set, makes Evolve run differential evolution instead of selection,
+	// crossover, and mutation. DEF is the scale factor and DECR the crossover rate, which
+	// default to DefaultDEF and DefaultDECR when zero. DE mode requires real-valued genotypes,
+	// and Evolve returns ErrNotRealValued otherwise.
+	// DEAdaptive, if set, self-adapts F and CR per individual as in jDE instead of using DEF
+	// and DECR; DETau1 and DETau2 are the probabilities of resetting an individual's F and
+	// CR each generation, which default to DefaultDETau when zero.
+	DEMode     bool
+	DEF        float64
+	DECR       float64
+	DEAdaptive bool
+	DETau1     float64
+	DETau2     float64
+
+	// Surrogate, if set, is trained with every exact fitness evaluation. Once it holds more
+	// than SurrogateThreshold training points, its reliable predictions replace the fitness
+	// function, except in every SurrogateInterval-th generation, which is evaluated exactly.
+	// A non-positive SurrogateInterval never forces exact evaluation. The Surrogate requires
+	// binary genotypes, and Initialize and Evolve return ErrSurrogateEncoding otherwise.
+	Surrogate          *surrogate.KNNSurrogate
+	SurrogateThreshold int
+	SurrogateInterval  int
+
+	// tracing provides the Tracer field when built with the otel build tag.
+	tracing
+
+	initializeGenotype func() *Genotype
+	generation         int
+	historyRecorded    int
+	// population calculates the statistics of Population lazily, so that the statistics
+	// read several times in a generation are calculated once.
+	population *Population
+	// contextLogger is the request-scoped logger derived from Logger for the context passed
+	// to EvolveWithContext, used instead of Logger while EvolveWithContext runs.
+	contextLogger *logger.Logger
+}
+
+// The phases of Evolve timed in OperatorTimings. In DE mode, the differential evolution step is
+// timed as evaluation. Immigration and catastrophic restarts are also timed as evaluation.
+const (
+	PhaseSelection  = "selection"
+	PhaseCrossover  = "crossover"
+	PhaseMutation   = "mutation"
+	PhaseEvaluation = "evaluation"
+	PhaseStatistics = "statistics"
+)
+
+// SchemaRecorder receives the population at the end of each generation of Evolve to record
+// the schemata it matches.
+type SchemaRecorder interface {
+	// OnGeneration records the schemata of the population at the end of the given zero-based
+	// generation.
+	OnGeneration(generation int, population []*Individual)
+}
+
+// MetricsRecorder receives the progress of Evolve at the end of each generation, for example
+// to export it for monitoring.
+type MetricsRecorder interface {
+	// RecordGeneration records the state of the GA after the given number of generations
+	// completed since Initialize, the total number of fitness evaluations, and the time the
+	// generation just completed took.
+	RecordGeneration(generations int, stats *Statistics, evaluations int64, duration time.Duration)
+}
+
+// ErrInvalidSeed is returned by Initialize when a seed individual lacks a Genotype or Phenotype.
+var ErrInvalidSeed = errors.New("seed individual must have a non-nil Genotype and Phenotype")
+
+// ErrObjectiveCount is returned by Initialize and Evolve when an evaluated phenotype does not
+// hold ObjectiveCount objectives.
+var ErrObjectiveCount = errors.New("phenotype has the wrong number of objectives")
+
+// ErrSurrogateEncoding is returned by Initialize and Evolve when Surrogate is set and a
+// genotype is not binary, since the Hamming distance of the KNNSurrogate is meaningless for
+// other encodings.
+var ErrSurrogateEncoding = errors.New("surrogate requires binary genotypes")
+
+// ErrBudgetExceeded is returned by Evolve when the evaluation budget is exhausted before
+// any generation completes.
+var ErrBudgetExceeded = errors.New("evaluation budget exceeded")
+
+// EvaluationError records a fitness evaluation that panicked during Evolve.
+type EvaluationError struct {
+	// IndividualIndex is the index in the population of the individual being evaluated.
+	IndividualIndex int
+	// Cause is the value the fitness function panicked with.
+	Cause interface{}
+}
+
+// Error implements the error interface.
+func (e EvaluationError) Error() string {
+	return fmt.Sprintf("evaluation of individual %d panicked: %v", e.IndividualIndex, e.Cause)
 }
 
 // Initialize initializes the population with the specified size, using the provided
 // functions to create and evaluate genotypes.
 //
+// If SeedIndividuals is set, the seeds are placed at the front of the population and only
+// the remainder is newly initialized. Seeds beyond populationSize are dropped with a warning.
+//
 // Parameters:
 // - populationSize: the size of the population to be initialized.
 // - initializeGenotype: a function to create a new Genotype.
 // - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
-func (ga *GA) Initialize(populationSize int, initializeGenotype func() *Genotype, evaluatePhenotype func(*Genotype) *Phenotype) {
-	ga.Population = make([]*Individual, populationSize)
-	for i := 0; i < populationSize; i++ {
-		genotype := initializeGenotype()
-		phenotype := evaluatePhenotype(genotype)
-		ga.Population[i] = &Individual{Genotype: genotype, Phenotype: phenotype}
-	}
+//
+// Returns:
+// - An error wrapping ErrInvalidSeed if a seed individual is invalid, ErrSurrogateEncoding if
+// Surrogate is set and a genotype is not binary, in which case Population is left empty, or
+// ErrObjectiveCount if an evaluated phenotype has the wrong number of objectives.
+func (ga *GA) Initialize(populationSize int, initializeGenotype func() *Genotype, evaluatePhenotype func(*Genotype) *Phenotype) error {
 	if ga.EnableLogger {
 		ga.initializeLogger(true)
 	}
+	evaluatePhenotype = ga.withRepeats(evaluatePhenotype)
+
+	seeds := ga.SeedIndividuals
+	for i, seed := range seeds {
+		if seed == nil || seed.Genotype == nil || seed.Phenotype == nil {
+			return fmt.Errorf("%w: seed %d", ErrInvalidSeed, i)
+		}
+	}
+	if len(seeds) > populationSize {
+		ga.warn("Truncating seed individuals to population size", "Seeds", len(seeds))
+		seeds = seeds[:populationSize]
+	}
+
+	ga.initializeGenotype = initializeGenotype
+	atomic.StoreInt64(&ga.EvaluationsUsed, 0)
+	ga.generation = 0
+	ga.leaveExploration()
+	ga.resetSuccessRule()
+	ga.Population = make([]*Individual, populationSize)
+	copy(ga.Population, seeds)
+	for _, seed := range seeds {
+		if seed.ID == 0 {
+			seed.ID = NextIndividualID()
+		}
+	}
+	for i := len(seeds); i < populationSize; i++ {
+		ga.Population[i] = &Individual{Genotype: initializeGenotype(), ID: NextIndividualID()}
+	}
+	// The genotypes are checked before any of them trains the Surrogate.
+	if err := ga.checkSurrogateEncoding(); err != nil {
+		ga.Population = nil
+		return err
+	}
+	for _, ind := range ga.Population[len(seeds):] {
+		ind.Phenotype = ga.evaluate(ind.Genotype, evaluatePhenotype)
+		ga.penalize(ind)
+	}
+	if err := ga.checkObjectives(); err != nil {
+		return err
+	}
+	ga.History = nil
+	ga.historyRecorded = 0
+	ga.CrossoverPointHistory = nil
+	ga.HallOfFame = nil
+	ga.OperatorTimings = nil
+	ga.markStatisticsDirty()
+	ga.recordGeneration()
+	return nil
+}
+
+// Reset reinitializes the GA for a new run, as Initialize does, and also clears the state
+// that adapts during a run: RestartCount and the rewards learned by SelectionPolicy, if it
+// has a Reset method. The operators and parameters of the GA are preserved.
+//
+// Parameters:
+// - populationSize: the size of the population to be initialized.
+// - initializeGenotype: a function to create a new Genotype.
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - Any error returned by Initialize.
+func (ga *GA) Reset(populationSize int, initializeGenotype func() *Genotype, evaluatePhenotype func(*Genotype) *Phenotype) error {
+	ga.RestartCount = 0
+	if policy, ok := ga.SelectionPolicy.(interface{ Reset() }); ok {
+		policy.Reset()
+	}
+	return ga.Initialize(populationSize, initializeGenotype, evaluatePhenotype)
 }
 
 // Evolve evolves the population over the specified number of generations, using the provided
 // function to evaluate the fitness of each individual after applying selection, crossover,
-// and mutation operations.
+// and mutation operations, or differential evolution if DEMode is set.
+//
+// If EvaluationBudget is set, evolution stops early once the remaining budget cannot cover
+// the evaluation of a whole population.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// If the fitness function panics while the offspring of a generation are evaluated, the
+// individual is given the worst finite fitness, so that selection discards it, a warning is
+// logged, and evolution continues.
+//
+// Returns:
+// - The best individual of the final population, or nil if the population is empty.
+// - An EvaluationError for every evaluation of the offspring that panicked, in the order
+// they occurred.
+// - ErrBudgetExceeded if the evaluation budget was exhausted before any generation completed.
+// - An error wrapping ErrNotRealValued if DEMode is set and a genotype is not real-valued, in
+// which case the population is left unchanged.
+// - An error wrapping ErrSurrogateEncoding if Surrogate is set and a genotype is not binary,
+// in which case the population is left unchanged.
+// - An error wrapping ErrObjectiveCount if an evaluated phenotype has the wrong number of
+// objectives, in which case evolution stops at the end of that generation's evaluation.
+func (ga *GA) Evolve(evaluatePhenotype func(*Genotype) *Phenotype) (*Individual, []EvaluationError, error) {
+	return ga.EvolveWithContext(context.Background(), evaluatePhenotype)
+}
+
+// EvolveWithContext is like Evolve, but logs through a request-scoped logger and stops when
+// the context is done.
+//
+// Every message that Logger receives during evolution carries the request ID stored in ctx
+// under logger.RequestIDKey, if any, as request_id. The context is checked at the start of
+// every generation.
 //
 // Parameters:
+// - ctx: the context of the request the evolution is part of.
 // - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
-func (ga *GA) Evolve(evaluatePhenotype func(*Genotype) *Phenotype) {
+//
+// Returns:
+// - The results returned by Evolve, with the error of the context if it is done before the
+// last generation starts.
+func (ga *GA) EvolveWithContext(ctx context.Context, evaluatePhenotype func(*Genotype) *Phenotype) (*Individual, []EvaluationError, error) {
+	ga.contextLogger = ga.Logger.WithContext(ctx)
+	defer func() { ga.contextLogger = nil }()
+	defer ga.traceEvolve(ctx)()
+	evaluatePhenotype = ga.withRepeats(evaluatePhenotype)
+	// The population may have been changed in place since the last call.
+	ga.markStatisticsDirty()
+	if ga.DEMode {
+		if err := ga.checkRealValued(); err != nil {
+			return ga.best(), nil, err
+		}
+	}
+	if err := ga.checkSurrogateEncoding(); err != nil {
+		return ga.best(), nil, err
+	}
+	var evaluationErrors []EvaluationError
 	for gen := 0; gen < ga.Generations; gen++ {
-		ga.log(fmt.Sprintf("Generation %d", gen), "BestFitness", findBestIndividual(ga.Population).Phenotype.Fitness)
-		ga.Population = ga.Selection(ga.Population)
-		ga.Population = ga.Crossover(ga.Population, ga.CrossoverRate)
+		if err := ctx.Err(); err != nil {
+			return ga.best(), evaluationErrors, err
+		}
+		if !ga.withinBudget(len(ga.Population)) {
+			ga.log("Evaluation budget exhausted", "EvaluationsUsed", atomic.LoadInt64(&ga.EvaluationsUsed))
+			if gen == 0 {
+				return ga.best(), evaluationErrors, ErrBudgetExceeded
+			}
+			break
+		}
+		ga.generation = gen
+		start := time.Now()
+		endGeneration := ga.traceGeneration(gen)
+		ga.log(fmt.Sprintf("Generation %d", gen), "BestFitness", bestIndividual(ga.Population, ga.Minimize).Phenotype.Fitness)
+		previous := ga.Population
+		var generationErrors []EvaluationError
+		var elites []*Individual
+		if ga.DEMode {
+			ga.timed(PhaseEvaluation, func() { generationErrors = ga.differentialEvolution(evaluatePhenotype) })
+		} else {
+			elites = ga.elites()
+			generationErrors = ga.breed(evaluatePhenotype)
+			ga.preserveElites(elites)
+		}
+		ga.markStatisticsDirty()
+		for _, evaluationError := range generationErrors {
+			ga.warn("Fitness evaluation panicked", "Error", evaluationError.Error())
+			evaluationErrors = append(evaluationErrors, evaluationError)
+		}
+		ga.timed(PhaseEvaluation, func() {
+			if ga.ImmigrationRate > 0 && ga.ImmigrationInterval > 0 && gen%ga.ImmigrationInterval == 0 {
+				ga.immigrate(evaluatePhenotype)
+				ga.markStatisticsDirty()
+			}
+			if ga.DeduplicateInterval > 0 && gen%ga.DeduplicateInterval == 0 && ga.withinBudget(len(ga.Population)) {
+				ga.deduplicate(evaluatePhenotype)
+				ga.markStatisticsDirty()
+			}
+			if ga.MinDiversity > 0 && ga.RestartFraction > 0 && ga.statistics().Diversity < ga.MinDiversity {
+				ga.restart(evaluatePhenotype)
+				ga.markStatisticsDirty()
+			}
+			// Elites are copies of individuals of the previous population, so they age too.
+			ageSurvivors(append(previous[:len(previous):len(previous)], elites...), ga.Population)
+			if ga.AgeBasedReplacement && ga.withinBudget(len(ga.Population)) {
+				ga.Population = AgeBasedReplacement(ga.Population, ga.MaxAge, func() *Individual {
+					return ga.newIndividual(evaluatePhenotype)
+				})
+				ga.markStatisticsDirty()
+			}
+			if ga.PopSizeAdaptInterval > 0 && gen%ga.PopSizeAdaptInterval == 0 {
+				ga.adaptPopulationSize(evaluatePhenotype)
+				ga.markStatisticsDirty()
+			}
+		})
+		if err := ga.checkObjectives(); err != nil {
+			endGeneration(math.NaN(), math.NaN())
+			return ga.best(), evaluationErrors, err
+		}
+		ga.timed(PhaseStatistics, ga.recordGeneration)
+		ga.updateAdaptiveParams()
+		if ga.SchemaTracker != nil {
+			ga.SchemaTracker.OnGeneration(gen, ga.Population)
+		}
+		if ga.OnGeneration != nil {
+			ga.OnGeneration(gen, ga.Population)
+		}
+		stats := ga.latestStatistics()
+		if ga.Metrics != nil {
+			ga.Metrics.RecordGeneration(ga.Generation(), stats, atomic.LoadInt64(&ga.EvaluationsUsed), time.Since(start))
+		}
+		endGeneration(stats.BestFitness, stats.Diversity)
+		if ga.Termination != nil && ga.Termination.Evaluate(ga) {
+			ga.log("Termination condition met", "Generation", gen)
+			break
+		}
+	}
+	ga.activeLogger().LogOperatorTimings(ga.OperatorTimings)
+	return ga.best(), evaluationErrors, nil
+}
+
+// best returns the best individual of the population, or nil if the population is empty.
+func (ga *GA) best() *Individual {
+	if len(ga.Population) == 0 {
+		return nil
+	}
+	return bestIndividual(ga.Population, ga.Minimize)
+}
+
+// checkObjectives checks that every phenotype of the population holds ObjectiveCount objectives.
+//
+// Returns:
+// - An error wrapping ErrObjectiveCount naming the first offending individual, or nil if
+// ObjectiveCount is not positive.
+func (ga *GA) checkObjectives() error {
+	if ga.ObjectiveCount <= 0 {
+		return nil
+	}
+	for i, ind := range ga.Population {
+		if count := len(ind.Phenotype.Objectives); count != ga.ObjectiveCount {
+			return fmt.Errorf("%w: individual %d has %d objectives, expected %d", ErrObjectiveCount, i, count, ga.ObjectiveCount)
+		}
+	}
+	return nil
+}
+
+// withinBudget reports whether n more fitness evaluations fit in the EvaluationBudget.
+//
+// Parameters:
+// - n: the number of evaluations.
+//
+// Returns:
+// - true if no budget is set or at least n evaluations remain.
+func (ga *GA) withinBudget(n int) bool {
+	return ga.EvaluationBudget <= 0 || atomic.LoadInt64(&ga.EvaluationsUsed)+int64(n) <= int64(ga.EvaluationBudget)
+}
+
+// timed runs f and adds its duration to the given phase in OperatorTimings.
+//
+// Parameters:
+// - phase: the phase f belongs to.
+// - f: the function to run.
+func (ga *GA) timed(phase string, f func()) {
+	start := time.Now()
+	if ga.ProfilingEnabled {
+		pprof.Do(context.Background(), pprof.Labels("phase", phase), func(context.Context) { f() })
+	} else {
+		f()
+	}
+	if ga.OperatorTimings == nil {
+		ga.OperatorTimings = make(map[string]time.Duration)
+	}
+	ga.OperatorTimings[phase] += time.Since(start)
+}
+
+// breed replaces the population with offspring produced by selection, crossover, and
+// mutation, and evaluates them.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - The errors of the evaluations that panicked.
+func (ga *GA) breed(evaluatePhenotype func(*Genotype) *Phenotype) []EvaluationError {
+	previous := ga.Population
+	previousAverage := averageFitness(ga.Population)
+	crossover, operator := ga.chooseCrossover()
+	rate := ga.CrossoverRate
+	if ga.CrossoverRateSchedule != nil {
+		rate = ga.CrossoverRateSchedule(ga.generation, ga.Generations)
+	}
+	ga.timed(PhaseSelection, func() { ga.Population = ga.selectParents() })
+	ga.timed(PhaseCrossover, func() { ga.Population = crossover(ga.Population, rate) })
+	if ga.TrackCrossoverPoints {
+		ga.CrossoverPointHistory = append(ga.CrossoverPointHistory, ga.crossoverPoints)
+		ga.crossoverPoints = nil
+	}
+	var records map[*Individual]mutationRecord
+	if ga.AdaptiveStrategy == AdaptiveStrategySuccessRate {
+		records = ga.recordBeforeMutation(previous)
+	}
+	ga.timed(PhaseMutation, func() {
 		ga.Mutation(ga.Population, ga.MutationRate)
+		if ga.TabuList != nil {
+			ga.escapeTabu()
+		}
+	})
+	var evaluationErrors []EvaluationError
+	ga.timed(PhaseEvaluation, func() {
+		evaluationErrors = ga.evaluatePopulation(evaluatePhenotype)
+		updateSelfAdaptiveRates(ga.Population, ga.Minimize)
+	})
+	if records != nil {
+		ga.MutationSuccessRate = ga.mutationSuccessRate(records)
+	}
+	if operator >= 0 {
+		improvement := averageFitness(ga.Population) - previousAverage
+		if ga.Minimize {
+			improvement = -improvement
+		}
+		ga.SelectionPolicy.Update(operator, improvement)
+	}
+	if ga.RecycleGenomes {
+		ga.recycleGenomes(previous)
+	}
+	return evaluationErrors
+}
+
+// escapeTabu mutates every individual of the population whose genome is in TabuList until it
+// escapes the list, up to TabuEscapeAttempts times, and then adds its genome to the list,
+// so that later duplicates in the population are mutated as well. An individual placed in
+// the population several times is considered once.
+func (ga *GA) escapeTabu() {
+	visited := make(map[*Individual]bool, len(ga.Population))
+	for _, ind := range ga.Population {
+		if visited[ind] {
+			continue
+		}
+		visited[ind] = true
+		for attempt := 0; attempt < ga.TabuEscapeAttempts && ga.TabuList.IsTabu(ind.Genotype.key()); attempt++ {
+			ga.Mutation([]*Individual{ind}, ga.MutationRate)
+		}
+		ga.TabuList.Add(ind.Genotype.key())
+	}
+}
+
+// recycleGenomes recycles the genomes of the individuals of the previous population that
+// are no longer part of the population. Genomes still shared with the population are kept.
+//
+// Parameters:
+// - previous: the population at the start of the generation.
+func (ga *GA) recycleGenomes(previous []*Individual) {
+	inUse := make(map[*byte]bool, len(ga.Population))
+	for _, ind := range ga.Population {
+		if ind.Genotype != nil && len(ind.Genotype.Genome) > 0 {
+			inUse[&ind.Genotype.Genome[0]] = true
+		}
+	}
+	for _, ind := range previous {
+		if ind.Genotype == nil || len(ind.Genotype.Genome) == 0 || inUse[&ind.Genotype.Genome[0]] {
+			continue
+		}
+		// Mark the genome in use so that an individual repeated in previous is recycled once.
+		inUse[&ind.Genotype.Genome[0]] = true
+		ga.recycleGenome(ind.Genotype.Genome)
+	}
+}
+
+// recycleGenome returns a genome that is no longer referenced to the pool used by the
+// crossover operators.
+//
+// Parameters:
+// - g: the genome to recycle.
+func (ga *GA) recycleGenome(g []byte) {
+	genomePool.Put(&g)
+}
+
+// selectParents applies the Selection operator to the population. If Minimize is set, the
+// operator is applied to negated fitness values, so that it prefers lower fitness, and if
+// NormalizeFitnessBeforeSelection is set, to fitness values normalized by NormalizeFitness.
+// The fitness values are restored once the operator returns.
+//
+// Returns:
+// - The selected individuals.
+func (ga *GA) selectParents() []*Individual {
+	if !ga.Minimize && !ga.NormalizeFitnessBeforeSelection {
+		return ga.Selection(ga.Population)
+	}
+	original := make(map[*Individual]float64, len(ga.Population))
+	for _, ind := range ga.Population {
+		if _, ok := original[ind]; !ok {
+			original[ind] = ind.Phenotype.Fitness
+			if ga.Minimize {
+				ind.Phenotype.Fitness = -ind.Phenotype.Fitness
+			}
+		}
+	}
+	if ga.NormalizeFitnessBeforeSelection {
+		NormalizeFitness(ga.Population)
+	}
+	defer func() {
+		for ind, fitness := range original {
+			ind.Phenotype.Fitness = fitness
+		}
+	}()
+	return ga.Selection(ga.Population)
+}
+
+// statistics returns the statistics of the current population, treating the lowest fitness
+// as the best if Minimize is set. The statistics are calculated only if the population has
+// been replaced or marked dirty with markStatisticsDirty since they were last calculated.
+//
+// Returns:
+// - The statistics of the population.
+func (ga *GA) statistics() *Statistics {
+	if ga.population == nil {
+		ga.population = &Population{}
+	}
+	p := ga.population
+	if !sharesBackingArray(p.Individuals, ga.Population) {
+		p.Individuals = ga.Population
+		p.MarkDirty()
+	}
+	p.Minimize = ga.Minimize
+	p.ConvergenceEpsilon = ga.ConvergenceEpsilon
+	p.SelectionPressureMetric = ga.SelectionPressureMetric
+	return p.GetStatistics()
+}
+
+// markStatisticsDirty marks the statistics of the current population out of date. It must be
+// called whenever the individuals of Population are changed in place.
+func (ga *GA) markStatisticsDirty() {
+	if ga.population != nil {
+		ga.population.MarkDirty()
+	}
+}
+
+// sharesBackingArray reports whether two slices share the same backing array and length.
+func sharesBackingArray(a, b []*Individual) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// recordGeneration records the statistics of the current population in History and
+// updates the hall of fame and the MAP-Elites archive.
+func (ga *GA) recordGeneration() {
+	stats := ga.statistics()
+	if ga.HistoryCapacity > 0 && len(ga.History) >= ga.HistoryCapacity {
+		ga.History[ga.historyRecorded%len(ga.History)] = stats
+	} else {
+		ga.History = append(ga.History, stats)
+	}
+	ga.historyRecorded++
+	ga.updateHallOfFame()
+	if ga.MAPElitesArchive != nil {
+		ga.MAPElitesArchive.Minimize = ga.Minimize
 		for _, ind := range ga.Population {
-			ind.Phenotype = evaluatePhenotype(ind.Genotype)
+			ga.MAPElitesArchive.Update(ind)
+		}
+	}
+}
+
+// GetHistory returns the entries of History in chronological order, whether or not the
+// ring buffer enabled by HistoryCapacity has wrapped around.
+//
+// Returns:
+// - A new slice holding the recorded statistics, oldest first.
+func (ga *GA) GetHistory() []*Statistics {
+	history := make([]*Statistics, 0, len(ga.History))
+	oldest := 0
+	if ga.HistoryCapacity > 0 && len(ga.History) > 0 {
+		oldest = (ga.historyRecorded - len(ga.History)) % len(ga.History)
+	}
+	history = append(history, ga.History[oldest:]...)
+	return append(history, ga.History[:oldest]...)
+}
+
+// firstHistoryGeneration returns the generation of the oldest entry of GetHistory, where
+// the initial population is generation 0.
+func (ga *GA) firstHistoryGeneration() int {
+	return max(ga.historyRecorded-len(ga.History), 0)
+}
+
+// latestStatistics returns the most recent entry of History.
+func (ga *GA) latestStatistics() *Statistics {
+	if ga.HistoryCapacity > 0 {
+		return ga.History[(ga.historyRecorded-1)%len(ga.History)]
+	}
+	return ga.History[len(ga.History)-1]
+}
+
+// restart replaces the worst RestartFraction of the population with freshly initialized
+// individuals, preserving the best, and increments RestartCount.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+func (ga *GA) restart(evaluatePhenotype func(*Genotype) *Phenotype) {
+	ga.log("Catastrophic restart", "RestartCount", ga.RestartCount+1)
+	ga.replaceWorst(int(ga.RestartFraction*float64(len(ga.Population))), evaluatePhenotype)
+	ga.RestartCount++
+}
+
+// immigrate replaces the worst ImmigrationRate fraction of the population with freshly
+// initialized individuals. The best individual is never replaced.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+func (ga *GA) immigrate(evaluatePhenotype func(*Genotype) *Phenotype) {
+	ga.replaceWorst(int(ga.ImmigrationRate*float64(len(ga.Population))), evaluatePhenotype)
+}
+
+// replaceWorst replaces the n worst individuals in the population with freshly initialized
+// individuals, using the genotype initializer passed to Initialize. The best individual
+// is never replaced, and no more individuals are replaced than EvaluationBudget allows.
+//
+// Parameters:
+// - n: the number of individuals to replace.
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+func (ga *GA) replaceWorst(n int, evaluatePhenotype func(*Genotype) *Phenotype) {
+	if ga.initializeGenotype == nil || len(ga.Population) == 0 {
+		return
+	}
+	if ga.EvaluationBudget > 0 {
+		n = min(n, ga.EvaluationBudget-int(atomic.LoadInt64(&ga.EvaluationsUsed)))
+	}
+	best := bestIndividual(ga.Population, ga.Minimize)
+	for _, i := range worstIndices(ga.Population, len(ga.Population), ga.Minimize) {
+		if n <= 0 {
+			break
+		}
+		if ga.Population[i] == best {
+			continue
+		}
+		ga.Population[i] = ga.newIndividual(evaluatePhenotype)
+		n--
+	}
+}
+
+// elites returns copies of the ElitismCount best individuals of the population, copied so
+// that breeding cannot modify them.
+//
+// Returns:
+// - The copies of the elites, best first.
+func (ga *GA) elites() []*Individual {
+	if ga.ElitismCount <= 0 {
+		return nil
+	}
+	indices := worstIndices(ga.Population, len(ga.Population), ga.Minimize)
+	elites := make([]*Individual, 0, min(ga.ElitismCount, len(indices)))
+	for i := len(indices) - 1; i >= 0 && len(elites) < ga.ElitismCount; i-- {
+		elites = append(elites, ga.Population[indices[i]].Clone())
+	}
+	return elites
+}
+
+// preserveElites replaces the worst individuals of the evaluated offspring with the elites
+// of the previous generation, so that the best fitness of the population never worsens.
+//
+// Parameters:
+// - elites: the elites returned by elites before breeding.
+func (ga *GA) preserveElites(elites []*Individual) {
+	for i, index := range worstIndices(ga.Population, len(elites), ga.Minimize) {
+		ga.Population[index] = elites[i]
+	}
+}
+
+// deduplicate replaces individuals that duplicate the genotype of another with freshly
+// initialized individuals, using the genotype initializer passed to Initialize.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+func (ga *GA) deduplicate(evaluatePhenotype func(*Genotype) *Phenotype) {
+	if ga.initializeGenotype == nil {
+		return
+	}
+	previous := make(map[*Individual]bool, len(ga.Population))
+	for _, ind := range ga.Population {
+		previous[ind] = true
+	}
+	population := &Population{Individuals: ga.Population, Minimize: ga.Minimize}
+	population.Deduplicate(ga.initializeGenotype, func(genotype *Genotype) *Phenotype {
+		return ga.evaluate(genotype, evaluatePhenotype)
+	})
+	for _, ind := range ga.Population {
+		if !previous[ind] {
+			ga.penalize(ind)
+		}
+	}
+}
+
+// newIndividual creates and evaluates a new individual, using the genotype initializer passed
+// to Initialize.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - A pointer to the newly created Individual.
+func (ga *GA) newIndividual(evaluatePhenotype func(*Genotype) *Phenotype) *Individual {
+	genotype := ga.initializeGenotype()
+	ind := &Individual{Genotype: genotype, Phenotype: ga.evaluate(genotype, evaluatePhenotype), ID: NextIndividualID()}
+	ga.penalize(ind)
+	return ind
+}
+
+// chooseCrossover returns the crossover operator to apply in the current generation.
+//
+// Returns:
+// - The crossover operator.
+// - The index of the operator in CrossoverPool, or -1 if the fixed Crossover operator is used.
+func (ga *GA) chooseCrossover() (func([]*Individual, float64) []*Individual, int) {
+	if len(ga.CrossoverPool) == 0 || ga.SelectionPolicy == nil {
+		return ga.Crossover, -1
+	}
+	operator := ga.SelectionPolicy.Select(len(ga.CrossoverPool))
+	return ga.CrossoverPool[operator], operator
+}
+
+// evaluatePopulation evaluates the phenotype of every individual in the population.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - The errors of the evaluations that panicked, ordered by individual index.
+func (ga *GA) evaluatePopulation(evaluatePhenotype func(*Genotype) *Phenotype) []EvaluationError {
+	defer ga.traceEvalPopulation()()
+	if ga.BatchEvaluator != nil {
+		return ga.evaluateBatch()
+	}
+	var evaluationErrors []EvaluationError
+	if ga.NumParallelEvals <= 1 {
+		for i, ind := range ga.Population {
+			if err := ga.evaluateIndividual(i, ind, evaluatePhenotype); err != nil {
+				evaluationErrors = append(evaluationErrors, *err)
+			}
+		}
+		return evaluationErrors
+	}
+
+	// Selection may place an individual in the population several times, so each distinct
+	// individual is sent to a single worker, identified by its first index.
+	indices := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < ga.NumParallelEvals; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if err := ga.evaluateIndividual(i, ga.Population[i], evaluatePhenotype); err != nil {
+					mu.Lock()
+					evaluationErrors = append(evaluationErrors, *err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	sent := make(map[*Individual]bool, len(ga.Population))
+	for i, ind := range ga.Population {
+		if !sent[ind] {
+			sent[ind] = true
+			indices <- i
+		}
+	}
+	close(indices)
+	wg.Wait()
+	sort.Slice(evaluationErrors, func(a, b int) bool {
+		return evaluationErrors[a].IndividualIndex < evaluationErrors[b].IndividualIndex
+	})
+	return evaluationErrors
+}
+
+// evaluateIndividual evaluates and penalizes an individual, unless the evaluation budget is
+// exhausted and the individual already has a Phenotype to keep. If the evaluation panics,
+// the individual is given the worst finite fitness.
+//
+// Parameters:
+// - index: the index of the individual in the population.
+// - ind: the individual to evaluate.
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - An EvaluationError if the evaluation panicked, or nil otherwise.
+func (ga *GA) evaluateIndividual(index int, ind *Individual, evaluatePhenotype func(*Genotype) *Phenotype) (evaluationError *EvaluationError) {
+	if ind.Phenotype != nil && !ga.withinBudget(1) {
+		return nil
+	}
+	defer func() {
+		if cause := recover(); cause != nil {
+			ind.Phenotype = &Phenotype{Fitness: ga.worstFitness()}
+			evaluationError = &EvaluationError{IndividualIndex: index, Cause: cause}
+		}
+	}()
+	ind.Phenotype = ga.evaluate(ind.Genotype, evaluatePhenotype)
+	ga.penalize(ind)
+	return nil
+}
+
+// worstFitness returns the worst finite fitness, given to individuals whose evaluation failed.
+func (ga *GA) worstFitness() float64 {
+	if ga.Minimize {
+		return math.MaxFloat64
+	}
+	return -math.MaxFloat64
+}
+
+// penalize records the constraint violation of an evaluated individual and worsens its
+// fitness by the penalty for the current generation, if a penalty schedule is set.
+//
+// Parameters:
+// - ind: the individual to penalize.
+func (ga *GA) penalize(ind *Individual) {
+	if ga.PenaltySchedule == nil || ga.ConstraintViolation == nil {
+		return
+	}
+	ind.ConstraintViolation = ga.ConstraintViolation(ind)
+	penalty := ga.PenaltySchedule.Penalty(ga.generation, ind.ConstraintViolation)
+	if ga.Minimize {
+		penalty = -penalty
+	}
+	ind.Phenotype.Fitness -= penalty
+}
+
+// evaluate evaluates a single genotype, consulting the fitness cache and the surrogate if set.
+//
+// Parameters:
+// - genotype: the Genotype to evaluate.
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - The Phenotype of the genotype.
+func (ga *GA) evaluate(genotype *Genotype, evaluatePhenotype func(*Genotype) *Phenotype) *Phenotype {
+	if ga.Cache == nil && ga.Surrogate == nil {
+		atomic.AddInt64(&ga.EvaluationsUsed, 1)
+		return evaluatePhenotype(genotype)
+	}
+	key := genotype.key()
+	if phenotype, ok := ga.lookup(key); ok {
+		return phenotype
+	}
+	atomic.AddInt64(&ga.EvaluationsUsed, 1)
+	phenotype := evaluatePhenotype(genotype)
+	ga.store(key, phenotype)
+	return phenotype
+}
+
+// lookup returns the phenotype of a genome from the Cache or, when it may be used, the
+// Surrogate, without evaluating it.
+//
+// Parameters:
+// - key: the key of the genome, as returned by Genotype.key.
+//
+// Returns:
+// - The phenotype of the genome.
+// - Whether it was found.
+func (ga *GA) lookup(key []byte) (*Phenotype, bool) {
+	if ga.Cache != nil {
+		if fitness, value, ok := ga.Cache.GetValue(key); ok {
+			if phenotype, ok := value.(*Phenotype); ok {
+				return phenotype.Clone(), true
+			}
+			return &Phenotype{Fitness: fitness}, true
+		}
+	}
+	if ga.useSurrogate() {
+		if fitness, ok := ga.Surrogate.Predict(key); ok {
+			return &Phenotype{Fitness: fitness}, true
+		}
+	}
+	return nil, false
+}
+
+// store records the evaluated phenotype of a genome in the Cache and the Surrogate.
+//
+// Parameters:
+// - key: the key of the genome, as returned by Genotype.key.
+// - phenotype: the evaluated phenotype of the genome.
+func (ga *GA) store(key []byte, phenotype *Phenotype) {
+	if ga.Cache != nil {
+		// The individual keeps the evaluated phenotype, so the cache holds a copy of its own.
+		ga.Cache.PutValue(key, phenotype.Fitness, phenotype.Clone())
+	}
+	if ga.Surrogate != nil {
+		ga.Surrogate.Add(key, phenotype.Fitness)
+	}
+}
+
+// checkSurrogateEncoding checks that every individual of the population has a binary genotype
+// if Surrogate is set.
+//
+// Returns:
+// - An error wrapping ErrSurrogateEncoding naming the first offending individual, or nil.
+func (ga *GA) checkSurrogateEncoding() error {
+	if ga.Surrogate == nil {
+		return nil
+	}
+	for i, ind := range ga.Population {
+		if ind.Genotype == nil || ind.Genotype.GenomeType != BinaryEncoding {
+			return fmt.Errorf("%w: individual %d", ErrSurrogateEncoding, i)
 		}
 	}
+	return nil
+}
+
+// useSurrogate reports whether the current generation may be evaluated by the Surrogate.
+func (ga *GA) useSurrogate() bool {
+	if ga.Surrogate == nil || ga.Surrogate.Size() <= ga.SurrogateThreshold {
+		return false
+	}
+	return ga.SurrogateInterval <= 0 || ga.generation%ga.SurrogateInterval != 0
+}
+
+// UniformCrossover returns a crossover operator that performs PositionalUniformCrossover with
+// the GA's CrossoverRateMap. If CrossoverRateMap is nil, the operator behaves like
+// UniformCrossover.
+//
+// Returns:
+// - A crossover operator suitable for the Crossover field.
+func (ga *GA) UniformCrossover() func([]*Individual, float64) []*Individual {
+	return func(population []*Individual, crossoverRate float64) []*Individual {
+		if ga.CrossoverRateMap == nil {
+			return UniformCrossover(population, crossoverRate)
+		}
+		return PositionalUniformCrossover(population, ga.CrossoverRateMap)
+	}
+}
+
+// SinglePointCrossover returns a crossover operator that performs SinglePointCrossover and,
+// if TrackCrossoverPoints is set, records the crossover points in CrossoverPointHistory.
+//
+// Returns:
+// - A crossover operator suitable for the Crossover field.
+func (ga *GA) SinglePointCrossover() func([]*Individual, float64) []*Individual {
+	return func(population []*Individual, crossoverRate float64) []*Individual {
+		return singlePointCrossover(population, crossoverRate, ga.recordCrossoverPoints())
+	}
+}
+
+// MultiPointCrossover returns a crossover operator that performs MultiPointCrossover and,
+// if TrackCrossoverPoints is set, records the cut points in CrossoverPointHistory. If
+// numPoints is negative or not less than the genome length of a pair of parents, the
+// population is passed through unchanged.
+//
+// Parameters:
+// - numPoints: the number of cut points.
+//
+// Returns:
+// - A crossover operator suitable for the Crossover field.
+func (ga *GA) MultiPointCrossover(numPoints int) func([]*Individual, float64) []*Individual {
+	return func(population []*Individual, crossoverRate float64) []*Individual {
+		offspring, err := multiPointCrossover(population, crossoverRate, numPoints, ga.recordCrossoverPoints())
+		if err != nil {
+			return population
+		}
+		return offspring
+	}
+}
+
+// recordCrossoverPoints returns a function appending crossover points to those of the
+// current generation, or nil if TrackCrossoverPoints is not set.
+func (ga *GA) recordCrossoverPoints() func(points ...int) {
+	if !ga.TrackCrossoverPoints {
+		return nil
+	}
+	return func(points ...int) {
+		ga.crossoverPoints = append(ga.crossoverPoints, points...)
+	}
+}
+
+// ConstrainedTournament returns a selection operator that performs ConstrainedTournamentSelection
+// using the GA's FeasibilityCheck. If FeasibilityCheck is nil, every individual is considered
+// feasible and the operator behaves like TournamentSelection.
+//
+// Parameters:
+// - tournamentSize: the number of individuals to be chosen randomly for each tournament.
+//
+// Returns:
+// - A selection operator suitable for the Selection field.
+func (ga *GA) ConstrainedTournament(tournamentSize int) func([]*Individual) []*Individual {
+	return func(population []*Individual) []*Individual {
+		feasible := ga.FeasibilityCheck
+		if feasible == nil {
+			feasible = func(*Individual) bool { return true }
+		}
+		return ConstrainedTournamentSelection(population, tournamentSize, feasible)
+	}
 }
 
 func (ga *GA) initializeLogger(enabled bool) {
 	ga.Logger = logger.NewLogger(enabled)
 }
 
+// activeLogger returns the request-scoped logger while EvolveWithContext runs, and Logger
+// otherwise.
+func (ga *GA) activeLogger() *logger.Logger {
+	if ga.contextLogger != nil {
+		return ga.contextLogger
+	}
+	return ga.Logger
+}
+
 // log logs a message with a key-value pair if the logger is set.
 //
 // Parameters:
@@ -70,7 +1274,15 @@ func (ga *GA) initializeLogger(enabled bool) {
 // - key: the key for the value being logged.
 // - value: the value to log.
 func (ga *GA) log(msg string, key string, value interface{}) {
-	if ga.Logger != nil {
-		ga.Logger.Log(msg, key, value)
-	}
+	ga.activeLogger().Log(msg, key, value)
+}
+
+// warn logs a warning with a key-value pair if the logger is set.
+//
+// Parameters:
+// - msg: the message to log.
+// - key: the key for the value being logged.
+// - value: the value to log.
+func (ga *GA) warn(msg string, key string, value interface{}) {
+	ga.activeLogger().Warn(msg, key, value)
 }
@@ -0,0 +1,139 @@
+package ga
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+)
+
+// sleepEvaluation simulates an expensive fitness function that does not use the CPU.
+func sleepEvaluation(genotype *Genotype) *Phenotype {
+	time.Sleep(time.Millisecond)
+	return oneMax(genotype)
+}
+
+func BenchmarkParallelScaling(b *testing.B) {
+	const populationSize = 64
+	population := make([]*Individual, populationSize)
+	for i := range population {
+		population[i] = &Individual{Genotype: MustVariableLengthBinaryGenotype(32, 32)}
+	}
+
+	throughputs := make(map[int]float64)
+	for workers := 1; workers <= max(2*runtime.NumCPU(), 4); workers *= 2 {
+		b.Run(fmt.Sprintf("Workers-%d", workers), func(b *testing.B) {
+			ga := &GA{Population: population, NumParallelEvals: workers}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ga.evaluatePopulation(sleepEvaluation)
+			}
+			throughput := float64(b.N*populationSize) / b.Elapsed().Seconds()
+			b.ReportMetric(throughput, "individuals/s")
+			throughputs[workers] = throughput
+		})
+	}
+
+	// The sleeps of a sleep-based evaluation overlap regardless of the number of CPUs.
+	if speedup := throughputs[4] / throughputs[1]; speedup < 2.5 {
+		b.Errorf("Expected 4 workers to achieve at least 2.5x the throughput of 1 worker, but got %.2fx", speedup)
+	}
+}
+
+func BenchmarkGeneticOperators(b *testing.B) {
+	const populationSize, genomeLength = 64, 1024
+	crossovers := []struct {
+		name      string
+		crossover func([]*Individual, float64) []*Individual
+	}{
+		{name: "SinglePointCrossover", crossover: SinglePointCrossover},
+		{name: "UniformCrossover", crossover: UniformCrossover},
+		{name: "MultiPointCrossover", crossover: func(population []*Individual, rate float64) []*Individual {
+			offspring, _ := MultiPointCrossover(population, rate, 4)
+			return offspring
+		}},
+	}
+
+	for _, tc := range crossovers {
+		for _, recycle := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/Recycle-%t", tc.name, recycle), func(b *testing.B) {
+				ga := &GA{}
+				population := make([]*Individual, populationSize)
+				for i := range population {
+					population[i] = &Individual{Genotype: MustVariableLengthBinaryGenotype(genomeLength, genomeLength)}
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					offspring := tc.crossover(population, 1.0)
+					if recycle {
+						for _, ind := range offspring {
+							ga.recycleGenome(ind.Genotype.Genome)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkMultiObjectiveSelection(b *testing.B) {
+	for _, populationSize := range []int{50, 200, 500} {
+		b.Run(fmt.Sprintf("Population-%d", populationSize), func(b *testing.B) {
+			population := make([]*Individual, populationSize)
+			for i := range population {
+				population[i] = &Individual{Phenotype: &Phenotype{Objectives: []float64{rand.Float64(), rand.Float64()}}}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				MultiObjectiveSelection(population)
+			}
+		})
+	}
+}
+
+func BenchmarkSelectionThroughput(b *testing.B) {
+	operators := selectionOperators()
+	names := make([]string, 0, len(operators))
+	for name := range operators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, populationSize := range []int{100, 1000, 10000} {
+			b.Run(fmt.Sprintf("%s/Population-%d", name, populationSize), func(b *testing.B) {
+				population := newSelectionPopulation(populationSize)
+				b.SetBytes(int64(len(population)))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					operators[name](population)
+				}
+				b.ReportMetric(float64(b.N*populationSize)/b.Elapsed().Seconds(), "individuals/s")
+			})
+		}
+	}
+}
+
+func BenchmarkNonDominatedSort(b *testing.B) {
+	sorts := []struct {
+		name string
+		sort func([]*Individual) [][]int
+	}{
+		{name: "Pairwise", sort: nonDominatedSort},
+		{name: "ENS-SS", sort: fastNonDominatedSort},
+	}
+
+	for _, size := range []int{100, 500, 2000} {
+		population := randomObjectivePopulation(rand.New(rand.NewSource(1)), size, 2, false)
+		for _, s := range sorts {
+			b.Run(fmt.Sprintf("%s-%d", s.name, size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					s.sort(population)
+				}
+			})
+		}
+	}
+}
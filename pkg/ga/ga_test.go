@@ -0,0 +1,973 @@
+package ga
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime/pprof"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Okabe-Junya/gago/internal/logger"
+	"github.com/Okabe-Junya/gago/pkg/ga/adaptive"
+	"github.com/Okabe-Junya/gago/pkg/ga/cache"
+	"github.com/Okabe-Junya/gago/pkg/ga/qd"
+	"github.com/Okabe-Junya/gago/pkg/ga/surrogate"
+	"github.com/Okabe-Junya/gago/pkg/ga/tabu"
+)
+
+// oneMax returns the number of ones in the genome as the fitness.
+func oneMax(genotype *Genotype) *Phenotype {
+	fitness := 0.0
+	for _, gene := range genotype.Genome {
+		fitness += float64(gene)
+	}
+	return &Phenotype{Fitness: fitness}
+}
+
+func TestEvolveWithCache(t *testing.T) {
+	ga := &GA{
+		Selection:     func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:     SinglePointCrossover,
+		Mutation:      BitFlipMutation,
+		CrossoverRate: 0.0,
+		MutationRate:  0.0,
+		Generations:   1,
+		Cache:         cache.NewFitnessCache(),
+	}
+
+	// Every individual shares one of two genomes, so most evaluations are duplicates.
+	count := 0
+	ga.Initialize(20, func() *Genotype {
+		count++
+		return &Genotype{Genome: []byte{byte(count % 2), 1, 0, 1}}
+	}, oneMax)
+	ga.Evolve(oneMax)
+
+	if ga.Cache.Hits() <= 0 {
+		t.Fatalf("Expected cache hits to be greater than 0, but got %d", ga.Cache.Hits())
+	}
+	for _, ind := range ga.Population {
+		expected := oneMax(ind.Genotype).Fitness
+		if ind.Phenotype.Fitness != expected {
+			t.Errorf("Expected cached fitness %f, but got %f", expected, ind.Phenotype.Fitness)
+		}
+	}
+}
+
+func TestEvolveWithCacheKeepsPhenotype(t *testing.T) {
+	// withFeatures evaluates oneMax and records the number of zeros as a feature and as a
+	// second objective.
+	withFeatures := func(genotype *Genotype) *Phenotype {
+		phenotype := oneMax(genotype)
+		zeros := float64(len(genotype.Genome)) - phenotype.Fitness
+		phenotype.Objectives = []float64{phenotype.Fitness, zeros}
+		phenotype.SetFloat("zeros", zeros)
+		return phenotype
+	}
+	ga := &GA{Cache: cache.NewFitnessCache()}
+	genotype := &Genotype{Genome: []byte{1, 0, 1, 0, 0}}
+
+	evaluated := ga.evaluate(genotype, withFeatures)
+	cached := ga.evaluate(genotype, withFeatures)
+
+	if ga.Cache.Hits() != 1 {
+		t.Fatalf("Expected %d cache hit, but got %d", 1, ga.Cache.Hits())
+	}
+	if zeros, ok := cached.GetFloat("zeros"); !ok || zeros != 3 {
+		t.Errorf("Expected the cached phenotype to keep feature zeros %d, but got %v", 3, cached.Features)
+	}
+	if !reflect.DeepEqual(cached.Objectives, evaluated.Objectives) {
+		t.Errorf("Expected the cached phenotype to keep objectives %v, but got %v", evaluated.Objectives, cached.Objectives)
+	}
+
+	// Changing a phenotype, as penalties do, must not change the cache or other hits.
+	evaluated.Fitness = -1
+	cached.SetFloat("zeros", -1)
+	if again := ga.evaluate(genotype, withFeatures); again.Fitness != 2 || again.Features["zeros"] != 3.0 {
+		t.Errorf("Expected the cache to be unaffected by changes to its phenotypes, but got fitness %f and features %v", again.Fitness, again.Features)
+	}
+}
+
+func TestConstrainedTournament(t *testing.T) {
+	ga := &GA{
+		// Genomes with a leading one violate the constraint.
+		FeasibilityCheck: func(ind *Individual) bool { return ind.Genotype.Genome[0] == 0 },
+		Crossover:        SinglePointCrossover,
+		Mutation:         BitFlipMutation,
+		Generations:      5,
+	}
+	ga.Selection = ga.ConstrainedTournament(64)
+
+	// Half of the initial population is feasible.
+	count := 0
+	ga.Initialize(8, func() *Genotype {
+		count++
+		return &Genotype{Genome: []byte{byte(count % 2), 1, 1, 1}}
+	}, oneMax)
+	ga.Evolve(oneMax)
+
+	for _, ind := range ga.Population {
+		if !ga.FeasibilityCheck(ind) {
+			t.Errorf("Expected only feasible individuals to survive, but got %v", ind.Genotype.Genome)
+		}
+	}
+}
+
+func TestEvolveWithCacheFloat64Genotype(t *testing.T) {
+	sum := func(genotype *Genotype) *Phenotype {
+		fitness := 0.0
+		for _, value := range genotype.Float64Genome {
+			fitness += value
+		}
+		return &Phenotype{Fitness: fitness}
+	}
+
+	ga := &GA{
+		Selection: func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover: func(population []*Individual, rate float64) []*Individual {
+			return BlendCrossover(population, rate, 0.5)
+		},
+		Mutation:      GaussianMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.1,
+		Generations:   3,
+		Cache:         cache.NewFitnessCache(),
+	}
+	ga.Initialize(10, func() *Genotype { return MustNewFloat64Genotype(4, []float64{0, 0, 0, 0}, []float64{1, 1, 1, 1}) }, sum)
+	ga.Evolve(sum)
+
+	for _, ind := range ga.Population {
+		if expected := sum(ind.Genotype).Fitness; ind.Phenotype.Fitness != expected {
+			t.Errorf("Expected cached fitness %f, but got %f", expected, ind.Phenotype.Fitness)
+		}
+	}
+}
+
+func TestEvolveWithAdaptiveCrossover(t *testing.T) {
+	// setOnes returns a crossover operator that sets n random genes of each offspring to one,
+	// counting how often it is applied.
+	setOnes := func(n int, applied *int) func([]*Individual, float64) []*Individual {
+		return func(population []*Individual, _ float64) []*Individual {
+			*applied++
+			offspring := make([]*Individual, len(population))
+			for i, ind := range population {
+				genotype := ind.Genotype.Clone()
+				for j := 0; j < n; j++ {
+					genotype.Genome[rand.Intn(len(genotype.Genome))] = 1
+				}
+				offspring[i] = &Individual{Genotype: genotype}
+			}
+			return offspring
+		}
+	}
+
+	var good, poor int
+	ga := &GA{
+		Selection:       func(population []*Individual) []*Individual { return population },
+		Mutation:        func([]*Individual, float64) {},
+		Generations:     20,
+		CrossoverPool:   []func([]*Individual, float64) []*Individual{setOnes(1, &poor), setOnes(10, &good)},
+		SelectionPolicy: adaptive.NewEpsilonGreedy(0.1),
+	}
+	ga.Initialize(20, func() *Genotype { return MustNewGenotype(10000) }, oneMax)
+	ga.Evolve(oneMax)
+
+	if good+poor != ga.Generations {
+		t.Fatalf("Expected one crossover per generation, but got %d", good+poor)
+	}
+	if good <= ga.Generations/2 {
+		t.Errorf("Expected the better operator to dominate selection, but it was applied %d of %d times", good, ga.Generations)
+	}
+}
+
+func TestImmigration(t *testing.T) {
+	ga := &GA{
+		Selection:           func(population []*Individual) []*Individual { return population },
+		Crossover:           SinglePointCrossover,
+		Mutation:            BitFlipMutation,
+		ImmigrationRate:     0.5,
+		ImmigrationInterval: 1,
+	}
+
+	// Start from a fully converged population except for a single best individual.
+	ga.Initialize(20, func() *Genotype { return MustNewGenotype(16) }, oneMax)
+	best := ga.Population[3]
+	best.Genotype.Genome[0] = 1
+	best.Phenotype = oneMax(best.Genotype)
+	ga.initializeGenotype = func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }
+
+	before := NewPopulation(ga.Population).Statistics.Diversity
+	ga.immigrate(oneMax)
+	after := NewPopulation(ga.Population).Statistics.Diversity
+
+	if after <= before {
+		t.Errorf("Expected diversity to increase after immigration, but got %f before and %f after", before, after)
+	}
+	if ga.Population[3] != best {
+		t.Errorf("Expected the best individual to survive immigration")
+	}
+
+	replaced := 0
+	for _, ind := range ga.Population {
+		if ind.Genotype.MaxLength == 16 {
+			replaced++
+		}
+	}
+	if replaced != 10 {
+		t.Errorf("Expected %d immigrants, but got %d", 10, replaced)
+	}
+}
+
+func TestImmigrationNeverReplacesBest(t *testing.T) {
+	ga := &GA{
+		Selection:           func(population []*Individual) []*Individual { return population },
+		Crossover:           SinglePointCrossover,
+		Mutation:            BitFlipMutation,
+		ImmigrationRate:     1.0,
+		ImmigrationInterval: 1,
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	best := findBestIndividual(ga.Population)
+
+	ga.immigrate(oneMax)
+
+	found := false
+	for _, ind := range ga.Population {
+		found = found || ind == best
+	}
+	if !found {
+		t.Errorf("Expected the best individual to survive immigration")
+	}
+}
+
+func TestCatastrophicRestart(t *testing.T) {
+	ga := &GA{
+		Selection:       func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:       SinglePointCrossover,
+		Mutation:        BitFlipMutation,
+		CrossoverRate:   0.7,
+		Generations:     1,
+		MinDiversity:    0.01,
+		RestartFraction: 0.9,
+	}
+
+	// Every individual starts with the same genome, so the population is fully converged.
+	converged := true
+	ga.Initialize(20, func() *Genotype {
+		if converged {
+			return MustNewGenotype(16)
+		}
+		return MustVariableLengthBinaryGenotype(16, 16)
+	}, oneMax)
+	converged = false
+
+	ga.Evolve(oneMax)
+
+	if ga.RestartCount != 1 {
+		t.Fatalf("Expected %d restart, but got %d", 1, ga.RestartCount)
+	}
+	if diversity := NewPopulation(ga.Population).Statistics.Diversity; diversity <= ga.MinDiversity {
+		t.Errorf("Expected diversity greater than %f after restart, but got %f", ga.MinDiversity, diversity)
+	}
+}
+
+func TestEvolveWithDeduplication(t *testing.T) {
+	ga := &GA{
+		// Selecting the best individual only makes the whole population duplicates of it.
+		Selection: func(population []*Individual) []*Individual {
+			selected := make([]*Individual, len(population))
+			for i := range selected {
+				selected[i] = findBestIndividual(population)
+			}
+			return selected
+		},
+		Crossover:           func(population []*Individual, _ float64) []*Individual { return population },
+		Mutation:            func([]*Individual, float64) {},
+		Generations:         3,
+		DeduplicateInterval: 1,
+	}
+	ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	ga.Evolve(oneMax)
+
+	for i, a := range ga.Population {
+		for _, b := range ga.Population[i+1:] {
+			if a.GenotypeEqual(b) {
+				t.Fatalf("Expected distinct genotypes after deduplication, but %v is duplicated", a.Genotype.Genome)
+			}
+		}
+	}
+}
+
+func TestEvolveWithTabuList(t *testing.T) {
+	ga := &GA{
+		Selection: func(population []*Individual) []*Individual { return population },
+		Crossover: func(population []*Individual, _ float64) []*Individual { return population },
+		// Only the escape from the tabu list mutates, as it mutates one individual at a time.
+		Mutation: func(population []*Individual, rate float64) {
+			if len(population) == 1 {
+				BitFlipMutation(population, rate)
+			}
+		},
+		MutationRate:       0.1,
+		Generations:        1,
+		TabuList:           tabu.NewTabuList(100),
+		TabuEscapeAttempts: 10,
+	}
+	ga.Initialize(20, func() *Genotype { return MustNewGenotype(32) }, oneMax)
+	ga.Evolve(oneMax)
+
+	for i, a := range ga.Population {
+		for _, b := range ga.Population[i+1:] {
+			if a.GenotypeEqual(b) {
+				t.Fatalf("Expected every duplicate to be mutated out of the tabu list, but %v is duplicated", a.Genotype.Genome)
+			}
+		}
+	}
+	if ga.TabuList.Len() != len(ga.Population) {
+		t.Errorf("Expected %d genomes in the tabu list, but got %d", len(ga.Population), ga.TabuList.Len())
+	}
+}
+
+func TestElitismPreservesQuality(t *testing.T) {
+	cases := []struct {
+		name         string
+		elitismCount int
+		minimize     bool
+	}{
+		{name: "one elite", elitismCount: 1},
+		{name: "several elites", elitismCount: 3},
+		{name: "one elite minimizing", elitismCount: 1, minimize: true},
+	}
+
+	for _, tc := range cases {
+		var bests []float64
+		ga := &GA{
+			Selection:     func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+			Crossover:     UniformCrossover,
+			Mutation:      BitFlipMutation,
+			CrossoverRate: 0.9,
+			// A mutation rate this high destroys most of the progress of each generation.
+			MutationRate: 0.5,
+			Generations:  50,
+			Minimize:     tc.minimize,
+			ElitismCount: tc.elitismCount,
+		}
+		ga.OnGeneration = func(_ int, population []*Individual) {
+			bests = append(bests, bestIndividual(population, ga.Minimize).Phenotype.Fitness)
+		}
+		ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(32, 32) }, oneMax)
+		initial := bestIndividual(ga.Population, ga.Minimize).Phenotype.Fitness
+		ga.Evolve(oneMax)
+
+		previous := initial
+		for gen, best := range bests {
+			if fitter(previous, best, tc.minimize) {
+				t.Fatalf("%s: Expected the best fitness never to worsen, but it went from %f to %f in generation %d", tc.name, previous, best, gen)
+			}
+			previous = best
+		}
+	}
+}
+
+func TestInitializeWithSeedIndividuals(t *testing.T) {
+	const genomeLength = 16
+
+	perfect := MustNewGenotype(genomeLength)
+	for i := range perfect.Genome {
+		perfect.Genome[i] = 1
+	}
+	seed := &Individual{Genotype: perfect, Phenotype: oneMax(perfect)}
+
+	cases := []struct {
+		seeds          []*Individual
+		populationSize int
+	}{
+		{seeds: []*Individual{seed}, populationSize: 10},
+		{seeds: []*Individual{seed, seed, seed}, populationSize: 2},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{SeedIndividuals: tc.seeds}
+
+		if err := ga.Initialize(tc.populationSize, func() *Genotype { return MustNewGenotype(genomeLength) }, oneMax); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(ga.Population) != tc.populationSize {
+			t.Fatalf("Expected population size %d, but got %d", tc.populationSize, len(ga.Population))
+		}
+		if ga.Population[0] != seed {
+			t.Errorf("Expected the seed individual at the front of the population")
+		}
+		if best := findBestIndividual(ga.Population).Phenotype.Fitness; best != genomeLength {
+			t.Errorf("Expected initial best fitness %d, but got %f", genomeLength, best)
+		}
+	}
+}
+
+func TestInitializeWithInvalidSeedIndividuals(t *testing.T) {
+	cases := []struct {
+		seed *Individual
+	}{
+		{seed: &Individual{Phenotype: &Phenotype{Fitness: 1.0}}},
+		{seed: &Individual{Genotype: MustNewGenotype(4)}},
+		{seed: nil},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{SeedIndividuals: []*Individual{tc.seed}}
+
+		err := ga.Initialize(4, func() *Genotype { return MustNewGenotype(4) }, oneMax)
+		if !errors.Is(err, ErrInvalidSeed) {
+			t.Errorf("Expected ErrInvalidSeed for seed %+v, but got %v", tc.seed, err)
+		}
+	}
+}
+
+func TestEvolveWithMAPElites(t *testing.T) {
+	const gridSize = 10
+	minValues, maxValues := []float64{0, 0}, []float64{1, 1}
+	// sphere rewards genes close to the center of the unit square.
+	sphere := func(genotype *Genotype) *Phenotype {
+		x, y := genotype.GetRealValue(0)-0.5, genotype.GetRealValue(1)-0.5
+		return &Phenotype{Fitness: -(x*x + y*y)}
+	}
+	cell := func(ind *Individual) [2]int {
+		x := min(int(ind.Genotype.GetRealValue(0)*gridSize), gridSize-1)
+		y := min(int(ind.Genotype.GetRealValue(1)*gridSize), gridSize-1)
+		return [2]int{x, y}
+	}
+
+	ga := &GA{
+		Selection: func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover: func(population []*Individual, rate float64) []*Individual {
+			return BlendCrossover(population, rate, 0.5)
+		},
+		Mutation:         GaussianMutation,
+		CrossoverRate:    0.7,
+		MutationRate:     1.0,
+		Generations:      200,
+		MAPElitesArchive: qd.NewMAPElites(cell),
+	}
+	ga.Initialize(50, func() *Genotype { return MustNewFloat64Genotype(2, minValues, maxValues) }, sphere)
+	initialCoverage := ga.MAPElitesArchive.CoverageRatio(gridSize * gridSize)
+	ga.Evolve(sphere)
+
+	coverage := ga.MAPElitesArchive.CoverageRatio(gridSize * gridSize)
+	if coverage <= initialCoverage || coverage < 0.9 {
+		t.Errorf("Expected coverage to grow to at least %f, but got %f (initially %f)", 0.9, coverage, initialCoverage)
+	}
+	for c, elite := range ga.MAPElitesArchive.Grid {
+		if cell(elite) != c {
+			t.Errorf("Expected elite in cell %v to have behavior %v", c, cell(elite))
+		}
+		if elite.Fitness() != sphere(elite.Genotype).Fitness {
+			t.Errorf("Expected elite fitness %f, but got %f", sphere(elite.Genotype).Fitness, elite.Fitness())
+		}
+	}
+}
+
+func TestEvolveWithMAPElitesMinimize(t *testing.T) {
+	cell := func(ind *Individual) [2]int {
+		return [2]int{int(ind.Genotype.Genome[0]), int(ind.Genotype.Genome[1])}
+	}
+
+	ga := &GA{
+		Selection:        func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:        SinglePointCrossover,
+		Mutation:         BitFlipMutation,
+		CrossoverRate:    0.7,
+		MutationRate:     0.1,
+		Generations:      20,
+		Minimize:         true,
+		MAPElitesArchive: qd.NewMAPElites(cell),
+	}
+	ga.OnGeneration = func(generation int, population []*Individual) {
+		for _, ind := range population {
+			if elite := ga.MAPElitesArchive.BestInCell(cell(ind)); elite.Fitness() > ind.Fitness() {
+				t.Fatalf("Expected elite fitness of at most %f, but got %f", ind.Fitness(), elite.Fitness())
+			}
+		}
+	}
+	if err := ga.Initialize(30, func() *Genotype { return MustNewGenotype(8) }, oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if _, _, err := ga.Evolve(oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+}
+
+func TestOperatorTimings(t *testing.T) {
+	var output bytes.Buffer
+	ga := &GA{
+		Selection:     func(population []*Individual) []*Individual { return TournamentSelection(population, 3) },
+		Crossover:     SinglePointCrossover,
+		Mutation:      BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.01,
+		Generations:   20,
+	}
+	ga.Initialize(50, func() *Genotype { return MustVariableLengthBinaryGenotype(1000, 1000) }, oneMax)
+	ga.Logger = logger.NewLoggerWithWriter(&output)
+
+	start := time.Now()
+	ga.Evolve(oneMax)
+	total := time.Since(start)
+
+	phases := []string{PhaseSelection, PhaseCrossover, PhaseMutation, PhaseEvaluation, PhaseStatistics}
+	var sum time.Duration
+	for _, phase := range phases {
+		timing, ok := ga.OperatorTimings[phase]
+		if !ok {
+			t.Errorf("Expected a timing for phase %q", phase)
+		}
+		if !strings.Contains(output.String(), phase+"=") {
+			t.Errorf("Expected the logged operator timings to include phase %q, but got %q", phase, output.String())
+		}
+		sum += timing
+	}
+	if sum > total || sum < total/2 {
+		t.Errorf("Expected the phase timings to sum to approximately %v, but got %v", total, sum)
+	}
+}
+
+func TestEvolveWithSurrogate(t *testing.T) {
+	cases := []struct {
+		interval            int
+		expectedEvaluations int64
+	}{
+		// The initial population and generations 0 and 5 are evaluated exactly.
+		{interval: 5, expectedEvaluations: 60},
+		// The surrogate takes over once it holds more than the threshold.
+		{interval: 0, expectedEvaluations: 21},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:          func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+			Crossover:          SinglePointCrossover,
+			Mutation:           BitFlipMutation,
+			CrossoverRate:      0.7,
+			MutationRate:       0.05,
+			Generations:        10,
+			Surrogate:          surrogate.NewKNNSurrogate(3),
+			SurrogateThreshold: 20,
+			SurrogateInterval:  tc.interval,
+		}
+		ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+		ga.Evolve(oneMax)
+
+		if ga.EvaluationsUsed != tc.expectedEvaluations {
+			t.Errorf("Expected %d exact evaluations with interval %d, but got %d", tc.expectedEvaluations, tc.interval, ga.EvaluationsUsed)
+		}
+		if ga.Surrogate.Size() != int(tc.expectedEvaluations) {
+			t.Errorf("Expected the surrogate to hold %d training points, but got %d", tc.expectedEvaluations, ga.Surrogate.Size())
+		}
+	}
+}
+
+func TestSurrogateRequiresBinaryGenotypes(t *testing.T) {
+	minValues, maxValues := []float64{0, 0}, []float64{1, 1}
+	newGA := func() *GA {
+		return &GA{
+			Selection:   func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+			Crossover:   SinglePointCrossover,
+			Mutation:    BitFlipMutation,
+			Generations: 1,
+		}
+	}
+
+	ga := newGA()
+	ga.Surrogate = surrogate.NewKNNSurrogate(3)
+	err := ga.Initialize(10, func() *Genotype { return MustNewFloat64Genotype(2, minValues, maxValues) }, oneMax)
+	if !errors.Is(err, ErrSurrogateEncoding) {
+		t.Errorf("Expected ErrSurrogateEncoding from Initialize, but got %v", err)
+	}
+	if ga.Surrogate.Size() != 0 || ga.EvaluationsUsed != 0 {
+		t.Errorf("Expected no evaluations before the encoding is rejected, but got %d", ga.EvaluationsUsed)
+	}
+
+	// A surrogate set after initialization is rejected by Evolve.
+	ga = newGA()
+	ga.Initialize(10, func() *Genotype { return MustNewRealGenotype(2, minValues, maxValues) }, oneMax)
+	ga.Surrogate = surrogate.NewKNNSurrogate(3)
+	if _, _, err := ga.Evolve(oneMax); !errors.Is(err, ErrSurrogateEncoding) {
+		t.Errorf("Expected ErrSurrogateEncoding from Evolve, but got %v", err)
+	}
+}
+
+func TestEvolveWithEvaluationBudget(t *testing.T) {
+	cases := []struct {
+		budget              int
+		expectedGenerations int
+		expectedErr         error
+	}{
+		{budget: 50, expectedGenerations: 1, expectedErr: nil},
+		{budget: 20, expectedGenerations: 0, expectedErr: ErrBudgetExceeded},
+		{budget: 0, expectedGenerations: 10, expectedErr: nil},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:        func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+			Crossover:        SinglePointCrossover,
+			Mutation:         BitFlipMutation,
+			CrossoverRate:    0.7,
+			MutationRate:     0.05,
+			Generations:      10,
+			EvaluationBudget: tc.budget,
+		}
+		ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+		_, _, err := ga.Evolve(oneMax)
+
+		if !errors.Is(err, tc.expectedErr) {
+			t.Errorf("Expected error %v with budget %d, but got %v", tc.expectedErr, tc.budget, err)
+		}
+		if tc.budget > 0 && ga.EvaluationsUsed > int64(tc.budget) {
+			t.Errorf("Expected at most %d evaluations, but got %d", tc.budget, ga.EvaluationsUsed)
+		}
+		if generations := len(ga.History) - 1; generations != tc.expectedGenerations {
+			t.Errorf("Expected %d generations with budget %d, but got %d", tc.expectedGenerations, tc.budget, generations)
+		}
+	}
+}
+
+func TestHistoryCapacity(t *testing.T) {
+	cases := []struct {
+		capacity       int
+		generations    int
+		expectedLength int
+		expectedFirst  int
+	}{
+		{capacity: 5, generations: 100, expectedLength: 5, expectedFirst: 96},
+		{capacity: 5, generations: 3, expectedLength: 4, expectedFirst: 0},
+		{capacity: 0, generations: 10, expectedLength: 11, expectedFirst: 0},
+	}
+
+	for _, tc := range cases {
+		// Every individual's fitness is the number of the generation it was evaluated in, so
+		// the average fitness of each history entry identifies its generation.
+		gen := 0
+		ga := &GA{
+			Selection:       func(population []*Individual) []*Individual { return population },
+			Crossover:       SinglePointCrossover,
+			Mutation:        func([]*Individual, float64) { gen++ },
+			Generations:     tc.generations,
+			HistoryCapacity: tc.capacity,
+		}
+		evaluate := func(*Genotype) *Phenotype { return &Phenotype{Fitness: float64(gen)} }
+		ga.Initialize(4, func() *Genotype { return MustNewGenotype(4) }, evaluate)
+		ga.Evolve(evaluate)
+
+		history := ga.GetHistory()
+		if len(history) != tc.expectedLength {
+			t.Fatalf("Expected %d history entries, but got %d", tc.expectedLength, len(history))
+		}
+		for i, stats := range history {
+			if expected := float64(tc.expectedFirst + i); stats.AverageFitness != expected {
+				t.Errorf("Expected entry %d to have average fitness %f, but got %f", i, expected, stats.AverageFitness)
+			}
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	policy := adaptive.NewUCB1()
+	ga := &GA{
+		Selection:       func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		CrossoverPool:   []func([]*Individual, float64) []*Individual{SinglePointCrossover, UniformCrossover},
+		SelectionPolicy: policy,
+		Mutation:        BitFlipMutation,
+		CrossoverRate:   0.7,
+		MutationRate:    0.05,
+		Generations:     5,
+		MinDiversity:    100,
+		RestartFraction: 0.5,
+	}
+	ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	ga.Evolve(oneMax)
+
+	cases := []struct {
+		populationSize int
+	}{
+		{populationSize: 10},
+		{populationSize: 30},
+	}
+
+	for _, tc := range cases {
+		if err := ga.Reset(tc.populationSize, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(ga.History) != 1 {
+			t.Errorf("Expected 1 history entry after Reset, but got %d", len(ga.History))
+		}
+		if len(ga.Population) != tc.populationSize {
+			t.Errorf("Expected population size %d after Reset, but got %d", tc.populationSize, len(ga.Population))
+		}
+		if ga.EvaluationsUsed != int64(tc.populationSize) {
+			t.Errorf("Expected %d evaluations after Reset, but got %d", tc.populationSize, ga.EvaluationsUsed)
+		}
+		if ga.RestartCount != 0 {
+			t.Errorf("Expected RestartCount 0 after Reset, but got %d", ga.RestartCount)
+		}
+		if len(policy.Counts()) != 0 {
+			t.Errorf("Expected the selection policy to be reset, but got counts %v", policy.Counts())
+		}
+
+		if _, _, err := ga.Evolve(oneMax); err != nil {
+			t.Fatalf("Expected no error from Evolve, but got %v", err)
+		}
+		if len(ga.History) != ga.Generations+1 {
+			t.Errorf("Expected %d history entries after Evolve, but got %d", ga.Generations+1, len(ga.History))
+		}
+	}
+}
+
+func TestEvaluatePopulationParallel(t *testing.T) {
+	cases := []struct {
+		workers int
+	}{
+		{workers: 0},
+		{workers: 1},
+		{workers: 4},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 20)
+		for i := range population {
+			population[i] = &Individual{Genotype: MustVariableLengthBinaryGenotype(16, 16)}
+		}
+		// Selection may repeat individuals, which must be evaluated without a data race.
+		population = append(population, population[:5]...)
+		ga := &GA{Population: population, NumParallelEvals: tc.workers}
+
+		ga.evaluatePopulation(oneMax)
+
+		for i, ind := range ga.Population {
+			if ind.Phenotype == nil || ind.Phenotype.Fitness != oneMax(ind.Genotype).Fitness {
+				t.Fatalf("Expected individual %d to be evaluated with %d workers, but got %+v", i, tc.workers, ind.Phenotype)
+			}
+		}
+	}
+}
+
+func TestEvolveWithRecycledGenomes(t *testing.T) {
+	cases := []struct {
+		name      string
+		crossover func([]*Individual, float64) []*Individual
+	}{
+		{name: "SinglePointCrossover", crossover: SinglePointCrossover},
+		{name: "UniformCrossover", crossover: UniformCrossover},
+		{name: "TwoPointCrossover", crossover: TwoPointCrossover},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:      func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+			Crossover:      tc.crossover,
+			Mutation:       BitFlipMutation,
+			CrossoverRate:  0.7,
+			MutationRate:   0.05,
+			Generations:    30,
+			RecycleGenomes: true,
+		}
+		ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(32, 32) }, oneMax)
+		ga.Evolve(oneMax)
+
+		// A recycled genome still in use would be overwritten by a later offspring, so it would
+		// no longer match the fitness evaluated for it or would be shared between individuals.
+		owners := make(map[*byte]*Individual)
+		for i, ind := range ga.Population {
+			if fitness := oneMax(ind.Genotype).Fitness; ind.Phenotype.Fitness != fitness {
+				t.Errorf("%s: expected individual %d to have fitness %f, but got %f", tc.name, i, fitness, ind.Phenotype.Fitness)
+			}
+			if owner, ok := owners[&ind.Genotype.Genome[0]]; ok && owner != ind {
+				t.Errorf("%s: expected individual %d to own its genome, but it is shared", tc.name, i)
+			}
+			owners[&ind.Genotype.Genome[0]] = ind
+		}
+	}
+}
+
+func TestEvolveMinimize(t *testing.T) {
+	ga := &GA{
+		Selection:      func(population []*Individual) []*Individual { return TournamentSelection(population, 3) },
+		Crossover:      SinglePointCrossover,
+		Mutation:       BitFlipMutation,
+		CrossoverRate:  0.7,
+		MutationRate:   0.02,
+		Generations:    100,
+		Minimize:       true,
+		HallOfFameSize: 3,
+	}
+	ga.Initialize(50, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	ga.Evolve(oneMax)
+
+	stats := ga.History[len(ga.History)-1]
+	if stats.BestFitness != 0 {
+		t.Fatalf("Expected minimizing OneMax to reach best fitness 0, but got %f", stats.BestFitness)
+	}
+	if stats.WorstFitness < stats.BestFitness {
+		t.Errorf("Expected the worst fitness to be at least the best when minimizing, but got %f and %f", stats.WorstFitness, stats.BestFitness)
+	}
+
+	best := (&Population{Individuals: ga.Population, Minimize: true}).GetBestIndividual()
+	for _, gene := range best.Genotype.Genome {
+		if gene != 0 {
+			t.Fatalf("Expected the best individual to be the all-zeros genome, but got %v", best.Genotype.Genome)
+		}
+	}
+	for i := 1; i < len(ga.HallOfFame); i++ {
+		if ga.HallOfFame[i].Phenotype.Fitness < ga.HallOfFame[i-1].Phenotype.Fitness {
+			t.Errorf("Expected the hall of fame sorted by ascending fitness when minimizing, but got %f after %f", ga.HallOfFame[i].Phenotype.Fitness, ga.HallOfFame[i-1].Phenotype.Fitness)
+		}
+	}
+}
+
+func TestEvolveWithContext(t *testing.T) {
+	var output bytes.Buffer
+	ga := &GA{
+		Selection:     func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:     SinglePointCrossover,
+		Mutation:      BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.01,
+		Generations:   5,
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	ga.Logger = logger.NewLoggerWithWriter(&output)
+
+	ctx := context.WithValue(context.Background(), logger.RequestIDKey, "req-42")
+	if _, _, err := ga.EvolveWithContext(ctx, oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) < ga.Generations {
+		t.Fatalf("Expected at least %d log lines, but got %d", ga.Generations, len(lines))
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "request_id=req-42") {
+			t.Errorf("Expected every log line to contain the request ID, but got %q", line)
+		}
+	}
+
+	output.Reset()
+	ga.Evolve(oneMax)
+	if strings.Contains(output.String(), "request_id=") {
+		t.Errorf("Expected no request ID after EvolveWithContext returned, but got %q", output.String())
+	}
+}
+
+func TestEvolveWithCanceledContext(t *testing.T) {
+	generations := 0
+	ga := &GA{
+		Selection:   func(population []*Individual) []*Individual { return population },
+		Crossover:   func(population []*Individual, _ float64) []*Individual { return population },
+		Mutation:    func([]*Individual, float64) {},
+		Generations: 10,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ga.OnGeneration = func(gen int, _ []*Individual) {
+		generations++
+		if gen == 2 {
+			cancel()
+		}
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+
+	if _, _, err := ga.EvolveWithContext(ctx, oneMax); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected error %v, but got %v", context.Canceled, err)
+	}
+	if generations != 3 {
+		t.Errorf("Expected %d generations before cancellation, but got %d", 3, generations)
+	}
+}
+
+func TestProfilingLabels(t *testing.T) {
+	var profile bytes.Buffer
+	if err := pprof.StartCPUProfile(&profile); err != nil {
+		t.Skipf("Skipping because a CPU profile cannot be started: %v", err)
+	}
+
+	ga := &GA{
+		Selection:        func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:        SinglePointCrossover,
+		Mutation:         BitFlipMutation,
+		CrossoverRate:    0.7,
+		MutationRate:     0.01,
+		Generations:      1,
+		ProfilingEnabled: true,
+	}
+	ga.Initialize(100, func() *Genotype { return MustVariableLengthBinaryGenotype(1000, 1000) }, oneMax)
+	_, _, err := ga.Evolve(oneMax)
+	pprof.StopCPUProfile()
+
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if profile.Len() == 0 {
+		t.Errorf("Expected a non-empty CPU profile")
+	}
+	for _, phase := range []string{PhaseSelection, PhaseCrossover, PhaseMutation, PhaseEvaluation} {
+		if _, ok := ga.OperatorTimings[phase]; !ok {
+			t.Errorf("Expected phase %q to run under profiling", phase)
+		}
+	}
+}
+
+func TestEvolveEvaluationErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		workers int
+	}{
+		{name: "sequential", workers: 1},
+		{name: "parallel", workers: 4},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:        func(population []*Individual) []*Individual { return population },
+			Crossover:        func(population []*Individual, _ float64) []*Individual { return population },
+			Mutation:         func([]*Individual, float64) {},
+			Generations:      5,
+			NumParallelEvals: tc.workers,
+		}
+		ga.Initialize(50, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+
+		// Every tenth evaluation panics, so that 10% of the offspring fail.
+		var calls int64
+		flaky := func(genotype *Genotype) *Phenotype {
+			if atomic.AddInt64(&calls, 1)%10 == 0 {
+				panic("evaluation failed")
+			}
+			return oneMax(genotype)
+		}
+
+		best, errs, err := ga.Evolve(flaky)
+		if err != nil {
+			t.Fatalf("%s: Expected no error, but got %v", tc.name, err)
+		}
+		if expected := 5 * 50 / 10; len(errs) != expected {
+			t.Errorf("%s: Expected %d evaluation errors, but got %d", tc.name, expected, len(errs))
+		}
+		for _, evaluationError := range errs {
+			if evaluationError.Cause != "evaluation failed" {
+				t.Errorf("%s: Expected cause %q, but got %v", tc.name, "evaluation failed", evaluationError.Cause)
+			}
+			if i := evaluationError.IndividualIndex; i < 0 || i >= len(ga.Population) {
+				t.Errorf("%s: Expected an individual index within the population, but got %d", tc.name, i)
+			}
+		}
+		if best == nil || best.Phenotype.Fitness == -math.MaxFloat64 {
+			t.Errorf("%s: Expected a best individual with a finite fitness, but got %+v", tc.name, best)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the export of a GA's configuration as reproducible Go code.
+package ga
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// stateFields names the exported fields of GA that hold the state of a run, such as the
+// population and its history, rather than its configuration, and are therefore not
+// exported by ExportGoCode.
+var stateFields = map[string]bool{
+	"Population":            true,
+	"History":               true,
+	"HallOfFame":            true,
+	"CrossoverPointHistory": true,
+	"OperatorTimings":       true,
+	"RestartCount":          true,
+	"MutationSuccessRate":   true,
+	"EvaluationsUsed":       true,
+}
+
+// operatorFields names the operator fields of GA that ExportGoCode resolves by name.
+var operatorFields = map[string]bool{
+	"Selection": true,
+	"Crossover": true,
+	"Mutation":  true,
+}
+
+// ExportGoCode returns a formatted Go program that recreates the configuration of the GA,
+// so that an experiment can be shared and rerun. Every non-zero boolean, numeric, and
+// string field is set in a GA literal, and Selection, Crossover, and Mutation are set
+// with LoadConfig by the names under which they are registered in SelectionOperators,
+// CrossoverOperators, and MutationOperators. Other non-nil configuration fields, such as
+// unregistered operators, callbacks, and caches, cannot be expressed as literals and are
+// listed in a comment instead. The program's main function is a stub that leaves the
+// initialization and evolution of the population to the reader.
+//
+// Returns:
+// - The Go source code of the program.
+func (ga *GA) ExportGoCode() string {
+	var fields, manual []string
+	operators := make(map[string]string)
+	v := reflect.ValueOf(ga).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if !field.IsExported() || field.Anonymous || stateFields[field.Name] || value.IsZero() {
+			continue
+		}
+		if operatorFields[field.Name] {
+			if name, ok := ga.operatorName(field.Name); ok {
+				operators[field.Name] = name
+				continue
+			}
+		}
+		if literal, ok := goLiteral(value); ok {
+			fields = append(fields, fmt.Sprintf("%s: %s,", field.Name, literal))
+		} else {
+			manual = append(manual, field.Name)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("package main\n\nimport \"github.com/Okabe-Junya/gago/pkg/ga\"\n\nfunc main() {\n")
+	fmt.Fprintf(&b, "g := &ga.GA{\n%s\n}\n", strings.Join(fields, "\n"))
+	if len(operators) > 0 {
+		var names []string
+		for _, field := range []string{"Selection", "Crossover", "Mutation"} {
+			if name, ok := operators[field]; ok {
+				names = append(names, fmt.Sprintf("%s: %q", field, name))
+			}
+		}
+		fmt.Fprintf(&b, "if err := g.LoadConfig(ga.OperatorConfig{%s}); err != nil {\npanic(err)\n}\n", strings.Join(names, ", "))
+	}
+	if len(manual) > 0 {
+		fmt.Fprintf(&b, "// Set by hand: %s.\n", strings.Join(manual, ", "))
+	}
+	b.WriteString("// Initialize the population with g.Initialize and evolve it with g.Evolve.\n_ = g\n}\n")
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return b.String()
+	}
+	return string(source)
+}
+
+// operatorName returns the registered name of the named operator field of the GA.
+//
+// Parameters:
+// - field: the name of the field, one of Selection, Crossover, and Mutation.
+//
+// Returns:
+// - The name under which the operator is registered.
+// - Whether the operator is registered.
+func (ga *GA) operatorName(field string) (string, bool) {
+	switch field {
+	case "Selection":
+		return SelectionOperators.Name(ga.Selection)
+	case "Crossover":
+		return CrossoverOperators.Name(ga.Crossover)
+	default:
+		return MutationOperators.Name(ga.Mutation)
+	}
+}
+
+// goLiteral returns the Go literal of a boolean, numeric, or string value.
+//
+// Parameters:
+// - value: the value to express as a literal.
+//
+// Returns:
+// - The literal.
+// - Whether the value can be expressed as a literal.
+func goLiteral(value reflect.Value) (string, bool) {
+	switch value.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), true
+	case reflect.Float32, reflect.Float64:
+		literal := strconv.FormatFloat(value.Float(), 'g', -1, 64)
+		return literal, !strings.ContainsAny(literal, "IN")
+	case reflect.String:
+		return strconv.Quote(value.String()), true
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package ga
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExportGoCode(t *testing.T) {
+	cases := []struct {
+		name      string
+		ga        *GA
+		operators []string
+		manual    []string
+	}{
+		{
+			name: "registered operators",
+			ga: &GA{
+				Selection:          RouletteWheelSelection,
+				Crossover:          SinglePointCrossover,
+				Mutation:           BitFlipMutation,
+				CrossoverRate:      0.8,
+				MutationRate:       1e-5,
+				Generations:        100,
+				Minimize:           true,
+				EvaluationBudget:   5000,
+				ConvergenceEpsilon: 0.25,
+				AdaptiveStrategy:   AdaptiveStrategySuccessRate,
+				EvaluationsUsed:    42,
+			},
+			operators: []string{`Selection: "roulette_wheel"`, `Crossover: "single_point"`, `Mutation: "bit_flip"`},
+		},
+		{
+			name: "unregistered operators",
+			ga: &GA{
+				Selection:    func(population []*Individual) []*Individual { return population },
+				MutationRate: 0.5,
+				Generations:  10,
+				OnGeneration: func(int, []*Individual) {},
+			},
+			manual: []string{"Selection", "OnGeneration"},
+		},
+	}
+
+	for _, tc := range cases {
+		code := tc.ga.ExportGoCode()
+		file, err := parser.ParseFile(token.NewFileSet(), "main.go", code, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("%s: Expected the exported code to parse, but got error: %v\n%s", tc.name, err, code)
+		}
+		if file.Name.Name != "main" {
+			t.Errorf("%s: Expected package main, but got %s", tc.name, file.Name.Name)
+		}
+
+		numeric := 0
+		ast.Inspect(file, func(node ast.Node) bool {
+			kv, ok := node.(*ast.KeyValueExpr)
+			if !ok {
+				return true
+			}
+			key := kv.Key.(*ast.Ident).Name
+			lit, ok := kv.Value.(*ast.BasicLit)
+			if !ok || (lit.Kind != token.INT && lit.Kind != token.FLOAT) {
+				return true
+			}
+			numeric++
+			got, err := strconv.ParseFloat(lit.Value, 64)
+			if err != nil {
+				t.Errorf("%s: Expected %s to be a number, but got %s", tc.name, key, lit.Value)
+				return true
+			}
+			field := reflect.ValueOf(tc.ga).Elem().FieldByName(key)
+			var expected float64
+			if field.CanFloat() {
+				expected = field.Float()
+			} else {
+				expected = float64(field.Int())
+			}
+			if got != expected {
+				t.Errorf("%s: Expected %s to be %v, but got %v", tc.name, key, expected, got)
+			}
+			return true
+		})
+		if numeric == 0 {
+			t.Errorf("%s: Expected numeric fields in the exported code, but got none:\n%s", tc.name, code)
+		}
+		if strings.Contains(code, "EvaluationsUsed") {
+			t.Errorf("%s: Expected run state not to be exported, but got:\n%s", tc.name, code)
+		}
+		for _, operator := range tc.operators {
+			if !strings.Contains(code, operator) {
+				t.Errorf("%s: Expected the exported code to contain %s, but got:\n%s", tc.name, operator, code)
+			}
+		}
+		for _, field := range tc.manual {
+			if !strings.Contains(code, field+",") && !strings.Contains(code, field+".") {
+				t.Errorf("%s: Expected %s to be listed as set by hand, but got:\n%s", tc.name, field, code)
+			}
+		}
+	}
+}
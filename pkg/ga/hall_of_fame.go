@@ -0,0 +1,44 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the hall of fame archive of the best individuals seen during evolution.
+package ga
+
+import (
+	"bytes"
+	"sort"
+)
+
+// GetHallOfFame returns the best individuals seen during evolution, sorted from best to worst.
+//
+// Returns:
+// - A copy of the hall of fame.
+func (ga *GA) GetHallOfFame() []*Individual {
+	return append([]*Individual(nil), ga.HallOfFame...)
+}
+
+// updateHallOfFame inserts a copy of the current best individual into the hall of fame if
+// the hall is not full or the individual is better than its worst member. An individual whose
+// genotype is already in the hall is not inserted again.
+func (ga *GA) updateHallOfFame() {
+	if ga.HallOfFameSize <= 0 || len(ga.Population) == 0 {
+		return
+	}
+
+	best := bestIndividual(ga.Population, ga.Minimize)
+	full := len(ga.HallOfFame) >= ga.HallOfFameSize
+	if full && !fitter(best.Phenotype.Fitness, ga.HallOfFame[len(ga.HallOfFame)-1].Phenotype.Fitness, ga.Minimize) {
+		return
+	}
+	for _, member := range ga.HallOfFame {
+		if bytes.Equal(member.Genotype.key(), best.Genotype.key()) {
+			return
+		}
+	}
+
+	ga.HallOfFame = append(ga.HallOfFame, best.Clone())
+	sort.SliceStable(ga.HallOfFame, func(i, j int) bool {
+		return fitter(ga.HallOfFame[i].Phenotype.Fitness, ga.HallOfFame[j].Phenotype.Fitness, ga.Minimize)
+	})
+	if len(ga.HallOfFame) > ga.HallOfFameSize {
+		ga.HallOfFame = ga.HallOfFame[:ga.HallOfFameSize]
+	}
+}
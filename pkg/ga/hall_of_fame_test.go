@@ -0,0 +1,49 @@
+package ga
+
+import "testing"
+
+func TestHallOfFame(t *testing.T) {
+	cases := []struct {
+		hallOfFameSize int
+	}{
+		{hallOfFameSize: 1},
+		{hallOfFameSize: 5},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:      func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+			Crossover:      UniformCrossover,
+			Mutation:       BitFlipMutation,
+			CrossoverRate:  0.9,
+			MutationRate:   0.2,
+			Generations:    30,
+			HallOfFameSize: tc.hallOfFameSize,
+		}
+		ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+		ga.Evolve(oneMax)
+
+		hall := ga.GetHallOfFame()
+		if len(hall) == 0 || len(hall) > tc.hallOfFameSize {
+			t.Fatalf("Expected hall of fame size in [1, %d], but got %d", tc.hallOfFameSize, len(hall))
+		}
+		if len(ga.History) != ga.Generations+1 {
+			t.Fatalf("Expected %d history entries, but got %d", ga.Generations+1, len(ga.History))
+		}
+		for i, stats := range ga.History {
+			if hall[0].Phenotype.Fitness < stats.BestFitness {
+				t.Errorf("Expected hall of fame top %f to be at least generation %d best %f", hall[0].Phenotype.Fitness, i, stats.BestFitness)
+			}
+		}
+		for i := 1; i < len(hall); i++ {
+			if hall[i].Phenotype.Fitness > hall[i-1].Phenotype.Fitness {
+				t.Errorf("Expected hall of fame sorted by descending fitness, but got %f before %f", hall[i-1].Phenotype.Fitness, hall[i].Phenotype.Fitness)
+			}
+		}
+		for _, member := range hall {
+			if expected := oneMax(member.Genotype).Fitness; member.Phenotype.Fitness != expected {
+				t.Errorf("Expected hall of fame member to keep fitness %f of its genome, but got %f", expected, member.Phenotype.Fitness)
+			}
+		}
+	}
+}
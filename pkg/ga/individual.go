@@ -2,20 +2,224 @@
 // including the definitions and operations related to individuals in the population.
 package ga
 
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync/atomic"
+)
+
+var (
+	// ErrNotFloat64Encoding is returned when a float64 gene is accessed on a genotype
+	// that is not float64-encoded.
+	ErrNotFloat64Encoding = errors.New("genotype is not float64-encoded")
+	// ErrPositionOutOfRange is returned when a gene position is outside the genome.
+	ErrPositionOutOfRange = errors.New("gene position out of range")
+	// ErrInvalidGenomeLength is returned by the genotype constructors when the genome length
+	// is not positive or exceeds the maximum length.
+	ErrInvalidGenomeLength = errors.New("invalid genome length")
+	// ErrInvalidBounds is returned by the genotype constructors when the bounds do not cover
+	// every gene or a lower bound exceeds its upper bound.
+	ErrInvalidBounds = errors.New("invalid gene bounds")
+)
+
+// GenomeType identifies how the genes of a Genotype are encoded.
+type GenomeType int
+
+const (
+	// BinaryEncoding encodes each gene as a single bit stored in a byte.
+	BinaryEncoding GenomeType = iota
+	// RealEncoding encodes each gene as a real value in [MinValues[i], MaxValues[i]],
+	// quantized to one of 256 levels stored in a byte.
+	RealEncoding
+	// Float64Encoding encodes each gene as a real value in [MinValues[i], MaxValues[i]],
+	// stored with full float64 precision in Float64Genome.
+	Float64Encoding
+	// LargePermutationEncoding encodes a permutation of 0..n-1 in Int32Genome, for
+	// permutations too large for the byte genes of Genome.
+	LargePermutationEncoding
+	// IntegerEncoding encodes each gene as an integer in [MinValues[i], MaxValues[i]],
+	// stored in Int32Genome.
+	IntegerEncoding
+)
+
 // Genotype represents the genetic makeup of an individual, encoded as a sequence of bytes.
+//
+// MaxLength bounds the genome length for variable-length genotypes. A MaxLength of zero
+// means the genome length is not bounded. MinValues and MaxValues hold the per-gene
+// bounds of real-valued and integer genotypes. Float64Genome holds the genes of
+// float64-encoded genotypes, and Int32Genome those of large-permutation and integer
+// genotypes; the Genome of either is empty.
 type Genotype struct {
-	Genome []byte
+	Genome        []byte
+	Float64Genome []float64
+	Int32Genome   []int32
+	MaxLength     int
+	GenomeType    GenomeType
+	MinValues     []float64
+	MaxValues     []float64
 }
 
 // Phenotype represents the observable traits of an individual, including its fitness value.
+//
+// Objectives holds the values of the objectives of a multi-objective problem, all of which
+// are maximized, as used by MultiObjectiveSelection. It is nil for single-objective problems.
+// Features holds arbitrary observed traits of the individual by name, such as behavior
+// descriptors or decoded solutions, and is read and written through the typed accessors.
 type Phenotype struct {
-	Fitness float64
+	Fitness    float64
+	Objectives []float64
+	Features   map[string]interface{}
+}
+
+// GetFloat returns the float64 feature with the given key.
+//
+// Parameters:
+// - key: the name of the feature.
+//
+// Returns:
+// - The value of the feature, and true if the feature is set and holds a float64.
+func (p *Phenotype) GetFloat(key string) (float64, bool) {
+	value, ok := p.Features[key].(float64)
+	return value, ok
+}
+
+// GetString returns the string feature with the given key.
+//
+// Parameters:
+// - key: the name of the feature.
+//
+// Returns:
+// - The value of the feature, and true if the feature is set and holds a string.
+func (p *Phenotype) GetString(key string) (string, bool) {
+	value, ok := p.Features[key].(string)
+	return value, ok
+}
+
+// SetFloat sets the float64 feature with the given key, replacing any existing value.
+//
+// Parameters:
+// - key: the name of the feature.
+// - value: the value to store.
+func (p *Phenotype) SetFloat(key string, value float64) {
+	p.SetFeature(key, value)
+}
+
+// SetFeature sets the feature with the given key to a value of any type, replacing any
+// existing value.
+//
+// Parameters:
+// - key: the name of the feature.
+// - value: the value to store.
+func (p *Phenotype) SetFeature(key string, value interface{}) {
+	if p.Features == nil {
+		p.Features = make(map[string]interface{})
+	}
+	p.Features[key] = value
+}
+
+// Clone returns a copy of the phenotype with its own Objectives and Features. The values in
+// Features are shared with the original.
+//
+// Returns:
+// - A pointer to the copied Phenotype.
+func (p *Phenotype) Clone() *Phenotype {
+	clone := *p
+	if p.Objectives != nil {
+		clone.Objectives = append([]float64(nil), p.Objectives...)
+	}
+	if p.Features != nil {
+		clone.Features = make(map[string]interface{}, len(p.Features))
+		for key, value := range p.Features {
+			clone.Features[key] = value
+		}
+	}
+	return &clone
 }
 
 // Individual represents an individual in the population, consisting of its genotype and phenotype.
+//
+// StrategyParams holds per-individual strategy parameters used by self-adaptive operators,
+// such as the mutation rate used by SelfAdaptiveMutation. ConstraintViolation holds the
+// magnitude by which the individual violates the problem's constraints, and is zero for
+// feasible individuals.
+//
+// Chromosomes holds the independent genome segments of multi-chromosome individuals, such as
+// those created by NewMultiChromosomeIndividual. Single-chromosome individuals use Genotype instead.
+// Age counts the generations the individual has survived in the population of a GA, including
+// as an elite; offspring and newly initialized individuals have an Age of zero. ID uniquely
+// identifies individuals created by a GA or by crossover, and ParentIDs holds the IDs of the
+// parents of an offspring; an ID of zero means none was assigned. Metadata holds arbitrary
+// problem-specific annotations, such as a decoded solution, and is not inherited by offspring.
+// SpeciesID identifies the species the individual was last assigned to by the speciation
+// package; zero means none.
 type Individual struct {
-	Genotype  *Genotype
-	Phenotype *Phenotype
+	Genotype            *Genotype
+	Phenotype           *Phenotype
+	StrategyParams      map[string]float64
+	ConstraintViolation float64
+	Chromosomes         []*Genotype
+	Age                 int
+	ID                  int64
+	ParentIDs           [2]int64
+	Metadata            map[string]interface{}
+	SpeciesID           int
+
+	// parentFitness is the fitness the individual is compared with after evaluation to
+	// adapt its mutation rate, and hasParentFitness reports whether it has been recorded.
+	parentFitness    float64
+	hasParentFitness bool
+}
+
+// lastIndividualID is the last ID assigned by NextIndividualID.
+var lastIndividualID int64
+
+// NextIndividualID returns a new, unique, positive individual ID. It is safe for concurrent use.
+func NextIndividualID() int64 {
+	return atomic.AddInt64(&lastIndividualID, 1)
+}
+
+// newOffspring creates a new individual with the given genotype, a new ID, and the IDs of its parents.
+// The offspring inherits the mean mutation rate and the mean fitness of those of its parents that
+// have one, so that SelfAdaptiveMutation carries on across crossover.
+//
+// Parameters:
+// - genotype: the genotype of the offspring.
+// - parent1: the first parent of the offspring.
+// - parent2: the second parent of the offspring.
+//
+// Returns:
+// - A pointer to the newly created Individual.
+func newOffspring(genotype *Genotype, parent1, parent2 *Individual) *Individual {
+	offspring := &Individual{
+		Genotype:  genotype,
+		ID:        NextIndividualID(),
+		ParentIDs: [2]int64{parent1.ID, parent2.ID},
+	}
+
+	var rateSum, fitnessSum float64
+	var rates, fitnesses int
+	for _, parent := range [2]*Individual{parent1, parent2} {
+		if rate, ok := parent.StrategyParams[mutationRateParam]; ok {
+			rateSum += rate
+			rates++
+		}
+		if parent.Phenotype != nil {
+			fitnessSum += parent.Phenotype.Fitness
+			fitnesses++
+		}
+	}
+	if rates > 0 {
+		offspring.StrategyParams = map[string]float64{mutationRateParam: rateSum / float64(rates)}
+	}
+	if fitnesses > 0 {
+		offspring.parentFitness = fitnessSum / float64(fitnesses)
+		offspring.hasParentFitness = true
+	}
+	return offspring
 }
 
 // NewGenotype creates a new Genotype with the specified genome length.
@@ -25,10 +229,407 @@ type Individual struct {
 //
 // Returns:
 // - A pointer to the newly created Genotype.
-func NewGenotype(genomeLength int) *Genotype {
+// - An error wrapping ErrInvalidGenomeLength if genomeLength is not positive.
+func NewGenotype(genomeLength int) (*Genotype, error) {
+	if genomeLength <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidGenomeLength, genomeLength)
+	}
 	return &Genotype{
 		Genome: make([]byte, genomeLength),
+	}, nil
+}
+
+// MustNewGenotype is like NewGenotype but panics if the genome length is invalid.
+func MustNewGenotype(genomeLength int) *Genotype {
+	return must(NewGenotype(genomeLength))
+}
+
+// NewMultiChromosomeIndividual creates a new Individual made of several independent chromosomes.
+// The Genotype of the individual is left nil.
+//
+// Parameters:
+// - genotypes: the chromosomes of the individual.
+//
+// Returns:
+// - A pointer to the newly created Individual.
+func NewMultiChromosomeIndividual(genotypes ...*Genotype) *Individual {
+	return &Individual{Chromosomes: genotypes}
+}
+
+// VariableLengthBinaryGenotype creates a new variable-length Genotype with random binary genes.
+//
+// Parameters:
+// - initialLength: the length of the genome to be created.
+// - maxLength: the maximum length the genome may grow to, or zero for no bound.
+//
+// Returns:
+// - A pointer to the newly created Genotype.
+// - An error wrapping ErrInvalidGenomeLength if initialLength is not positive or exceeds a
+// positive maxLength, or if maxLength is negative.
+func VariableLengthBinaryGenotype(initialLength, maxLength int) (*Genotype, error) {
+	if initialLength <= 0 || maxLength < 0 || (maxLength > 0 && initialLength > maxLength) {
+		return nil, fmt.Errorf("%w: %d with maximum %d", ErrInvalidGenomeLength, initialLength, maxLength)
+	}
+	genotype := &Genotype{
+		Genome:    make([]byte, initialLength),
+		MaxLength: maxLength,
+	}
+	for i := range genotype.Genome {
+		genotype.Genome[i] = byte(rand.Intn(2))
+	}
+	return genotype, nil
+}
+
+// MustVariableLengthBinaryGenotype is like VariableLengthBinaryGenotype but panics if the
+// lengths are invalid.
+func MustVariableLengthBinaryGenotype(initialLength, maxLength int) *Genotype {
+	return must(VariableLengthBinaryGenotype(initialLength, maxLength))
+}
+
+// NewRealGenotype creates a new real-valued Genotype with random genes within the given bounds.
+//
+// Parameters:
+// - genomeLength: the length of the genome to be created.
+// - minValues: the lower bound of each gene.
+// - maxValues: the upper bound of each gene.
+//
+// Returns:
+// - A pointer to the newly created Genotype.
+// - An error wrapping ErrInvalidGenomeLength or ErrInvalidBounds if the arguments are invalid.
+func NewRealGenotype(genomeLength int, minValues, maxValues []float64) (*Genotype, error) {
+	if err := checkGenotypeBounds(genomeLength, minValues, maxValues); err != nil {
+		return nil, err
+	}
+	genotype := &Genotype{
+		Genome:     make([]byte, genomeLength),
+		GenomeType: RealEncoding,
+		MinValues:  minValues,
+		MaxValues:  maxValues,
+	}
+	for i := range genotype.Genome {
+		genotype.Genome[i] = byte(rand.Intn(256))
+	}
+	return genotype, nil
+}
+
+// MustNewRealGenotype is like NewRealGenotype but panics if the arguments are invalid.
+func MustNewRealGenotype(genomeLength int, minValues, maxValues []float64) *Genotype {
+	return must(NewRealGenotype(genomeLength, minValues, maxValues))
+}
+
+// NewFloat64Genotype creates a new float64-encoded Genotype with random genes within the given bounds.
+//
+// Parameters:
+// - genomeLength: the length of the genome to be created.
+// - minValues: the lower bound of each gene.
+// - maxValues: the upper bound of each gene.
+//
+// Returns:
+// - A pointer to the newly created Genotype.
+// - An error wrapping ErrInvalidGenomeLength or ErrInvalidBounds if the arguments are invalid.
+func NewFloat64Genotype(genomeLength int, minValues, maxValues []float64) (*Genotype, error) {
+	if err := checkGenotypeBounds(genomeLength, minValues, maxValues); err != nil {
+		return nil, err
+	}
+	genotype := &Genotype{
+		Float64Genome: make([]float64, genomeLength),
+		GenomeType:    Float64Encoding,
+		MinValues:     minValues,
+		MaxValues:     maxValues,
 	}
+	for i := range genotype.Float64Genome {
+		genotype.Float64Genome[i] = minValues[i] + rand.Float64()*(maxValues[i]-minValues[i])
+	}
+	return genotype, nil
+}
+
+// MustNewFloat64Genotype is like NewFloat64Genotype but panics if the arguments are invalid.
+func MustNewFloat64Genotype(genomeLength int, minValues, maxValues []float64) *Genotype {
+	return must(NewFloat64Genotype(genomeLength, minValues, maxValues))
+}
+
+// NewIntegerGenotype creates a new integer-encoded Genotype with random genes within the
+// given bounds, which are rounded inwards to the nearest integers.
+//
+// Parameters:
+// - genomeLength: the length of the genome to be created.
+// - minValues: the lower bound of each gene.
+// - maxValues: the upper bound of each gene.
+//
+// Returns:
+// - A pointer to the newly created Genotype.
+// - An error wrapping ErrInvalidGenomeLength or ErrInvalidBounds if the arguments are invalid.
+func NewIntegerGenotype(genomeLength int, minValues, maxValues []float64) (*Genotype, error) {
+	if err := checkGenotypeBounds(genomeLength, minValues, maxValues); err != nil {
+		return nil, err
+	}
+	genotype := &Genotype{
+		Int32Genome: make([]int32, genomeLength),
+		GenomeType:  IntegerEncoding,
+		MinValues:   minValues,
+		MaxValues:   maxValues,
+	}
+	for i := range genotype.Int32Genome {
+		genotype.Int32Genome[i] = genotype.randomInteger(i)
+	}
+	return genotype, nil
+}
+
+// MustNewIntegerGenotype is like NewIntegerGenotype but panics if the arguments are invalid.
+func MustNewIntegerGenotype(genomeLength int, minValues, maxValues []float64) *Genotype {
+	return must(NewIntegerGenotype(genomeLength, minValues, maxValues))
+}
+
+// integerBounds returns the bounds of the integer gene at the given position, rounded
+// inwards to the nearest integers.
+func (g *Genotype) integerBounds(position int) (lower, upper int32) {
+	return int32(math.Ceil(g.MinValues[position])), int32(math.Floor(g.MaxValues[position]))
+}
+
+// randomInteger returns a uniformly random integer within the bounds of the gene at the
+// given position.
+func (g *Genotype) randomInteger(position int) int32 {
+	lower, upper := g.integerBounds(position)
+	return lower + rand.Int31n(upper-lower+1)
+}
+
+// checkGenotypeBounds checks that the genome length is positive and that the bounds hold a
+// lower bound no greater than the upper bound for every gene.
+func checkGenotypeBounds(genomeLength int, minValues, maxValues []float64) error {
+	if genomeLength <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidGenomeLength, genomeLength)
+	}
+	if len(minValues) < genomeLength || len(maxValues) < genomeLength {
+		return fmt.Errorf("%w: %d lower and %d upper bounds for %d genes", ErrInvalidBounds, len(minValues), len(maxValues), genomeLength)
+	}
+	for i := 0; i < genomeLength; i++ {
+		if minValues[i] > maxValues[i] {
+			return fmt.Errorf("%w: gene %d has lower bound %g above upper bound %g", ErrInvalidBounds, i, minValues[i], maxValues[i])
+		}
+	}
+	return nil
+}
+
+// must returns the genotype, panicking if err is non-nil. It backs the Must constructors.
+func must(genotype *Genotype, err error) *Genotype {
+	if err != nil {
+		panic(err)
+	}
+	return genotype
+}
+
+// Len returns the number of genes in the genotype.
+func (g *Genotype) Len() int {
+	switch g.GenomeType {
+	case Float64Encoding:
+		return len(g.Float64Genome)
+	case LargePermutationEncoding, IntegerEncoding:
+		return len(g.Int32Genome)
+	}
+	return len(g.Genome)
+}
+
+// GetFloat64Value returns the gene at the given position of a float64-encoded genotype.
+//
+// Parameters:
+// - position: the index of the gene to return.
+//
+// Returns:
+// - The value of the gene.
+// - An error if the genotype is not float64-encoded or the position is out of range.
+func (g *Genotype) GetFloat64Value(position int) (float64, error) {
+	if err := g.checkFloat64Position(position); err != nil {
+		return 0, err
+	}
+	return g.Float64Genome[position], nil
+}
+
+// SetFloat64Value sets the gene at the given position of a float64-encoded genotype.
+// If the genotype has bounds, values outside [MinValues[position], MaxValues[position]]
+// are clamped.
+//
+// Parameters:
+// - position: the index of the gene to set.
+// - value: the value to store.
+//
+// Returns:
+// - An error if the genotype is not float64-encoded or the position is out of range.
+func (g *Genotype) SetFloat64Value(position int, value float64) error {
+	if err := g.checkFloat64Position(position); err != nil {
+		return err
+	}
+	if position < len(g.MinValues) && position < len(g.MaxValues) {
+		value = math.Min(math.Max(value, g.MinValues[position]), g.MaxValues[position])
+	}
+	g.Float64Genome[position] = value
+	return nil
+}
+
+func (g *Genotype) checkFloat64Position(position int) error {
+	if g.GenomeType != Float64Encoding {
+		return ErrNotFloat64Encoding
+	}
+	if position < 0 || position >= len(g.Float64Genome) {
+		return fmt.Errorf("%w: position %d, genome length %d", ErrPositionOutOfRange, position, len(g.Float64Genome))
+	}
+	return nil
+}
+
+// GetRealValue decodes the gene at the given position of a real-valued genotype,
+// using either RealEncoding or Float64Encoding.
+//
+// Parameters:
+// - position: the index of the gene to decode.
+//
+// Returns:
+// - The real value of the gene, within [MinValues[position], MaxValues[position]].
+func (g *Genotype) GetRealValue(position int) float64 {
+	if g.GenomeType == Float64Encoding {
+		return g.Float64Genome[position]
+	}
+	lower, upper := g.MinValues[position], g.MaxValues[position]
+	return lower + (upper-lower)*float64(g.Genome[position])/255
+}
+
+// SetRealValue encodes a real value into the gene at the given position of a real-valued
+// genotype, using either RealEncoding or Float64Encoding. Values outside
+// [MinValues[position], MaxValues[position]] are clamped.
+//
+// Parameters:
+// - position: the index of the gene to encode.
+// - value: the real value to store.
+func (g *Genotype) SetRealValue(position int, value float64) {
+	lower, upper := g.MinValues[position], g.MaxValues[position]
+	value = math.Min(math.Max(value, lower), upper)
+	if g.GenomeType == Float64Encoding {
+		g.Float64Genome[position] = value
+		return
+	}
+	if upper <= lower {
+		g.Genome[position] = 0
+		return
+	}
+	g.Genome[position] = byte(math.Round((value - lower) / (upper - lower) * 255))
+}
+
+// Clone returns a deep copy of the genotype. The gene bounds are shared with the original,
+// since they describe the encoding rather than the genes themselves.
+//
+// Returns:
+// - A pointer to the copied Genotype.
+func (g *Genotype) Clone() *Genotype {
+	clone := *g
+	clone.Genome = append([]byte(nil), g.Genome...)
+	if g.Float64Genome != nil {
+		clone.Float64Genome = append([]float64(nil), g.Float64Genome...)
+	}
+	if g.Int32Genome != nil {
+		clone.Int32Genome = append([]int32(nil), g.Int32Genome...)
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the individual, including its genotype, chromosomes,
+// phenotype, and strategy parameters. The Metadata map is copied, but the values in it
+// are shared with the original.
+//
+// Returns:
+// - A pointer to the copied Individual.
+func (ind *Individual) Clone() *Individual {
+	clone := *ind
+	if ind.Genotype != nil {
+		clone.Genotype = ind.Genotype.Clone()
+	}
+	if ind.Chromosomes != nil {
+		clone.Chromosomes = make([]*Genotype, len(ind.Chromosomes))
+		for i, chromosome := range ind.Chromosomes {
+			clone.Chromosomes[i] = chromosome.Clone()
+		}
+	}
+	if ind.Phenotype != nil {
+		clone.Phenotype = ind.Phenotype.Clone()
+	}
+	if ind.StrategyParams != nil {
+		clone.StrategyParams = make(map[string]float64, len(ind.StrategyParams))
+		for key, value := range ind.StrategyParams {
+			clone.StrategyParams[key] = value
+		}
+	}
+	if ind.Metadata != nil {
+		clone.Metadata = make(map[string]interface{}, len(ind.Metadata))
+		for key, value := range ind.Metadata {
+			clone.Metadata[key] = value
+		}
+	}
+	return &clone
+}
+
+// SetMeta stores a value in the metadata of the individual, creating the map if needed.
+//
+// Parameters:
+// - key: the key to store the value under.
+// - value: the value to store.
+func (ind *Individual) SetMeta(key string, value interface{}) {
+	if ind.Metadata == nil {
+		ind.Metadata = make(map[string]interface{})
+	}
+	ind.Metadata[key] = value
+}
+
+// GetMeta returns a value from the metadata of the individual.
+//
+// Parameters:
+// - key: the key the value is stored under.
+//
+// Returns:
+// - The value, or nil if there is none.
+// - true if a value is stored under the key, false otherwise.
+func (ind *Individual) GetMeta(key string) (interface{}, bool) {
+	value, ok := ind.Metadata[key]
+	return value, ok
+}
+
+// Fitness returns the fitness of the individual, or 0 if it has not been evaluated.
+func (ind *Individual) Fitness() float64 {
+	if ind.Phenotype == nil {
+		return 0
+	}
+	return ind.Phenotype.Fitness
+}
+
+// GenotypeEqual reports whether two individuals have identical genes. Genotypes of different
+// encodings are never equal.
+//
+// Parameters:
+// - b: the individual to compare with.
+//
+// Returns:
+// - true if the genotypes of both individuals hold the same genes, false otherwise.
+func (ind *Individual) GenotypeEqual(b *Individual) bool {
+	if ind.Genotype == nil || b.Genotype == nil {
+		return ind.Genotype == b.Genotype
+	}
+	return ind.Genotype.GenomeType == b.Genotype.GenomeType && bytes.Equal(ind.Genotype.key(), b.Genotype.key())
+}
+
+// key returns a byte representation of the genes that uniquely identifies them,
+// suitable for use as a cache key.
+func (g *Genotype) key() []byte {
+	switch g.GenomeType {
+	case Float64Encoding:
+		key := make([]byte, 8*len(g.Float64Genome))
+		for i, value := range g.Float64Genome {
+			binary.LittleEndian.PutUint64(key[8*i:], math.Float64bits(value))
+		}
+		return key
+	case LargePermutationEncoding, IntegerEncoding:
+		key := make([]byte, 4*len(g.Int32Genome))
+		for i, value := range g.Int32Genome {
+			binary.LittleEndian.PutUint32(key[4*i:], uint32(value))
+		}
+		return key
+	}
+	return g.Genome
 }
 
 // findBestIndividual finds the individual with the highest fitness in the given population.
@@ -39,11 +640,26 @@ func NewGenotype(genomeLength int) *Genotype {
 // Returns:
 // - A pointer to the individual with the highest fitness.
 func findBestIndividual(population []*Individual) *Individual {
+	return bestIndividual(population, false)
+}
+
+// bestIndividual finds the individual with the best fitness in the given population, which
+// is the lowest fitness if minimize is set and the highest otherwise.
+func bestIndividual(population []*Individual, minimize bool) *Individual {
 	best := population[0]
 	for _, ind := range population {
-		if ind.Phenotype.Fitness > best.Phenotype.Fitness {
+		if fitter(ind.Phenotype.Fitness, best.Phenotype.Fitness, minimize) {
 			best = ind
 		}
 	}
 	return best
 }
+
+// fitter reports whether fitness a is strictly better than fitness b, that is, lower if
+// minimize is set and higher otherwise.
+func fitter(a, b float64, minimize bool) bool {
+	if minimize {
+		return a < b
+	}
+	return a > b
+}
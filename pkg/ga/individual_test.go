@@ -1,6 +1,11 @@
 package ga
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
 
 func TestNewGenotype(t *testing.T) {
 	cases := []struct {
@@ -12,7 +17,10 @@ func TestNewGenotype(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		genotype := NewGenotype(tc.genomeLength)
+		genotype, err := NewGenotype(tc.genomeLength)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
 
 		if len(genotype.Genome) != tc.expectedLength {
 			t.Fatalf("Expected genome length %d, but got %d", tc.expectedLength, len(genotype.Genome))
@@ -52,3 +60,353 @@ func TestFindBestIndividual(t *testing.T) {
 		}
 	}
 }
+
+func TestGenotypeEqual(t *testing.T) {
+	cases := []struct {
+		a        *Genotype
+		b        *Genotype
+		expected bool
+	}{
+		{a: &Genotype{Genome: []byte{1, 0, 1}}, b: &Genotype{Genome: []byte{1, 0, 1}}, expected: true},
+		{a: &Genotype{Genome: []byte{1, 0, 1}}, b: &Genotype{Genome: []byte{1, 1, 1}}, expected: false},
+		{a: &Genotype{Genome: []byte{1, 0}}, b: &Genotype{Genome: []byte{1, 0, 1}}, expected: false},
+		{a: &Genotype{Genome: []byte{1}}, b: &Genotype{Genome: []byte{1}, GenomeType: RealEncoding}, expected: false},
+		{a: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{0.5}}, b: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{0.5}}, expected: true},
+		{a: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{0.5}}, b: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{0.25}}, expected: false},
+	}
+
+	for i, tc := range cases {
+		if got := (&Individual{Genotype: tc.a}).GenotypeEqual(&Individual{Genotype: tc.b}); got != tc.expected {
+			t.Errorf("Expected %t for case %d, but got %t", tc.expected, i, got)
+		}
+	}
+}
+
+func TestVariableLengthBinaryGenotype(t *testing.T) {
+	cases := []struct {
+		initialLength int
+		maxLength     int
+	}{
+		{initialLength: 5, maxLength: 10},
+		{initialLength: 1, maxLength: 1},
+	}
+
+	for _, tc := range cases {
+		genotype, err := VariableLengthBinaryGenotype(tc.initialLength, tc.maxLength)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+
+		if len(genotype.Genome) != tc.initialLength {
+			t.Fatalf("Expected genome length %d, but got %d", tc.initialLength, len(genotype.Genome))
+		}
+		if genotype.MaxLength != tc.maxLength {
+			t.Errorf("Expected max length %d, but got %d", tc.maxLength, genotype.MaxLength)
+		}
+		for _, gene := range genotype.Genome {
+			if gene > 1 {
+				t.Errorf("Expected binary genes, but got %d", gene)
+			}
+		}
+	}
+}
+
+func TestRealValue(t *testing.T) {
+	cases := []struct {
+		value    float64
+		expected float64
+	}{
+		{value: 0.0, expected: 0.0},
+		{value: 10.0, expected: 10.0},
+		{value: -3.0, expected: 0.0},
+		{value: 42.0, expected: 10.0},
+	}
+
+	genotype := MustNewRealGenotype(1, []float64{0}, []float64{10})
+	for _, tc := range cases {
+		genotype.SetRealValue(0, tc.value)
+
+		if got := genotype.GetRealValue(0); math.Abs(got-tc.expected) > 10.0/255 {
+			t.Errorf("Expected real value %f, but got %f", tc.expected, got)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	genotype := MustNewRealGenotype(3, []float64{0, 0, 0}, []float64{1, 1, 1})
+	clone := genotype.Clone()
+
+	clone.Genome[0] = genotype.Genome[0] + 1
+
+	if clone.Genome[0] == genotype.Genome[0] {
+		t.Fatalf("Expected clone genome to be independent of the original")
+	}
+	if clone.GenomeType != genotype.GenomeType {
+		t.Errorf("Expected genome type %d, but got %d", genotype.GenomeType, clone.GenomeType)
+	}
+}
+
+func TestFloat64Value(t *testing.T) {
+	cases := []float64{math.Pi, -math.E, 1e-300, 123456.789012345678, math.SmallestNonzeroFloat64}
+
+	minValues := make([]float64, len(cases))
+	maxValues := make([]float64, len(cases))
+	for i := range cases {
+		minValues[i] = -math.MaxFloat64
+		maxValues[i] = math.MaxFloat64
+	}
+	genotype := MustNewFloat64Genotype(len(cases), minValues, maxValues)
+
+	for i, value := range cases {
+		if err := genotype.SetFloat64Value(i, value); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		got, err := genotype.GetFloat64Value(i)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if math.Abs(got-value) > 1e-15*math.Abs(value) {
+			t.Errorf("Expected value %g, but got %g", value, got)
+		}
+	}
+}
+
+func TestFloat64ValueErrors(t *testing.T) {
+	cases := []struct {
+		genotype *Genotype
+		position int
+		expected error
+	}{
+		{genotype: MustNewFloat64Genotype(2, []float64{0, 0}, []float64{1, 1}), position: 2, expected: ErrPositionOutOfRange},
+		{genotype: MustNewFloat64Genotype(2, []float64{0, 0}, []float64{1, 1}), position: -1, expected: ErrPositionOutOfRange},
+		{genotype: MustNewGenotype(2), position: 0, expected: ErrNotFloat64Encoding},
+	}
+
+	for _, tc := range cases {
+		if _, err := tc.genotype.GetFloat64Value(tc.position); !errors.Is(err, tc.expected) {
+			t.Errorf("Expected error %v from GetFloat64Value, but got %v", tc.expected, err)
+		}
+		if err := tc.genotype.SetFloat64Value(tc.position, 0.5); !errors.Is(err, tc.expected) {
+			t.Errorf("Expected error %v from SetFloat64Value, but got %v", tc.expected, err)
+		}
+	}
+}
+
+func TestCloneFloat64Genotype(t *testing.T) {
+	genotype := MustNewFloat64Genotype(3, []float64{0, 0, 0}, []float64{1, 1, 1})
+	clone := genotype.Clone()
+
+	clone.Float64Genome[0] = genotype.Float64Genome[0] + 1
+
+	if clone.Float64Genome[0] == genotype.Float64Genome[0] {
+		t.Fatalf("Expected clone Float64Genome to be independent of the original")
+	}
+}
+
+func TestCloneIndividual(t *testing.T) {
+	ind := &Individual{
+		Genotype:       &Genotype{Genome: []byte{1, 0, 1}},
+		Phenotype:      &Phenotype{Fitness: 2.0},
+		StrategyParams: map[string]float64{"mutationRate": 0.1},
+	}
+	clone := ind.Clone()
+
+	clone.Genotype.Genome[0] = 0
+	clone.Phenotype.Fitness = 0.0
+	clone.StrategyParams["mutationRate"] = 0.2
+
+	if ind.Genotype.Genome[0] != 1 || ind.Phenotype.Fitness != 2.0 || ind.StrategyParams["mutationRate"] != 0.1 {
+		t.Errorf("Expected the original individual to be unaffected by changes to its clone, but got %+v", ind)
+	}
+}
+
+func TestIndividualMetadata(t *testing.T) {
+	cases := []struct {
+		key   string
+		value interface{}
+	}{
+		{key: "route", value: "A-B-C"},
+		{key: "cost", value: 12.5},
+		{key: "tour", value: []int{2, 0, 1}},
+	}
+
+	ind := &Individual{}
+	if _, ok := ind.GetMeta("route"); ok {
+		t.Fatalf("Expected no metadata on a new individual")
+	}
+	for _, tc := range cases {
+		ind.SetMeta(tc.key, tc.value)
+	}
+	for _, tc := range cases {
+		if value, ok := ind.GetMeta(tc.key); !ok || !reflect.DeepEqual(value, tc.value) {
+			t.Errorf("Expected metadata %q to be %v, but got %v", tc.key, tc.value, value)
+		}
+	}
+
+	clone := ind.Clone()
+	clone.SetMeta("route", "C-B-A")
+	clone.SetMeta("extra", true)
+	delete(clone.Metadata, "cost")
+
+	if value, _ := ind.GetMeta("route"); value != "A-B-C" {
+		t.Errorf("Expected the original metadata to be unaffected by the clone, but got %v", value)
+	}
+	if _, ok := ind.GetMeta("extra"); ok {
+		t.Errorf("Expected keys added to the clone not to appear in the original")
+	}
+	if _, ok := ind.GetMeta("cost"); !ok {
+		t.Errorf("Expected keys deleted from the clone to remain in the original")
+	}
+}
+
+func TestPhenotypeFeatures(t *testing.T) {
+	ind := &Individual{Phenotype: &Phenotype{Fitness: 1}}
+	if _, ok := ind.Phenotype.GetFloat("length"); ok {
+		t.Fatalf("Expected GetFloat to report a missing feature")
+	}
+	ind.Phenotype.SetFloat("length", 42.5)
+	ind.Phenotype.SetFeature("route", "A-B-C")
+
+	cases := []struct {
+		key           string
+		expectedFloat bool
+		expectedStr   bool
+	}{
+		{key: "length", expectedFloat: true},
+		{key: "route", expectedStr: true},
+		{key: "missing"},
+	}
+	for _, tc := range cases {
+		if _, ok := ind.Phenotype.GetFloat(tc.key); ok != tc.expectedFloat {
+			t.Errorf("Expected GetFloat(%q) to report %v, but got %v", tc.key, tc.expectedFloat, ok)
+		}
+		if _, ok := ind.Phenotype.GetString(tc.key); ok != tc.expectedStr {
+			t.Errorf("Expected GetString(%q) to report %v, but got %v", tc.key, tc.expectedStr, ok)
+		}
+	}
+
+	clone := ind.Clone()
+	clone.Phenotype.SetFloat("length", 7)
+	clone.Phenotype.SetFeature("extra", true)
+
+	if length, _ := ind.Phenotype.GetFloat("length"); length != 42.5 {
+		t.Errorf("Expected the original feature to be unaffected by the clone, but got %f", length)
+	}
+	if _, ok := ind.Phenotype.Features["extra"]; ok {
+		t.Errorf("Expected features added to the clone not to appear in the original")
+	}
+}
+
+func TestCloneMultiChromosomeIndividual(t *testing.T) {
+	ind := NewMultiChromosomeIndividual(&Genotype{Genome: []byte{1, 0, 1}}, &Genotype{Genome: []byte{1, 1}})
+	clone := ind.Clone()
+
+	clone.Chromosomes[0].Genome[0] = 0
+	clone.Chromosomes[1].Genome[1] = 0
+
+	if len(clone.Chromosomes) != 2 {
+		t.Fatalf("Expected %d chromosomes, but got %d", 2, len(clone.Chromosomes))
+	}
+	if ind.Chromosomes[0].Genome[0] != 1 || ind.Chromosomes[1].Genome[1] != 1 {
+		t.Errorf("Expected the original chromosomes to be unaffected by changes to the clone, but got %v and %v", ind.Chromosomes[0].Genome, ind.Chromosomes[1].Genome)
+	}
+}
+
+func TestGenotypeConstructorErrors(t *testing.T) {
+	newGenotype := func(length int) func() (*Genotype, error) {
+		return func() (*Genotype, error) { return NewGenotype(length) }
+	}
+	variableLength := func(initialLength, maxLength int) func() (*Genotype, error) {
+		return func() (*Genotype, error) { return VariableLengthBinaryGenotype(initialLength, maxLength) }
+	}
+	realGenotype := func(length int, minValues, maxValues []float64) func() (*Genotype, error) {
+		return func() (*Genotype, error) { return NewRealGenotype(length, minValues, maxValues) }
+	}
+	float64Genotype := func(length int, minValues, maxValues []float64) func() (*Genotype, error) {
+		return func() (*Genotype, error) { return NewFloat64Genotype(length, minValues, maxValues) }
+	}
+
+	cases := []struct {
+		name        string
+		construct   func() (*Genotype, error)
+		expectedErr error
+	}{
+		{name: "NewGenotype(0)", construct: newGenotype(0), expectedErr: ErrInvalidGenomeLength},
+		{name: "NewGenotype(-1)", construct: newGenotype(-1), expectedErr: ErrInvalidGenomeLength},
+		{name: "VariableLengthBinaryGenotype(0, 5)", construct: variableLength(0, 5), expectedErr: ErrInvalidGenomeLength},
+		{name: "VariableLengthBinaryGenotype(6, 5)", construct: variableLength(6, 5), expectedErr: ErrInvalidGenomeLength},
+		{name: "VariableLengthBinaryGenotype(5, 0)", construct: variableLength(5, 0), expectedErr: nil},
+		{name: "NewRealGenotype with missing bounds", construct: realGenotype(2, []float64{0}, []float64{1, 1}), expectedErr: ErrInvalidBounds},
+		{name: "NewRealGenotype with inverted bounds", construct: realGenotype(1, []float64{1}, []float64{0}), expectedErr: ErrInvalidBounds},
+		{name: "NewFloat64Genotype(0)", construct: float64Genotype(0, nil, nil), expectedErr: ErrInvalidGenomeLength},
+		{name: "NewFloat64Genotype with missing bounds", construct: float64Genotype(2, []float64{0, 0}, []float64{1}), expectedErr: ErrInvalidBounds},
+	}
+
+	for _, tc := range cases {
+		genotype, err := tc.construct()
+		if !errors.Is(err, tc.expectedErr) {
+			t.Errorf("%s: expected error %v, but got %v", tc.name, tc.expectedErr, err)
+		}
+		if (err == nil) != (genotype != nil) {
+			t.Errorf("%s: expected a genotype exactly when there is no error, but got %v and %v", tc.name, genotype, err)
+		}
+	}
+}
+
+func TestMustNewGenotypePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected MustNewGenotype(0) to panic")
+		}
+	}()
+	MustNewGenotype(0)
+}
+
+func TestNewOffspringInheritance(t *testing.T) {
+	cases := []struct {
+		name            string
+		parent1         *Individual
+		parent2         *Individual
+		expectedRate    float64
+		hasRate         bool
+		expectedFitness float64
+		hasFitness      bool
+	}{
+		{
+			name:            "both parents adapted and evaluated",
+			parent1:         &Individual{StrategyParams: map[string]float64{mutationRateParam: 0.1}, Phenotype: &Phenotype{Fitness: 2}},
+			parent2:         &Individual{StrategyParams: map[string]float64{mutationRateParam: 0.3}, Phenotype: &Phenotype{Fitness: 4}},
+			expectedRate:    0.2,
+			hasRate:         true,
+			expectedFitness: 3,
+			hasFitness:      true,
+		},
+		{
+			name:            "one parent adapted and evaluated",
+			parent1:         &Individual{StrategyParams: map[string]float64{mutationRateParam: 0.1}, Phenotype: &Phenotype{Fitness: 2}},
+			parent2:         &Individual{},
+			expectedRate:    0.1,
+			hasRate:         true,
+			expectedFitness: 2,
+			hasFitness:      true,
+		},
+		{
+			name:    "neither parent adapted nor evaluated",
+			parent1: &Individual{},
+			parent2: &Individual{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			offspring := newOffspring(MustNewGenotype(4), c.parent1, c.parent2)
+			rate, ok := offspring.StrategyParams[mutationRateParam]
+			if ok != c.hasRate || math.Abs(rate-c.expectedRate) > 1e-9 {
+				t.Errorf("Expected mutation rate %f (%t), but got %f (%t)", c.expectedRate, c.hasRate, rate, ok)
+			}
+			if offspring.hasParentFitness != c.hasFitness || offspring.parentFitness != c.expectedFitness {
+				t.Errorf("Expected parent fitness %f (%t), but got %f (%t)", c.expectedFitness, c.hasFitness, offspring.parentFitness, offspring.hasParentFitness)
+			}
+		})
+	}
+}
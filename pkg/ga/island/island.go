@@ -0,0 +1,150 @@
+// Package island provides the island model for genetic algorithms, in which several
+// populations evolve independently and periodically exchange migrants.
+package island
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// IslandModel evolves several islands connected in a ring, each island sending migrants to
+// the next one, and the last to the first.
+//
+// Each island is a GA with its own operators and rates; its Generations field is overwritten
+// by Run. Every MigrationInterval generations, MigrationSize migrants chosen by MigrationPolicy,
+// which defaults to ElitistMigration, replace the worst individuals of the next island.
+//
+// ParallelIslands, if set, makes Run initialize the islands and evolve them between
+// migrations concurrently, each in its own goroutine; migration still happens once all
+// islands have finished. The islands must then not share state that is not safe for
+// concurrent use, such as a *rand.Rand or a Cache, and the genotype initializer and the
+// fitness function must be safe for concurrent use.
+type IslandModel struct {
+	Islands           []*ga.GA
+	PopulationSize    int
+	MigrationInterval int
+	MigrationSize     int
+	MigrationPolicy   MigrationPolicy
+	ParallelIslands   bool
+}
+
+// Run initializes every island and evolves them for the given number of epochs, each epoch
+// evolving every island for MigrationInterval generations and then migrating.
+//
+// Parameters:
+// - initFunc: a function creating the Genotype of a new individual.
+// - evalFunc: a function to evaluate a Genotype and return its Phenotype.
+// - epochs: the number of migration events.
+//
+// Returns:
+// - The best individual of each island, in the order of Islands.
+// - An error if an island fails to initialize or to evolve.
+func (m *IslandModel) Run(initFunc func() *ga.Genotype, evalFunc func(*ga.Genotype) *ga.Phenotype, epochs int) ([]*ga.Individual, error) {
+	err := m.forEachIsland(func(island *ga.GA) error {
+		return island.Initialize(m.PopulationSize, initFunc, evalFunc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for epoch := 0; epoch < epochs; epoch++ {
+		err := m.forEachIsland(func(island *ga.GA) error {
+			island.Generations = max(m.MigrationInterval, 1)
+			_, _, err := island.Evolve(evalFunc)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		m.Migrate()
+	}
+
+	best := make([]*ga.Individual, len(m.Islands))
+	for i, island := range m.Islands {
+		best[i] = ElitistMigration{}.SelectMigrants(population(island), 1)[0]
+	}
+	return best, nil
+}
+
+// forEachIsland calls f for every island, concurrently if ParallelIslands is set, and waits
+// for all calls to return.
+//
+// Parameters:
+// - f: the function to call for each island.
+//
+// Returns:
+// - The error of the first island, in the order of Islands, for which f failed, or nil.
+func (m *IslandModel) forEachIsland(f func(island *ga.GA) error) error {
+	if !m.ParallelIslands {
+		for _, island := range m.Islands {
+			if err := f(island); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Each goroutine writes only the error of its own island.
+	errs := make([]error, len(m.Islands))
+	var wg sync.WaitGroup
+	for i, island := range m.Islands {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = f(island)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Migrate performs one migration event around the ring: the migrants of every island are
+// chosen first, and clones of them then replace the worst individuals of the next island.
+func (m *IslandModel) Migrate() {
+	if len(m.Islands) < 2 || m.MigrationSize <= 0 {
+		return
+	}
+	policy := m.MigrationPolicy
+	if policy == nil {
+		policy = ElitistMigration{}
+	}
+
+	migrants := make([][]*ga.Individual, len(m.Islands))
+	for i, island := range m.Islands {
+		migrants[i] = policy.SelectMigrants(population(island), m.MigrationSize)
+	}
+	for i, island := range m.Islands {
+		receive(island, migrants[(i+len(m.Islands)-1)%len(m.Islands)])
+	}
+}
+
+// receive replaces the worst individuals of the island with clones of the migrants. The
+// island keeps at least its best individual if it holds no more individuals than migrants.
+func receive(island *ga.GA, migrants []*ga.Individual) {
+	worst := make([]int, len(island.Population))
+	for i := range worst {
+		worst[i] = i
+	}
+	sort.SliceStable(worst, func(a, b int) bool {
+		return better(island.Population[worst[b]], island.Population[worst[a]], island.Minimize)
+	})
+	worst = worst[:min(len(migrants), len(worst)-1)]
+
+	population := append([]*ga.Individual(nil), island.Population...)
+	for k, i := range worst {
+		population[i] = migrants[k].Clone()
+	}
+	island.Population = population
+}
+
+// population returns the population of the island.
+func population(island *ga.GA) *ga.Population {
+	return &ga.Population{Individuals: island.Population, Minimize: island.Minimize}
+}
@@ -0,0 +1,42 @@
+package island
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// cpuBoundOneMax is oneMax made CPU-bound by counting the ones many times over, simulating
+// an expensive fitness function that keeps a core busy.
+func cpuBoundOneMax(genotype *ga.Genotype) *ga.Phenotype {
+	const rounds = 2000
+	total := 0.0
+	for i := 0; i < rounds; i++ {
+		total += oneMax(genotype).Fitness
+	}
+	return &ga.Phenotype{Fitness: total / rounds}
+}
+
+func BenchmarkIslandModel(b *testing.B) {
+	const islands = 4
+
+	for _, parallel := range []bool{false, true} {
+		b.Run(fmt.Sprintf("Parallel-%t", parallel), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m := &IslandModel{
+					PopulationSize:    20,
+					MigrationInterval: 5,
+					MigrationSize:     2,
+					ParallelIslands:   parallel,
+				}
+				for j := 0; j < islands; j++ {
+					m.Islands = append(m.Islands, newIsland())
+				}
+				if _, err := m.Run(func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(32, 32) }, cpuBoundOneMax, 2); err != nil {
+					b.Fatalf("Expected no error, but got %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package island
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// oneMax returns the number of ones in the genome as the fitness.
+func oneMax(genotype *ga.Genotype) *ga.Phenotype {
+	fitness := 0.0
+	for _, gene := range genotype.Genome {
+		fitness += float64(gene)
+	}
+	return &ga.Phenotype{Fitness: fitness}
+}
+
+func newIsland() *ga.GA {
+	return &ga.GA{
+		Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 2) },
+		Crossover:     ga.UniformCrossover,
+		Mutation:      ga.BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.05,
+		Generations:   1,
+	}
+}
+
+func bestFitness(island *ga.GA) float64 {
+	return ElitistMigration{}.SelectMigrants(population(island), 1)[0].Phenotype.Fitness
+}
+
+func TestElitistRingMigration(t *testing.T) {
+	m := &IslandModel{MigrationSize: 2, MigrationPolicy: ElitistMigration{}}
+	for i := 0; i < 4; i++ {
+		island := newIsland()
+		island.Initialize(10, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(32, 32) }, oneMax)
+		m.Islands = append(m.Islands, island)
+	}
+
+	for event := 0; event < 10; event++ {
+		for _, island := range m.Islands {
+			island.Evolve(oneMax)
+		}
+		before := make([]float64, len(m.Islands))
+		for i, island := range m.Islands {
+			before[i] = bestFitness(island)
+		}
+
+		m.Migrate()
+
+		for i, island := range m.Islands {
+			previous := (i + len(m.Islands) - 1) % len(m.Islands)
+			after := bestFitness(island)
+			if after < before[i] || after < before[previous] {
+				t.Errorf("Migration %d: Expected island %d to keep at least best fitness %f and receive %f, but got %f", event, i, before[i], before[previous], after)
+			}
+			if len(island.Population) != 10 {
+				t.Errorf("Migration %d: Expected island %d to keep %d individuals, but got %d", event, i, 10, len(island.Population))
+			}
+		}
+	}
+}
+
+func TestIslandModelRun(t *testing.T) {
+	policies := []MigrationPolicy{nil, ElitistMigration{}, RandomMigration{}, TournamentMigration{TournamentSize: 3}}
+
+	for _, parallel := range []bool{false, true} {
+		for _, policy := range policies {
+			m := &IslandModel{
+				Islands:           []*ga.GA{newIsland(), newIsland(), newIsland(), newIsland()},
+				PopulationSize:    10,
+				MigrationInterval: 2,
+				MigrationSize:     2,
+				MigrationPolicy:   policy,
+				ParallelIslands:   parallel,
+			}
+
+			best, err := m.Run(func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(32, 32) }, oneMax, 5)
+			if err != nil {
+				t.Fatalf("%T, parallel %t: Expected no error, but got %v", policy, parallel, err)
+			}
+			if len(best) != len(m.Islands) {
+				t.Fatalf("%T, parallel %t: Expected %d best individuals, but got %d", policy, parallel, len(m.Islands), len(best))
+			}
+			for i, island := range m.Islands {
+				if generations := len(island.GetHistory()) - 1; generations != 10 {
+					t.Errorf("%T, parallel %t: Expected island %d to evolve %d generations, but got %d", policy, parallel, i, 10, generations)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelIslandsError(t *testing.T) {
+	m := &IslandModel{
+		Islands:           []*ga.GA{newIsland(), newIsland(), newIsland()},
+		PopulationSize:    10,
+		MigrationInterval: 2,
+		MigrationSize:     2,
+		ParallelIslands:   true,
+	}
+	// The second island requires two objectives, which oneMax does not return.
+	m.Islands[1].ObjectiveCount = 2
+
+	if _, err := m.Run(func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(32, 32) }, oneMax, 3); !errors.Is(err, ga.ErrObjectiveCount) {
+		t.Errorf("Expected an error wrapping ErrObjectiveCount, but got %v", err)
+	}
+}
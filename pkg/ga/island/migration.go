@@ -0,0 +1,90 @@
+// Package island provides the island model for genetic algorithms, in which several
+// populations evolve independently and periodically exchange migrants.
+package island
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// MigrationPolicy chooses the individuals of an island that migrate to its neighbor.
+type MigrationPolicy interface {
+	// SelectMigrants returns n individuals of the population to migrate. The individuals are
+	// cloned before they join another island, so they may be returned as they are.
+	SelectMigrants(pop *ga.Population, n int) []*ga.Individual
+}
+
+// ElitistMigration sends the n best individuals of an island, which spreads the best
+// solutions quickly at the cost of diversity.
+type ElitistMigration struct{}
+
+// SelectMigrants returns the n best individuals of the population, best first, or every
+// individual if the population holds fewer than n.
+func (ElitistMigration) SelectMigrants(pop *ga.Population, n int) []*ga.Individual {
+	ranked := append([]*ga.Individual(nil), pop.Individuals...)
+	sort.SliceStable(ranked, func(a, b int) bool { return better(ranked[a], ranked[b], pop.Minimize) })
+	return ranked[:min(n, len(ranked))]
+}
+
+// RandomMigration sends n individuals of an island chosen uniformly at random without
+// replacement, which preserves the diversity of the receiving island. If Rng is nil, the
+// global source of math/rand is used.
+type RandomMigration struct {
+	Rng *rand.Rand
+}
+
+// SelectMigrants returns n distinct individuals of the population chosen at random, or every
+// individual if the population holds fewer than n.
+func (m RandomMigration) SelectMigrants(pop *ga.Population, n int) []*ga.Individual {
+	perm := rand.Perm
+	if m.Rng != nil {
+		perm = m.Rng.Perm
+	}
+	order := perm(len(pop.Individuals))
+	migrants := make([]*ga.Individual, min(n, len(order)))
+	for i := range migrants {
+		migrants[i] = pop.Individuals[order[i]]
+	}
+	return migrants
+}
+
+// TournamentMigration sends the winners of n tournaments among TournamentSize individuals of
+// an island drawn with replacement, trading off the convergence of ElitistMigration against
+// the diversity of RandomMigration. If Rng is nil, the global source of math/rand is used.
+type TournamentMigration struct {
+	TournamentSize int
+	Rng            *rand.Rand
+}
+
+// SelectMigrants returns the winners of n tournaments, which may repeat individuals. It
+// returns no migrants from an empty population.
+func (m TournamentMigration) SelectMigrants(pop *ga.Population, n int) []*ga.Individual {
+	if len(pop.Individuals) == 0 {
+		return nil
+	}
+	intn := rand.Intn
+	if m.Rng != nil {
+		intn = m.Rng.Intn
+	}
+	migrants := make([]*ga.Individual, n)
+	for i := range migrants {
+		winner := pop.Individuals[intn(len(pop.Individuals))]
+		for j := 1; j < m.TournamentSize; j++ {
+			if contender := pop.Individuals[intn(len(pop.Individuals))]; better(contender, winner, pop.Minimize) {
+				winner = contender
+			}
+		}
+		migrants[i] = winner
+	}
+	return migrants
+}
+
+// better reports whether individual a is strictly fitter than individual b.
+func better(a, b *ga.Individual, minimize bool) bool {
+	if minimize {
+		return a.Phenotype.Fitness < b.Phenotype.Fitness
+	}
+	return a.Phenotype.Fitness > b.Phenotype.Fitness
+}
@@ -0,0 +1,78 @@
+package island
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func newPopulation(fitness ...float64) *ga.Population {
+	individuals := make([]*ga.Individual, len(fitness))
+	for i, f := range fitness {
+		individuals[i] = &ga.Individual{Genotype: ga.MustNewGenotype(4), Phenotype: &ga.Phenotype{Fitness: f}}
+	}
+	return &ga.Population{Individuals: individuals}
+}
+
+func TestElitistMigration(t *testing.T) {
+	cases := []struct {
+		minimize bool
+		n        int
+		expected []float64
+	}{
+		{n: 2, expected: []float64{5, 4}},
+		{minimize: true, n: 2, expected: []float64{1, 2}},
+		{n: 10, expected: []float64{5, 4, 3, 2, 1}},
+	}
+
+	for _, tc := range cases {
+		pop := newPopulation(3, 1, 5, 2, 4)
+		pop.Minimize = tc.minimize
+
+		migrants := ElitistMigration{}.SelectMigrants(pop, tc.n)
+
+		if len(migrants) != len(tc.expected) {
+			t.Fatalf("Expected %d migrants, but got %d", len(tc.expected), len(migrants))
+		}
+		for i, migrant := range migrants {
+			if migrant.Phenotype.Fitness != tc.expected[i] {
+				t.Errorf("Expected migrant %d to have fitness %f, but got %f", i, tc.expected[i], migrant.Phenotype.Fitness)
+			}
+		}
+	}
+}
+
+func TestRandomAndTournamentMigration(t *testing.T) {
+	pop := newPopulation(3, 1, 5, 2, 4)
+	members := make(map[*ga.Individual]bool)
+	for _, ind := range pop.Individuals {
+		members[ind] = true
+	}
+
+	cases := []struct {
+		name     string
+		policy   MigrationPolicy
+		distinct bool
+	}{
+		{name: "random", policy: RandomMigration{Rng: rand.New(rand.NewSource(1))}, distinct: true},
+		{name: "tournament", policy: TournamentMigration{TournamentSize: 2, Rng: rand.New(rand.NewSource(1))}},
+	}
+
+	for _, tc := range cases {
+		migrants := tc.policy.SelectMigrants(pop, 3)
+		if len(migrants) != 3 {
+			t.Fatalf("%s: Expected %d migrants, but got %d", tc.name, 3, len(migrants))
+		}
+		seen := make(map[*ga.Individual]bool)
+		for i, migrant := range migrants {
+			if !members[migrant] {
+				t.Errorf("%s: Expected migrant %d to be from the population", tc.name, i)
+			}
+			if tc.distinct && seen[migrant] {
+				t.Errorf("%s: Expected distinct migrants, but migrant %d is repeated", tc.name, i)
+			}
+			seen[migrant] = true
+		}
+	}
+}
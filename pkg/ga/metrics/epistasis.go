@@ -0,0 +1,99 @@
+// Package metrics provides measures of problem difficulty for genetic algorithms,
+// computed by probing the fitness function around the individuals of a population.
+package metrics
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// EpistaticVariance estimates the epistasis of a binary-encoded problem as the variance of the
+// effect of each gene across genetic backgrounds.
+//
+// The effect of gene i in a genotype is the fitness with the gene set to one minus the fitness
+// with the gene set to zero, obtained by flipping the gene. Under an additive fitness function,
+// each gene's effect is the same in every genotype, so its variance is zero; interactions
+// between genes make the effect depend on the rest of the genotype. The result is the variance
+// of the effect of each gene across the sampled genotypes, averaged over the genes.
+//
+// Parameters:
+// - population: the individuals to sample genotypes from.
+// - evalFunc: a function to evaluate a Genotype and return its Phenotype.
+// - samples: the number of genotypes to sample, with replacement.
+//
+// Returns:
+// - The average variance of the gene effects, or 0 if the population is empty or samples is not positive.
+func EpistaticVariance(population []*ga.Individual, evalFunc func(*ga.Genotype) *ga.Phenotype, samples int) float64 {
+	if len(population) == 0 || samples <= 0 {
+		return 0
+	}
+
+	genomeLength := len(population[0].Genotype.Genome)
+	sums := make([]float64, genomeLength)
+	squares := make([]float64, genomeLength)
+	for s := 0; s < samples; s++ {
+		genotype := population[rand.Intn(len(population))].Genotype
+		fitness := evalFunc(genotype).Fitness
+		for i := 0; i < genomeLength; i++ {
+			effect := fitness - evalFunc(flipped(genotype, i)).Fitness
+			if genotype.Genome[i] == 0 {
+				effect = -effect
+			}
+			sums[i] += effect
+			squares[i] += effect * effect
+		}
+	}
+
+	variance := 0.0
+	for i := range sums {
+		mean := sums[i] / float64(samples)
+		variance += math.Max(squares[i]/float64(samples)-mean*mean, 0)
+	}
+	return variance / float64(genomeLength)
+}
+
+// AverageEpistasis measures the average pairwise epistasis of a binary-encoded problem.
+//
+// For each individual and each pair of genes i and j, the interaction between the genes is
+// f(x) - f(x with i flipped) - f(x with j flipped) + f(x with i and j flipped), which is zero
+// when the effects of the two genes add up. The result is the mean absolute interaction over
+// all individuals and gene pairs.
+//
+// Parameters:
+// - population: the individuals around which to measure the interactions.
+// - evalFunc: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - The mean absolute pairwise interaction, or 0 if there are no gene pairs.
+func AverageEpistasis(population []*ga.Individual, evalFunc func(*ga.Genotype) *ga.Phenotype) float64 {
+	total, pairs := 0.0, 0
+	for _, ind := range population {
+		genotype := ind.Genotype
+		fitness := evalFunc(genotype).Fitness
+
+		single := make([]float64, len(genotype.Genome))
+		for i := range single {
+			single[i] = evalFunc(flipped(genotype, i)).Fitness
+		}
+		for i := range genotype.Genome {
+			for j := i + 1; j < len(genotype.Genome); j++ {
+				both := evalFunc(flipped(flipped(genotype, i), j)).Fitness
+				total += math.Abs(fitness - single[i] - single[j] + both)
+				pairs++
+			}
+		}
+	}
+	if pairs == 0 {
+		return 0
+	}
+	return total / float64(pairs)
+}
+
+// flipped returns a copy of the binary genotype with the gene at the given position flipped.
+func flipped(genotype *ga.Genotype, position int) *ga.Genotype {
+	clone := genotype.Clone()
+	clone.Genome[position] = 1 - clone.Genome[position]
+	return clone
+}
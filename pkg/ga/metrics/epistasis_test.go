@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// oneMax returns the number of ones in the genome as the fitness.
+func oneMax(genotype *ga.Genotype) *ga.Phenotype {
+	fitness := 0.0
+	for _, gene := range genotype.Genome {
+		fitness += float64(gene)
+	}
+	return &ga.Phenotype{Fitness: fitness}
+}
+
+// pairProduct rewards adjacent pairs of ones, so that the effect of each gene depends on its neighbors.
+func pairProduct(genotype *ga.Genotype) *ga.Phenotype {
+	fitness := 0.0
+	for i := 0; i+1 < len(genotype.Genome); i += 2 {
+		fitness += float64(genotype.Genome[i] * genotype.Genome[i+1])
+	}
+	return &ga.Phenotype{Fitness: fitness}
+}
+
+func newPopulation(size, genomeLength int) []*ga.Individual {
+	population := make([]*ga.Individual, size)
+	for i := range population {
+		population[i] = &ga.Individual{Genotype: ga.MustVariableLengthBinaryGenotype(genomeLength, genomeLength)}
+	}
+	return population
+}
+
+func TestEpistaticVariance(t *testing.T) {
+	population := newPopulation(20, 10)
+
+	if got := EpistaticVariance(population, oneMax, 50); math.Abs(got) > 1e-9 {
+		t.Errorf("Expected near-zero epistatic variance for OneMax, but got %f", got)
+	}
+	if got := EpistaticVariance(population, pairProduct, 50); got <= 0 {
+		t.Errorf("Expected positive epistatic variance for an interacting fitness function, but got %f", got)
+	}
+	if got := EpistaticVariance(nil, oneMax, 50); got != 0 {
+		t.Errorf("Expected zero epistatic variance for an empty population, but got %f", got)
+	}
+}
+
+func TestAverageEpistasis(t *testing.T) {
+	population := newPopulation(5, 8)
+
+	cases := []struct {
+		name     string
+		evalFunc func(*ga.Genotype) *ga.Phenotype
+		expected float64
+	}{
+		{name: "oneMax", evalFunc: oneMax, expected: 0},
+		// Only the 4 adjacent pairs out of 28 gene pairs interact, each with magnitude 1.
+		{name: "pairProduct", evalFunc: pairProduct, expected: 4.0 / 28.0},
+	}
+
+	for _, tc := range cases {
+		if got := AverageEpistasis(population, tc.evalFunc); math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("%s: Expected average epistasis %f, but got %f", tc.name, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Package metrics provides measures of problem difficulty for genetic algorithms,
+// and the export of the progress of a GA as Prometheus metrics.
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics exports the progress of a GA as Prometheus metrics. It implements
+// ga.MetricsRecorder, so it can be set as the GA's Metrics to be updated after every generation.
+type PrometheusMetrics struct {
+	BestFitness    prometheus.Gauge
+	AverageFitness prometheus.Gauge
+	Diversity      prometheus.Gauge
+	Generation     prometheus.Gauge
+	Runtime        prometheus.Gauge
+	Evaluations    prometheus.Counter
+
+	mu              sync.Mutex
+	lastEvaluations int64
+	lastGeneration  int
+}
+
+// NewPrometheusMetrics creates a new, unregistered PrometheusMetrics.
+//
+// Returns:
+// - A pointer to the newly created PrometheusMetrics.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		BestFitness: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ga_best_fitness",
+			Help: "Best fitness in the population.",
+		}),
+		AverageFitness: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ga_avg_fitness",
+			Help: "Average fitness of the population.",
+		}),
+		Diversity: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ga_diversity",
+			Help: "Standard deviation of the fitness of the population.",
+		}),
+		Generation: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ga_generation",
+			Help: "Number of completed generations.",
+		}),
+		Runtime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ga_runtime_seconds",
+			Help: "Total time spent evolving the generations completed since initialization.",
+		}),
+		Evaluations: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ga_evaluations_total",
+			Help: "Total number of fitness evaluations.",
+		}),
+	}
+}
+
+// Register registers all metrics with the given registerer, such as prometheus.DefaultRegisterer.
+//
+// Parameters:
+// - registerer: the registerer to register the metrics with.
+//
+// Returns:
+// - An error if a metric could not be registered.
+func (m *PrometheusMetrics) Register(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{m.BestFitness, m.AverageFitness, m.Diversity, m.Generation, m.Runtime, m.Evaluations} {
+		if err := registerer.Register(collector); err != nil {
+			return fmt.Errorf("register GA metrics: %w", err)
+		}
+	}
+	return nil
+}
+
+// RecordGeneration updates the metrics with the state of the GA after a generation, adding
+// the duration of the generation to the runtime.
+//
+// Parameters:
+// - generations: the number of generations completed since the GA was initialized.
+// - stats: the statistics of the population.
+// - evaluations: the total number of fitness evaluations.
+// - duration: the time the generation took.
+func (m *PrometheusMetrics) RecordGeneration(generations int, stats *ga.Statistics, evaluations int64, duration time.Duration) {
+	m.BestFitness.Set(stats.BestFitness)
+	m.AverageFitness.Set(stats.AverageFitness)
+	m.Diversity.Set(stats.Diversity)
+	m.Generation.Set(float64(generations))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// The GA reports running totals, which restart from zero when it is reinitialized.
+	if generations <= m.lastGeneration {
+		m.Runtime.Set(0)
+	}
+	m.lastGeneration = generations
+	m.Runtime.Add(duration.Seconds())
+	if evaluations < m.lastEvaluations {
+		m.lastEvaluations = 0
+	}
+	m.Evaluations.Add(float64(evaluations - m.lastEvaluations))
+	m.lastEvaluations = evaluations
+}
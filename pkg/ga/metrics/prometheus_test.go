@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics()
+	registry := prometheus.NewRegistry()
+	if err := m.Register(registry); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	g := &ga.GA{
+		Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 3) },
+		Crossover:     ga.SinglePointCrossover,
+		Mutation:      ga.BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.01,
+		Generations:   15,
+		Metrics:       m,
+	}
+	g.Initialize(20, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(10, 10) }, oneMax)
+	g.Evolve(oneMax)
+
+	if got := testutil.ToFloat64(m.Generation); got != float64(g.Generations) {
+		t.Errorf("Expected ga_generation %d, but got %f", g.Generations, got)
+	}
+	if got := testutil.ToFloat64(m.Evaluations); got != float64(g.EvaluationsUsed) || got != float64(20*(g.Generations+1)) {
+		t.Errorf("Expected ga_evaluations_total %d, but got %f", g.EvaluationsUsed, got)
+	}
+	stats := g.History[len(g.History)-1]
+	if got := testutil.ToFloat64(m.BestFitness); got != stats.BestFitness {
+		t.Errorf("Expected ga_best_fitness %f, but got %f", stats.BestFitness, got)
+	}
+	if got := testutil.ToFloat64(m.Runtime); got <= 0 {
+		t.Errorf("Expected positive ga_runtime_seconds, but got %f", got)
+	}
+	if count := testutil.CollectAndCount(registry); count != 6 {
+		t.Errorf("Expected %d registered metrics, but got %d", 6, count)
+	}
+
+	if err := m.Register(registry); err == nil {
+		t.Errorf("Expected an error registering the metrics twice")
+	}
+}
+
+func TestPrometheusMetricsAcrossEvolveCalls(t *testing.T) {
+	m := NewPrometheusMetrics()
+	g := &ga.GA{
+		Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 3) },
+		Crossover:     ga.SinglePointCrossover,
+		Mutation:      ga.BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.01,
+		Generations:   1,
+		Metrics:       m,
+	}
+	g.Initialize(20, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(10, 10) }, oneMax)
+	for i := 0; i < 5; i++ {
+		g.Evolve(oneMax)
+	}
+
+	if got := testutil.ToFloat64(m.Generation); got != 5 {
+		t.Errorf("Expected ga_generation %d after %d calls to Evolve, but got %f", 5, 5, got)
+	}
+}
+
+func TestPrometheusMetricsRuntime(t *testing.T) {
+	m := NewPrometheusMetrics()
+	stats := &ga.Statistics{}
+
+	m.RecordGeneration(1, stats, 10, time.Second)
+	m.RecordGeneration(2, stats, 20, 2*time.Second)
+	if got := testutil.ToFloat64(m.Runtime); got != 3 {
+		t.Errorf("Expected ga_runtime_seconds %d, but got %f", 3, got)
+	}
+
+	// A reinitialized GA starts counting its generations from one again.
+	m.RecordGeneration(1, stats, 10, time.Second)
+	if got := testutil.ToFloat64(m.Runtime); got != 1 {
+		t.Errorf("Expected ga_runtime_seconds %d after reinitialization, but got %f", 1, got)
+	}
+}
@@ -2,7 +2,28 @@
 // including mutation operations for introducing genetic diversity in the population.
 package ga
 
-import "math/rand"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// ErrNegativeCreepStep is returned by CreepMutation when the creep step is negative.
+var ErrNegativeCreepStep = errors.New("creep step must not be negative")
+
+const (
+	// mutationRateParam is the StrategyParams key holding an individual's own mutation rate.
+	mutationRateParam = "mutationRate"
+
+	minSelfAdaptiveRate = 0.001
+	maxSelfAdaptiveRate = 0.5
+
+	// gaussianMutationScale is the standard deviation of GaussianMutation relative to a gene's range.
+	gaussianMutationScale = 0.1
+	// polynomialDistributionIndex is the distribution index of PolynomialMutation.
+	polynomialDistributionIndex = 20.0
+)
 
 // BitFlipMutation performs bit-flip mutation on the given population.
 //
@@ -27,7 +48,8 @@ func BitFlipMutation(population []*Individual, mutationRate float64) {
 // SwapMutation performs swap mutation on the given population.
 //
 // In swap mutation, two genes in the individual's genome are randomly selected
-// and swapped with a certain probability, known as the mutation rate.
+// and swapped with a certain probability, known as the mutation rate. Genotypes with
+// LargePermutationEncoding have the genes of their Int32Genome swapped.
 //
 // Parameters:
 // - population: a slice of pointers to Individual, representing the current population.
@@ -36,10 +58,358 @@ func BitFlipMutation(population []*Individual, mutationRate float64) {
 // This function modifies the input population in place.
 func SwapMutation(population []*Individual, mutationRate float64) {
 	for _, ind := range population {
+		if ind.Genotype.GenomeType == LargePermutationEncoding {
+			swapGenes(ind.Genotype.Int32Genome, mutationRate)
+		} else {
+			swapGenes(ind.Genotype.Genome, mutationRate)
+		}
+	}
+}
+
+// swapGenes swaps each gene with a random gene of the genome with probability mutationRate.
+func swapGenes[T any](genome []T, mutationRate float64) {
+	for i := range genome {
+		if rand.Float64() < mutationRate {
+			j := rand.Intn(len(genome))
+			genome[i], genome[j] = genome[j], genome[i]
+		}
+	}
+}
+
+// InsertionMutation performs insertion mutation on the given population of variable-length genomes.
+//
+// In insertion mutation, a random binary gene is appended to the individual's genome with
+// a certain probability, known as the mutation rate. Genomes that have already reached
+// their MaxLength are left unchanged.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each individual will be mutated.
+//
+// This function modifies the input population in place.
+func InsertionMutation(population []*Individual, mutationRate float64) {
+	for _, ind := range population {
+		if rand.Float64() < mutationRate {
+			genotype := ind.Genotype
+			if genotype.MaxLength > 0 && len(genotype.Genome) >= genotype.MaxLength {
+				continue
+			}
+			genotype.Genome = append(genotype.Genome, byte(rand.Intn(2)))
+		}
+	}
+}
+
+// PermutationInsertionMutation performs insertion mutation on the given population of
+// permutation-encoded genomes, including genotypes with LargePermutationEncoding.
+//
+// In permutation insertion mutation, a randomly selected gene is removed from the individual's
+// genome and reinserted at a different random position with a certain probability, known as
+// the mutation rate. Unlike InsertionMutation, which grows variable-length genomes and whose
+// name it therefore cannot take, the genome length is preserved, so every value of the
+// permutation remains present exactly once. Genomes shorter than two genes are left unchanged.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each individual will be mutated.
+//
+// This function modifies the input population in place.
+func PermutationInsertionMutation(population []*Individual, mutationRate float64) {
+	for _, ind := range population {
+		if ind.Genotype.Len() < 2 || rand.Float64() >= mutationRate {
+			continue
+		}
+		if ind.Genotype.GenomeType == LargePermutationEncoding {
+			insertGene(ind.Genotype.Int32Genome)
+		} else {
+			insertGene(ind.Genotype.Genome)
+		}
+	}
+}
+
+// insertGene moves a random gene of the genome, which must hold at least two genes, to a
+// different random position.
+func insertGene[T any](genome []T) {
+	from := rand.Intn(len(genome))
+	to := rand.Intn(len(genome) - 1)
+	if to >= from {
+		to++
+	}
+	gene := genome[from]
+	if from < to {
+		copy(genome[from:to], genome[from+1:to+1])
+	} else {
+		copy(genome[to+1:from+1], genome[to:from])
+	}
+	genome[to] = gene
+}
+
+// DisplacementMutation performs displacement mutation on the given population of
+// permutation-encoded genomes, including genotypes with LargePermutationEncoding.
+//
+// In displacement mutation, a randomly selected sub-sequence of genes is removed from the
+// individual's genome and reinserted at a different random position with a certain probability,
+// known as the mutation rate. Every value of the permutation remains present exactly once.
+// Genomes shorter than two genes are left unchanged.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each individual will be mutated.
+//
+// This function modifies the input population in place.
+func DisplacementMutation(population []*Individual, mutationRate float64) {
+	for _, ind := range population {
+		if ind.Genotype.Len() < 2 || rand.Float64() >= mutationRate {
+			continue
+		}
+		if ind.Genotype.GenomeType == LargePermutationEncoding {
+			displaceSegment(ind.Genotype.Int32Genome)
+		} else {
+			displaceSegment(ind.Genotype.Genome)
+		}
+	}
+}
+
+// displaceSegment moves a random sub-sequence of the genome, which must hold at least two
+// genes, to a different random position.
+func displaceSegment[T any](genome []T) {
+	length := 1 + rand.Intn(len(genome)-1)
+	start := rand.Intn(len(genome) - length + 1)
+	segment := append([]T(nil), genome[start:start+length]...)
+
+	rest := make([]T, 0, len(genome)-length)
+	rest = append(rest, genome[:start]...)
+	rest = append(rest, genome[start+length:]...)
+
+	offset := rand.Intn(len(rest))
+	if offset >= start {
+		offset++
+	}
+	n := copy(genome, rest[:offset])
+	n += copy(genome[n:], segment)
+	copy(genome[n:], rest[offset:])
+}
+
+// DeletionMutation performs deletion mutation on the given population of variable-length genomes.
+//
+// In deletion mutation, a randomly selected gene is removed from the individual's genome
+// with a certain probability, known as the mutation rate. Genomes of length one are left
+// unchanged so that no genome becomes empty.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each individual will be mutated.
+//
+// This function modifies the input population in place.
+func DeletionMutation(population []*Individual, mutationRate float64) {
+	for _, ind := range population {
+		if rand.Float64() < mutationRate {
+			genotype := ind.Genotype
+			if len(genotype.Genome) <= 1 {
+				continue
+			}
+			i := rand.Intn(len(genotype.Genome))
+			genotype.Genome = append(genotype.Genome[:i], genotype.Genome[i+1:]...)
+		}
+	}
+}
+
+// SelfAdaptiveMutation performs bit-flip mutation using a mutation rate carried by each
+// individual.
+//
+// Each individual's rate is read from StrategyParams["mutationRate"], falling back to
+// baseMutationRate when the individual has no rate of its own. Offspring of crossover inherit
+// the mean rate of their parents. The individual's fitness before mutation, or the mean
+// fitness of its parents for an unevaluated offspring, is recorded so that, once the
+// offspring has been evaluated, its rate can be adapted following the 1/5 success rule: the
+// rate is multiplied by 1.2 if the mutation improved the fitness and by 0.8 otherwise, and is
+// clamped to [0.001, 0.5]. The GA applies this update automatically after each evaluation.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - baseMutationRate: the mutation rate used for individuals without their own rate.
+//
+// This function modifies the input population in place.
+func SelfAdaptiveMutation(population []*Individual, baseMutationRate float64) {
+	for _, ind := range population {
+		if ind.StrategyParams == nil {
+			ind.StrategyParams = make(map[string]float64)
+		}
+		rate, ok := ind.StrategyParams[mutationRateParam]
+		if !ok {
+			rate = baseMutationRate
+			ind.StrategyParams[mutationRateParam] = rate
+		}
+		if ind.Phenotype != nil {
+			ind.parentFitness = ind.Phenotype.Fitness
+			ind.hasParentFitness = true
+		}
+
 		for i := range ind.Genotype.Genome {
+			if rand.Float64() < rate {
+				ind.Genotype.Genome[i] = 1 - ind.Genotype.Genome[i]
+			}
+		}
+	}
+}
+
+// updateSelfAdaptiveRates adapts the mutation rate of every individual mutated by
+// SelfAdaptiveMutation by comparing its fitness with the fitness recorded before mutation.
+//
+// Parameters:
+// - population: a slice of pointers to Individual whose phenotypes have been evaluated.
+// - minimize: whether lower fitness is better.
+func updateSelfAdaptiveRates(population []*Individual, minimize bool) {
+	for _, ind := range population {
+		if !ind.hasParentFitness || ind.Phenotype == nil {
+			continue
+		}
+		ind.hasParentFitness = false
+
+		rate, ok := ind.StrategyParams[mutationRateParam]
+		if !ok {
+			continue
+		}
+		if fitter(ind.Phenotype.Fitness, ind.parentFitness, minimize) {
+			rate *= 1.2
+		} else {
+			rate *= 0.8
+		}
+		ind.StrategyParams[mutationRateParam] = math.Min(math.Max(rate, minSelfAdaptiveRate), maxSelfAdaptiveRate)
+	}
+}
+
+// GaussianMutation performs Gaussian mutation on a population of real-valued genotypes.
+//
+// In Gaussian mutation, each gene is independently perturbed with a certain probability,
+// known as the mutation rate, by adding normally distributed noise with a standard deviation
+// of one tenth of the gene's range. Mutated genes are clamped to their bounds. Both
+// RealEncoding and Float64Encoding genotypes are supported.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each gene will be mutated.
+//
+// This function modifies the input population in place.
+func GaussianMutation(population []*Individual, mutationRate float64) {
+	for _, ind := range population {
+		genotype := ind.Genotype
+		for i := 0; i < genotype.Len(); i++ {
+			if rand.Float64() < mutationRate {
+				sigma := gaussianMutationScale * (genotype.MaxValues[i] - genotype.MinValues[i])
+				genotype.SetRealValue(i, genotype.GetRealValue(i)+rand.NormFloat64()*sigma)
+			}
+		}
+	}
+}
+
+// BoundedGaussianMutation performs Gaussian mutation with a fixed standard deviation on a
+// population of real-valued genotypes.
+//
+// Each gene is independently perturbed with a certain probability, known as the mutation rate,
+// by Gaussian noise with standard deviation sigma in the units of the gene, and the result is
+// clamped to [MinValues[i], MaxValues[i]], however large sigma is. Both RealEncoding and
+// Float64Encoding genotypes are supported.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each gene will be mutated.
+// - sigma: the standard deviation of the Gaussian noise.
+//
+// This function modifies the input population in place.
+func BoundedGaussianMutation(population []*Individual, mutationRate float64, sigma float64) {
+	for _, ind := range population {
+		genotype := ind.Genotype
+		for i := 0; i < genotype.Len(); i++ {
+			if rand.Float64() < mutationRate {
+				value := genotype.GetRealValue(i) + rand.NormFloat64()*sigma
+				genotype.SetRealValue(i, math.Min(math.Max(value, genotype.MinValues[i]), genotype.MaxValues[i]))
+			}
+		}
+	}
+}
+
+// PolynomialMutation performs polynomial mutation on a population of real-valued genotypes.
+//
+// In polynomial mutation, each gene is independently perturbed with a certain probability,
+// known as the mutation rate, by a polynomially distributed amount scaled to the gene's range,
+// so that small perturbations are much more likely than large ones. The distribution index
+// is 20. Both RealEncoding and Float64Encoding genotypes are supported.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each gene will be mutated.
+//
+// This function modifies the input population in place.
+func PolynomialMutation(population []*Individual, mutationRate float64) {
+	for _, ind := range population {
+		genotype := ind.Genotype
+		for i := 0; i < genotype.Len(); i++ {
+			if rand.Float64() < mutationRate {
+				var delta float64
+				if u := rand.Float64(); u < 0.5 {
+					delta = math.Pow(2*u, 1/(polynomialDistributionIndex+1)) - 1
+				} else {
+					delta = 1 - math.Pow(2*(1-u), 1/(polynomialDistributionIndex+1))
+				}
+				span := genotype.MaxValues[i] - genotype.MinValues[i]
+				genotype.SetRealValue(i, genotype.GetRealValue(i)+delta*span)
+			}
+		}
+	}
+}
+
+// CreepMutation performs creep mutation on a population of integer-encoded genotypes.
+//
+// In creep mutation, each gene is independently changed with a certain probability, known as
+// the mutation rate, by adding a uniformly random integer in [-creepStep, creepStep]. The
+// result is clamped to [MinValues[i], MaxValues[i]], so that small changes keep the gene near
+// its value.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each gene will be mutated.
+// - creepStep: the largest change to a gene; it must not be negative.
+//
+// Returns:
+// - An error wrapping ErrNegativeCreepStep if creepStep is negative, in which case the
+// population is left unchanged.
+//
+// This function modifies the input population in place.
+func CreepMutation(population []*Individual, mutationRate float64, creepStep int) error {
+	if creepStep < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeCreepStep, creepStep)
+	}
+	for _, ind := range population {
+		genotype := ind.Genotype
+		for i := range genotype.Int32Genome {
+			if rand.Float64() < mutationRate {
+				lower, upper := genotype.integerBounds(i)
+				step := int32(rand.Intn(2*creepStep+1) - creepStep)
+				genotype.Int32Genome[i] = min(max(genotype.Int32Genome[i]+step, lower), upper)
+			}
+		}
+	}
+	return nil
+}
+
+// RandomResettingMutation performs random resetting mutation on a population of
+// integer-encoded genotypes.
+//
+// In random resetting mutation, each gene is independently replaced with a certain
+// probability, known as the mutation rate, by a uniformly random integer in
+// [MinValues[i], MaxValues[i]].
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - mutationRate: the probability with which each gene will be mutated.
+//
+// This function modifies the input population in place.
+func RandomResettingMutation(population []*Individual, mutationRate float64) {
+	for _, ind := range population {
+		genotype := ind.Genotype
+		for i := range genotype.Int32Genome {
 			if rand.Float64() < mutationRate {
-				j := rand.Intn(len(ind.Genotype.Genome))
-				ind.Genotype.Genome[i], ind.Genotype.Genome[j] = ind.Genotype.Genome[j], ind.Genotype.Genome[i]
+				genotype.Int32Genome[i] = genotype.randomInteger(i)
 			}
 		}
 	}
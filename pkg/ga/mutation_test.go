@@ -1,8 +1,11 @@
 package ga
 
 import (
+	"errors"
+	"math/rand"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestBitFlipMutation(t *testing.T) {
@@ -106,3 +109,297 @@ func TestSwapMutation(t *testing.T) {
 		}
 	}
 }
+
+func TestInsertionAndDeletionMutation(t *testing.T) {
+	const maxLength = 6
+
+	cases := []struct {
+		mutation func([]*Individual, float64)
+	}{
+		{mutation: InsertionMutation},
+		{mutation: DeletionMutation},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 10)
+		for i := range population {
+			population[i] = &Individual{Genotype: MustVariableLengthBinaryGenotype(1+i%maxLength, maxLength)}
+		}
+
+		for gen := 0; gen < 20; gen++ {
+			tc.mutation(population, 1.0)
+
+			for i, ind := range population {
+				length := len(ind.Genotype.Genome)
+				if length < 1 || length > maxLength {
+					t.Fatalf("Expected genome length in [1, %d], but got %d for individual %d", maxLength, length, i)
+				}
+			}
+		}
+	}
+}
+
+func TestSelfAdaptiveMutation(t *testing.T) {
+	const (
+		genomeLength = 64
+		generations  = 50
+		runs         = 40
+		target       = 60
+	)
+
+	// The operators draw from the global source, so it is seeded for a reproducible
+	// comparison and reseeded afterwards so that later tests stay random.
+	rand.Seed(1)
+	defer rand.Seed(time.Now().UnixNano())
+
+	// generationsToTarget returns the number of generations needed to find an individual
+	// with the target fitness, averaged over the runs. Runs that miss the target count as
+	// taking all the generations.
+	generationsToTarget := func(mutation func([]*Individual, float64)) float64 {
+		total := 0
+		for run := 0; run < runs; run++ {
+			reached := generations
+			ga := &GA{
+				Selection:     func(population []*Individual) []*Individual { return TournamentSelection(population, 3) },
+				Crossover:     SinglePointCrossover,
+				Mutation:      mutation,
+				CrossoverRate: 0.7,
+				MutationRate:  0.05,
+				Generations:   generations,
+				OnGeneration: func(generation int, population []*Individual) {
+					if reached == generations && findBestIndividual(population).Phenotype.Fitness >= target {
+						reached = generation + 1
+					}
+				},
+			}
+			if err := ga.Initialize(50, func() *Genotype { return MustVariableLengthBinaryGenotype(genomeLength, genomeLength) }, oneMax); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+			if _, _, err := ga.Evolve(oneMax); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+
+			for _, ind := range ga.Population {
+				for key, value := range ind.StrategyParams {
+					if key != mutationRateParam {
+						t.Fatalf("Expected only %q in StrategyParams, but got %q", mutationRateParam, key)
+					}
+					if value < minSelfAdaptiveRate || value > maxSelfAdaptiveRate {
+						t.Fatalf("Expected mutation rate in [%f, %f], but got %f", minSelfAdaptiveRate, maxSelfAdaptiveRate, value)
+					}
+				}
+			}
+			total += reached
+		}
+		return float64(total) / runs
+	}
+
+	selfAdaptive := generationsToTarget(SelfAdaptiveMutation)
+	fixed := generationsToTarget(BitFlipMutation)
+	t.Logf("Average generations to reach fitness %d: self-adaptive %.1f, fixed-rate %.1f", target, selfAdaptive, fixed)
+
+	// The fixed rate of 0.05 flips about three bits of every genome and stalls short of the
+	// optimum, while the self-adaptive rates shrink and keep improving.
+	if selfAdaptive >= fixed {
+		t.Errorf("Expected self-adaptive mutation to converge faster than fixed-rate mutation, but got %.1f versus %.1f generations", selfAdaptive, fixed)
+	}
+}
+
+func TestRealValuedMutation(t *testing.T) {
+	cases := []struct {
+		mutation func([]*Individual, float64)
+		genotype func() *Genotype
+	}{
+		{mutation: GaussianMutation, genotype: func() *Genotype { return MustNewFloat64Genotype(3, []float64{0, -1, 5}, []float64{1, 1, 6}) }},
+		{mutation: GaussianMutation, genotype: func() *Genotype { return MustNewRealGenotype(3, []float64{0, -1, 5}, []float64{1, 1, 6}) }},
+		{mutation: PolynomialMutation, genotype: func() *Genotype { return MustNewFloat64Genotype(3, []float64{0, -1, 5}, []float64{1, 1, 6}) }},
+		{mutation: PolynomialMutation, genotype: func() *Genotype { return MustNewRealGenotype(3, []float64{0, -1, 5}, []float64{1, 1, 6}) }},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 10)
+		original := make([]*Genotype, len(population))
+		for i := range population {
+			population[i] = &Individual{Genotype: tc.genotype()}
+			original[i] = population[i].Genotype.Clone()
+		}
+
+		tc.mutation(population, 1.0)
+
+		changed := false
+		for i, ind := range population {
+			for j := 0; j < ind.Genotype.Len(); j++ {
+				value := ind.Genotype.GetRealValue(j)
+				if value < ind.Genotype.MinValues[j] || value > ind.Genotype.MaxValues[j] {
+					t.Errorf("Expected gene %d of individual %d within bounds, but got %f", j, i, value)
+				}
+				if value != original[i].GetRealValue(j) {
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			t.Errorf("Expected mutation to change at least one gene")
+		}
+	}
+}
+
+func TestBoundedGaussianMutation(t *testing.T) {
+	cases := []struct {
+		name     string
+		genotype func() *Genotype
+	}{
+		{name: "real", genotype: func() *Genotype { return MustNewRealGenotype(1, []float64{0}, []float64{1}) }},
+		{name: "float64", genotype: func() *Genotype { return MustNewFloat64Genotype(1, []float64{0}, []float64{1}) }},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 100)
+		for i := range population {
+			population[i] = &Individual{Genotype: tc.genotype()}
+		}
+
+		BoundedGaussianMutation(population, 1.0, 100.0)
+
+		for i, ind := range population {
+			if value := ind.Genotype.GetRealValue(0); value < 0 || value > 1 {
+				t.Errorf("%s: Expected the gene of individual %d within [0, 1], but got %f", tc.name, i, value)
+			}
+		}
+	}
+}
+
+func TestIntegerMutation(t *testing.T) {
+	minValues, maxValues := []float64{0, -5, 10, -2.5}, []float64{3, 5, 10, 2.5}
+	cases := []struct {
+		name     string
+		mutation func([]*Individual, float64)
+	}{
+		{name: "CreepMutation", mutation: func(population []*Individual, rate float64) {
+			if err := CreepMutation(population, rate, 2); err != nil {
+				t.Fatalf("Expected no error, but got %v", err)
+			}
+		}},
+		{name: "RandomResettingMutation", mutation: RandomResettingMutation},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 20)
+		original := make([]*Genotype, len(population))
+		for i := range population {
+			population[i] = &Individual{Genotype: MustNewIntegerGenotype(len(minValues), minValues, maxValues)}
+			original[i] = population[i].Genotype.Clone()
+		}
+
+		changed := false
+		for round := 0; round < 10; round++ {
+			tc.mutation(population, 1.0)
+			for i, ind := range population {
+				for j, gene := range ind.Genotype.Int32Genome {
+					if float64(gene) < minValues[j] || float64(gene) > maxValues[j] {
+						t.Errorf("%s: Expected gene %d of individual %d within [%g, %g], but got %d", tc.name, j, i, minValues[j], maxValues[j], gene)
+					}
+					if gene != original[i].Int32Genome[j] {
+						changed = true
+					}
+				}
+			}
+		}
+		if !changed {
+			t.Errorf("%s: Expected mutation to change at least one gene", tc.name)
+		}
+	}
+}
+
+func TestCreepMutationNegativeStep(t *testing.T) {
+	genotype := MustNewIntegerGenotype(2, []float64{0, 0}, []float64{10, 10})
+	original := genotype.Clone()
+
+	if err := CreepMutation([]*Individual{{Genotype: genotype}}, 1.0, -1); !errors.Is(err, ErrNegativeCreepStep) {
+		t.Errorf("Expected ErrNegativeCreepStep, but got %v", err)
+	}
+	if !reflect.DeepEqual(genotype.Int32Genome, original.Int32Genome) {
+		t.Errorf("Expected the genes %v to be left unchanged, but got %v", original.Int32Genome, genotype.Int32Genome)
+	}
+}
+
+func TestPermutationMutations(t *testing.T) {
+	cases := []struct {
+		name     string
+		mutation func([]*Individual, float64)
+	}{
+		{name: "PermutationInsertionMutation", mutation: PermutationInsertionMutation},
+		{name: "DisplacementMutation", mutation: DisplacementMutation},
+	}
+
+	for _, tc := range cases {
+		for _, genomeLength := range []int{2, 3, 10} {
+			population := make([]*Individual, 50)
+			for i := range population {
+				genome := make([]byte, genomeLength)
+				for j, k := range rand.Perm(genomeLength) {
+					genome[j] = byte(k)
+				}
+				population[i] = &Individual{Genotype: &Genotype{Genome: genome}}
+			}
+			original := make([][]byte, len(population))
+			for i, ind := range population {
+				original[i] = append([]byte(nil), ind.Genotype.Genome...)
+			}
+
+			tc.mutation(population, 1.0)
+
+			for i, ind := range population {
+				seen := make(map[byte]bool, genomeLength)
+				for _, gene := range ind.Genotype.Genome {
+					seen[gene] = true
+				}
+				if len(ind.Genotype.Genome) != genomeLength || len(seen) != genomeLength {
+					t.Errorf("%s: Expected a permutation of %d values, but got %v", tc.name, genomeLength, ind.Genotype.Genome)
+				}
+				if reflect.DeepEqual(ind.Genotype.Genome, original[i]) {
+					t.Errorf("%s: Expected at least one position to change, but got %v", tc.name, ind.Genotype.Genome)
+				}
+			}
+		}
+	}
+}
+
+func TestPermutationMutationsLargePermutation(t *testing.T) {
+	const genomeLength = 300
+	cases := []struct {
+		name     string
+		mutation func([]*Individual, float64)
+	}{
+		{name: "PermutationInsertionMutation", mutation: PermutationInsertionMutation},
+		{name: "DisplacementMutation", mutation: DisplacementMutation},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, 20)
+		original := make([][]int32, len(population))
+		for i := range population {
+			genome := make([]int32, genomeLength)
+			for j, k := range rand.Perm(genomeLength) {
+				genome[j] = int32(k)
+			}
+			population[i] = &Individual{Genotype: &Genotype{Int32Genome: genome, GenomeType: LargePermutationEncoding}}
+			original[i] = append([]int32(nil), genome...)
+		}
+
+		tc.mutation(population, 1.0)
+
+		for i, ind := range population {
+			seen := make(map[int32]bool, genomeLength)
+			for _, gene := range ind.Genotype.Int32Genome {
+				seen[gene] = true
+			}
+			if len(ind.Genotype.Int32Genome) != genomeLength || len(seen) != genomeLength {
+				t.Errorf("%s: Expected a permutation of %d values in individual %d", tc.name, genomeLength, i)
+			}
+			if reflect.DeepEqual(ind.Genotype.Int32Genome, original[i]) {
+				t.Errorf("%s: Expected at least one position of individual %d to change", tc.name, i)
+			}
+		}
+	}
+}
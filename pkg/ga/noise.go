@@ -0,0 +1,96 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the repeated evaluation of noisy fitness functions.
+package ga
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// StdFeature is the feature in which NoisyEval records the standard deviation of the
+// fitness over the repeated evaluations.
+const StdFeature = "std"
+
+// NoisyEval wraps a stochastic fitness function so that each genotype is evaluated k times
+// and given the mean of the results, which reduces the noise in its fitness. The standard
+// deviation of the fitness over the k evaluations is recorded as the StdFeature float64
+// feature of the phenotype. Objectives, if the evaluations return any, are averaged as well;
+// other features of the evaluations are not kept.
+//
+// If rng is set, evalFunc is expected to draw its noise from it, so that a run with a seeded
+// rng is reproducible. Since rng is not safe for concurrent use, the wrapped function then
+// performs the k evaluations of one genotype at a time, which makes it safe for concurrent
+// use; otherwise it is safe for concurrent use if evalFunc is.
+//
+// Parameters:
+// - evalFunc: the fitness function to evaluate repeatedly.
+// - k: the number of evaluations per genotype; values below 1 are treated as 1.
+// - rng: the source of randomness evalFunc draws from, or nil if it draws from none shared.
+//
+// Returns:
+// - The wrapped fitness function.
+func NoisyEval(evalFunc func(*Genotype) *Phenotype, k int, rng *rand.Rand) func(*Genotype) *Phenotype {
+	k = max(k, 1)
+	var mu sync.Mutex
+	return func(genotype *Genotype) *Phenotype {
+		if rng != nil {
+			mu.Lock()
+			defer mu.Unlock()
+		}
+		phenotypes := make([]*Phenotype, k)
+		for i := range phenotypes {
+			phenotypes[i] = evalFunc(genotype)
+		}
+		return meanPhenotype(phenotypes)
+	}
+}
+
+// meanPhenotype averages the phenotypes of repeated evaluations of a genotype, recording the
+// standard deviation of their fitness as the StdFeature feature of the result.
+//
+// Parameters:
+// - phenotypes: the phenotypes of the evaluations; there must be at least one.
+//
+// Returns:
+// - A phenotype with the mean fitness and objectives of the evaluations.
+func meanPhenotype(phenotypes []*Phenotype) *Phenotype {
+	k := len(phenotypes)
+	var objectives []float64
+	if phenotypes[0].Objectives != nil {
+		objectives = make([]float64, len(phenotypes[0].Objectives))
+	}
+	mean := 0.0
+	for _, phenotype := range phenotypes {
+		mean += phenotype.Fitness / float64(k)
+		for m := range objectives {
+			objectives[m] += phenotype.Objectives[m] / float64(k)
+		}
+	}
+	variance := 0.0
+	if k > 1 {
+		for _, phenotype := range phenotypes {
+			variance += (phenotype.Fitness - mean) * (phenotype.Fitness - mean)
+		}
+		variance /= float64(k - 1)
+	}
+
+	phenotype := &Phenotype{Fitness: mean, Objectives: objectives}
+	phenotype.SetFloat(StdFeature, math.Sqrt(variance))
+	return phenotype
+}
+
+// withRepeats wraps the fitness function with NoisyEval if EvalRepeats is greater than 1. The
+// GA holds no source of randomness for the fitness function, so none is passed to NoisyEval.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+//
+// Returns:
+// - The fitness function to use for evaluation.
+func (ga *GA) withRepeats(evaluatePhenotype func(*Genotype) *Phenotype) func(*Genotype) *Phenotype {
+	if ga.EvalRepeats > 1 {
+		return NoisyEval(evaluatePhenotype, ga.EvalRepeats, nil)
+	}
+	return evaluatePhenotype
+}
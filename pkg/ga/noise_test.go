@@ -0,0 +1,110 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNoisyEval(t *testing.T) {
+	const trueMean, sigma = 5.0, 10.0
+	rng := rand.New(rand.NewSource(1))
+	noisy := func(*Genotype) *Phenotype {
+		return &Phenotype{Fitness: trueMean + rng.NormFloat64()*sigma}
+	}
+
+	cases := []struct {
+		k         int
+		tolerance float64
+	}{
+		{k: 1, tolerance: 3 * sigma},
+		{k: 30, tolerance: 3 * sigma / math.Sqrt(30)},
+		{k: 100, tolerance: 3 * sigma / math.Sqrt(100)},
+	}
+
+	for _, tc := range cases {
+		phenotype := NoisyEval(noisy, tc.k, rng)(MustNewGenotype(4))
+
+		if math.Abs(phenotype.Fitness-trueMean) > tc.tolerance {
+			t.Errorf("Expected fitness within %f of %f with k=%d, but got %f", tc.tolerance, trueMean, tc.k, phenotype.Fitness)
+		}
+		std, ok := phenotype.GetFloat(StdFeature)
+		if !ok {
+			t.Fatalf("Expected the %q feature to be set with k=%d", StdFeature, tc.k)
+		}
+		if tc.k == 1 && std != 0 {
+			t.Errorf("Expected a standard deviation of 0 with k=1, but got %f", std)
+		}
+		if tc.k > 1 && math.Abs(std-sigma) > sigma/2 {
+			t.Errorf("Expected a standard deviation near %f with k=%d, but got %f", sigma, tc.k, std)
+		}
+	}
+}
+
+func TestNoisyEvalReproducible(t *testing.T) {
+	run := func(seed int64) []float64 {
+		rng := rand.New(rand.NewSource(seed))
+		evaluate := NoisyEval(func(*Genotype) *Phenotype {
+			return &Phenotype{Fitness: rng.NormFloat64()}
+		}, 5, rng)
+		fitness := make([]float64, 10)
+		for i := range fitness {
+			fitness[i] = evaluate(MustNewGenotype(4)).Fitness
+		}
+		return fitness
+	}
+
+	first, second := run(7), run(7)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected evaluation %d to be reproduced with the same seed, but got %f and %f", i, first[i], second[i])
+		}
+	}
+}
+
+func TestNoisyEvalConcurrent(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	evaluate := NoisyEval(func(*Genotype) *Phenotype {
+		return &Phenotype{Fitness: rng.Float64()}
+	}, 10, rng)
+	ga := &GA{NumParallelEvals: 4}
+	ga.Population = make([]*Individual, 20)
+	for i := range ga.Population {
+		ga.Population[i] = &Individual{Genotype: MustNewGenotype(4)}
+	}
+
+	ga.evaluatePopulation(evaluate)
+
+	for i, ind := range ga.Population {
+		if ind.Phenotype.Fitness < 0 || ind.Phenotype.Fitness >= 1 {
+			t.Errorf("Expected individual %d to have a mean fitness in [0, 1), but got %f", i, ind.Phenotype.Fitness)
+		}
+	}
+}
+
+func TestEvalRepeats(t *testing.T) {
+	const populationSize, repeats = 10, 3
+	calls := 0
+	evaluate := func(genotype *Genotype) *Phenotype {
+		calls++
+		return oneMax(genotype)
+	}
+	ga := &GA{
+		Selection:   func(population []*Individual) []*Individual { return population },
+		Crossover:   func(population []*Individual, _ float64) []*Individual { return population },
+		Mutation:    func([]*Individual, float64) {},
+		Generations: 1,
+		EvalRepeats: repeats,
+	}
+	ga.Initialize(populationSize, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, evaluate)
+	ga.Evolve(evaluate)
+
+	if expected := 2 * populationSize * repeats; calls != expected {
+		t.Errorf("Expected %d calls to the fitness function, but got %d", expected, calls)
+	}
+	for _, ind := range ga.Population {
+		if std, ok := ind.Phenotype.GetFloat(StdFeature); !ok || std != 0 {
+			t.Errorf("Expected a standard deviation of 0 for a deterministic fitness function, but got %f", std)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package novelty provides novelty search for genetic algorithms, replacing the raw
+// fitness of an individual with a measure of how novel its behavior is compared to
+// the behaviors seen so far.
+package novelty
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// NoveltyArchive stores the behaviors that were novel enough to be archived.
+// It is safe for concurrent use.
+//
+// A behavior is added to the archive when its distance to the nearest archived
+// behavior exceeds Threshold.
+type NoveltyArchive struct {
+	mu        sync.Mutex
+	behaviors [][]float64
+	Threshold float64
+}
+
+// NewNoveltyArchive creates a new, empty NoveltyArchive.
+//
+// Parameters:
+// - threshold: the nearest-neighbor distance a behavior must exceed to be archived.
+//
+// Returns:
+// - A pointer to the newly created NoveltyArchive.
+func NewNoveltyArchive(threshold float64) *NoveltyArchive {
+	return &NoveltyArchive{Threshold: threshold}
+}
+
+// Size returns the number of behaviors in the archive.
+func (a *NoveltyArchive) Size() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.behaviors)
+}
+
+// Behaviors returns a copy of the archived behaviors.
+func (a *NoveltyArchive) Behaviors() [][]float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([][]float64(nil), a.behaviors...)
+}
+
+// Evaluate computes the novelty of a behavior against the archive, and archives the
+// behavior if its nearest archived neighbor is farther away than Threshold.
+//
+// Parameters:
+// - behavior: the behavior to evaluate.
+// - k: the number of nearest archived behaviors to average over.
+//
+// Returns:
+// - The mean distance from the behavior to its k nearest archived behaviors.
+func (a *NoveltyArchive) Evaluate(behavior []float64, k int) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	distances := sortedDistances(behavior, a.behaviors)
+	if len(distances) == 0 || distances[0] > a.Threshold {
+		a.behaviors = append(a.behaviors, behavior)
+	}
+	return meanOfNearest(distances, k)
+}
+
+// NoveltySearch evaluates individuals by the novelty of their behavior, as characterized
+// by BehaviorCharacterize, against the behaviors stored in Archive.
+type NoveltySearch struct {
+	BehaviorCharacterize func(*ga.Individual) []float64
+	Archive              *NoveltyArchive
+	K                    int
+}
+
+// NoveltyFitness returns the novelty of an individual with respect to a set of archived
+// individuals, measured as the mean Euclidean distance between its behavior and the
+// behaviors of its k nearest archived individuals.
+//
+// Parameters:
+// - ind: the individual whose novelty is measured.
+// - archive: the individuals to compare against.
+// - k: the number of nearest individuals to average over.
+//
+// Returns:
+// - The novelty of the individual, or zero if the archive is empty.
+func (ns *NoveltySearch) NoveltyFitness(ind *ga.Individual, archive []*ga.Individual, k int) float64 {
+	behaviors := make([][]float64, len(archive))
+	for i, archived := range archive {
+		behaviors[i] = ns.BehaviorCharacterize(archived)
+	}
+	return meanOfNearest(sortedDistances(ns.BehaviorCharacterize(ind), behaviors), k)
+}
+
+// EvalFunc returns a fitness function that evaluates novelty against the search's archive.
+//
+// Returns:
+// - A function to evaluate a Genotype and return its Phenotype.
+func (ns *NoveltySearch) EvalFunc() func(*ga.Genotype) *ga.Phenotype {
+	return NoveltyEvalFunc(ns.BehaviorCharacterize, ns.Archive, ns.K)
+}
+
+// NoveltyEvalFunc adapts a behavior characterization into a fitness function whose
+// fitness is the novelty of the genotype's behavior against the archive. Novel behaviors
+// are added to the archive as they are evaluated.
+//
+// Parameters:
+// - characterize: a function describing the behavior of an individual.
+// - archive: the archive of novel behaviors.
+// - k: the number of nearest archived behaviors to average over.
+//
+// Returns:
+// - A function to evaluate a Genotype and return its Phenotype.
+func NoveltyEvalFunc(characterize func(*ga.Individual) []float64, archive *NoveltyArchive, k int) func(*ga.Genotype) *ga.Phenotype {
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		behavior := characterize(&ga.Individual{Genotype: genotype})
+		return &ga.Phenotype{Fitness: archive.Evaluate(behavior, k)}
+	}
+}
+
+// EuclideanDistance returns the Euclidean distance between two behaviors of equal length.
+func EuclideanDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// sortedDistances returns the distances from behavior to each of the others, in ascending order.
+func sortedDistances(behavior []float64, others [][]float64) []float64 {
+	distances := make([]float64, len(others))
+	for i, other := range others {
+		distances[i] = EuclideanDistance(behavior, other)
+	}
+	sort.Float64s(distances)
+	return distances
+}
+
+// meanOfNearest returns the mean of the first k sorted distances, or zero if there are none.
+func meanOfNearest(distances []float64, k int) float64 {
+	if k > len(distances) {
+		k = len(distances)
+	}
+	if k <= 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, d := range distances[:k] {
+		sum += d
+	}
+	return sum / float64(k)
+}
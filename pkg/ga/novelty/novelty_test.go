@@ -0,0 +1,87 @@
+package novelty
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+const genomeLength = 20
+
+// finalPosition interprets the first and second halves of a binary genome as the number
+// of steps taken along the x and y axes of a maze, returning the final position reached.
+func finalPosition(ind *ga.Individual) []float64 {
+	x, y := 0.0, 0.0
+	for i, gene := range ind.Genotype.Genome {
+		if i < len(ind.Genotype.Genome)/2 {
+			x += float64(gene)
+		} else {
+			y += float64(gene)
+		}
+	}
+	return []float64{x, y}
+}
+
+func TestNoveltyFitness(t *testing.T) {
+	ns := &NoveltySearch{BehaviorCharacterize: func(ind *ga.Individual) []float64 {
+		return []float64{float64(ind.Genotype.Genome[0]), float64(ind.Genotype.Genome[1])}
+	}}
+	archive := []*ga.Individual{
+		{Genotype: &ga.Genotype{Genome: []byte{0, 0}}},
+		{Genotype: &ga.Genotype{Genome: []byte{3, 4}}},
+		{Genotype: &ga.Genotype{Genome: []byte{6, 8}}},
+	}
+
+	cases := []struct {
+		k        int
+		expected float64
+	}{
+		{k: 1, expected: 0.0},
+		{k: 2, expected: 2.5},
+		{k: 3, expected: 5.0},
+		{k: 10, expected: 5.0},
+	}
+
+	for _, tc := range cases {
+		got := ns.NoveltyFitness(&ga.Individual{Genotype: &ga.Genotype{Genome: []byte{0, 0}}}, archive, tc.k)
+		if got != tc.expected {
+			t.Errorf("Expected novelty %f with k=%d, but got %f", tc.expected, tc.k, got)
+		}
+	}
+}
+
+func TestNoveltyArchiveGrows(t *testing.T) {
+	ns := &NoveltySearch{
+		BehaviorCharacterize: finalPosition,
+		Archive:              NewNoveltyArchive(1.5),
+		K:                    5,
+	}
+
+	gaInstance := &ga.GA{
+		Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 3) },
+		Crossover:     ga.SinglePointCrossover,
+		Mutation:      ga.BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.05,
+		Generations:   1,
+	}
+	gaInstance.Initialize(30, func() *ga.Genotype {
+		// Start every individual near the maze entrance.
+		genotype := ga.MustNewGenotype(genomeLength)
+		genotype.Genome[rand.Intn(genomeLength)] = 1
+		return genotype
+	}, ns.EvalFunc())
+
+	previous := ns.Archive.Size()
+	if previous == 0 {
+		t.Fatalf("Expected the archive to be seeded by the initial population")
+	}
+	for gen := 0; gen < 10; gen++ {
+		gaInstance.Evolve(ns.EvalFunc())
+	}
+
+	if ns.Archive.Size() <= previous {
+		t.Errorf("Expected the archive to grow beyond %d behaviors, but got %d", previous, ns.Archive.Size())
+	}
+}
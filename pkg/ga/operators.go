@@ -0,0 +1,145 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including registries that resolve genetic operators by name.
+package ga
+
+import "github.com/Okabe-Junya/gago/pkg/ga/registry"
+
+// defaultTournamentSize is the tournament size of the "tournament" selection operator.
+const defaultTournamentSize = 3
+
+// defaultBlendAlpha is the alpha parameter of the "blend" crossover operator.
+const defaultBlendAlpha = 0.5
+
+// randomArithmeticAlpha is the alpha parameter of the "arithmetic" and "whole_arithmetic"
+// crossover operators, which makes them sample alpha uniformly from [0, 1].
+const randomArithmeticAlpha = -1
+
+// SelectionRegistry maps names to selection operators.
+type SelectionRegistry = registry.Registry[func([]*Individual) []*Individual]
+
+// CrossoverRegistry maps names to crossover operators.
+type CrossoverRegistry = registry.Registry[func([]*Individual, float64) []*Individual]
+
+// MutationRegistry maps names to mutation operators.
+type MutationRegistry = registry.Registry[func([]*Individual, float64)]
+
+// SelectionOperators holds the selection operators available to LoadConfig.
+// Custom operators can be added with SelectionOperators.Register.
+var SelectionOperators = registry.New[func([]*Individual) []*Individual]("selection")
+
+// CrossoverOperators holds the crossover operators available to LoadConfig.
+// Custom operators can be added with CrossoverOperators.Register.
+var CrossoverOperators = registry.New[func([]*Individual, float64) []*Individual]("crossover")
+
+// MutationOperators holds the mutation operators available to LoadConfig.
+// Custom operators can be added with MutationOperators.Register.
+var MutationOperators = registry.New[func([]*Individual, float64)]("mutation")
+
+func init() {
+	SelectionOperators.Register("tournament", func(population []*Individual) []*Individual {
+		return TournamentSelection(population, defaultTournamentSize)
+	})
+	SelectionOperators.Register("roulette_wheel", RouletteWheelSelection)
+
+	CrossoverOperators.Register("single_point", SinglePointCrossover)
+	CrossoverOperators.Register("uniform", UniformCrossover)
+	CrossoverOperators.Register("two_point", TwoPointCrossover)
+	CrossoverOperators.Register("position_based", PositionBasedCrossover)
+	CrossoverOperators.Register("order_based", OrderBasedCrossover)
+	CrossoverOperators.Register("edge_assembly", EdgeAssemblyCrossover)
+	CrossoverOperators.Register("variable_length", VariableLengthCrossover)
+	CrossoverOperators.Register("blend", func(population []*Individual, crossoverRate float64) []*Individual {
+		return BlendCrossover(population, crossoverRate, defaultBlendAlpha)
+	})
+	CrossoverOperators.Register("arithmetic", func(population []*Individual, crossoverRate float64) []*Individual {
+		return ArithmeticCrossover(population, crossoverRate, randomArithmeticAlpha)
+	})
+	CrossoverOperators.Register("whole_arithmetic", func(population []*Individual, crossoverRate float64) []*Individual {
+		return WholeArithmeticCrossover(population, crossoverRate, randomArithmeticAlpha)
+	})
+
+	MutationOperators.Register("bit_flip", BitFlipMutation)
+	MutationOperators.Register("swap", SwapMutation)
+	MutationOperators.Register("insertion", InsertionMutation)
+	MutationOperators.Register("deletion", DeletionMutation)
+	MutationOperators.Register("self_adaptive", SelfAdaptiveMutation)
+	MutationOperators.Register("gaussian", GaussianMutation)
+	MutationOperators.Register("polynomial", PolynomialMutation)
+	MutationOperators.Register("permutation_insertion", PermutationInsertionMutation)
+	MutationOperators.Register("displacement", DisplacementMutation)
+}
+
+// OperatorConfig names the genetic operators to use, as registered in SelectionOperators,
+// CrossoverOperators, and MutationOperators.
+type OperatorConfig struct {
+	Selection string
+	Crossover string
+	Mutation  string
+}
+
+// OperatorRegistry holds the registries from which LoadConfigFrom resolves operators by
+// name. A nil registry stands for the corresponding package-level registry:
+// SelectionOperators, CrossoverOperators, or MutationOperators.
+type OperatorRegistry = registry.OperatorRegistry[
+	func([]*Individual) []*Individual,
+	func([]*Individual, float64) []*Individual,
+	func([]*Individual, float64),
+]
+
+// LoadConfig resolves the operators named in cfg and sets them on the GA.
+//
+// Empty names leave the corresponding operator unchanged. If any name cannot be resolved,
+// an error is returned and none of the operators are changed.
+//
+// Parameters:
+// - cfg: the names of the operators to use.
+//
+// Returns:
+// - An error if any of the named operators is not registered.
+func (ga *GA) LoadConfig(cfg OperatorConfig) error {
+	return ga.LoadConfigFrom(cfg, nil)
+}
+
+// LoadConfigFrom is like LoadConfig but resolves the operators in the given registries.
+//
+// Parameters:
+// - cfg: the names of the operators to use.
+// - operators: the registries to resolve the names in, or nil for the package-level ones.
+//
+// Returns:
+// - An error if any of the named operators is not registered.
+func (ga *GA) LoadConfigFrom(cfg OperatorConfig, operators *OperatorRegistry) error {
+	selections, crossovers, mutations := SelectionOperators, CrossoverOperators, MutationOperators
+	if operators != nil {
+		if operators.Selection != nil {
+			selections = operators.Selection
+		}
+		if operators.Crossover != nil {
+			crossovers = operators.Crossover
+		}
+		if operators.Mutation != nil {
+			mutations = operators.Mutation
+		}
+	}
+	selection, crossover, mutation := ga.Selection, ga.Crossover, ga.Mutation
+
+	var err error
+	if cfg.Selection != "" {
+		if selection, err = selections.Get(cfg.Selection); err != nil {
+			return err
+		}
+	}
+	if cfg.Crossover != "" {
+		if crossover, err = crossovers.Get(cfg.Crossover); err != nil {
+			return err
+		}
+	}
+	if cfg.Mutation != "" {
+		if mutation, err = mutations.Get(cfg.Mutation); err != nil {
+			return err
+		}
+	}
+
+	ga.Selection, ga.Crossover, ga.Mutation = selection, crossover, mutation
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package operators provides combinators that build genetic operators out of other
+// operators, such as pipelines applying several operators in sequence.
+package operators
+
+import "github.com/Okabe-Junya/gago/pkg/ga"
+
+// CrossoverPipeline returns a crossover operator that applies the given crossover operators
+// in sequence, each to the offspring produced by the previous one, with the same crossover rate.
+//
+// Parameters:
+// - operators: the crossover operators to apply, in order.
+//
+// Returns:
+// - The combined crossover operator, which returns its input population if operators is empty.
+func CrossoverPipeline(operators ...func([]*ga.Individual, float64) []*ga.Individual) func([]*ga.Individual, float64) []*ga.Individual {
+	return func(population []*ga.Individual, crossoverRate float64) []*ga.Individual {
+		for _, crossover := range operators {
+			population = crossover(population, crossoverRate)
+		}
+		return population
+	}
+}
+
+// MutationPipeline returns a mutation operator that applies the given mutation operators in
+// sequence to the population, with the same mutation rate.
+//
+// Parameters:
+// - operators: the mutation operators to apply, in order.
+//
+// Returns:
+// - The combined mutation operator.
+func MutationPipeline(operators ...func([]*ga.Individual, float64)) func([]*ga.Individual, float64) {
+	return func(population []*ga.Individual, mutationRate float64) {
+		for _, mutate := range operators {
+			mutate(population, mutationRate)
+		}
+	}
+}
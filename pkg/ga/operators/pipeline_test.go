@@ -0,0 +1,73 @@
+package operators
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// changedFraction applies the crossover operator to many random populations and returns the
+// fraction of offspring whose genome differs from that of the parent at the same position.
+func changedFraction(crossover func([]*ga.Individual, float64) []*ga.Individual, crossoverRate float64) float64 {
+	changed, total := 0, 0
+	for trial := 0; trial < 200; trial++ {
+		population := make([]*ga.Individual, 20)
+		for i := range population {
+			population[i] = &ga.Individual{Genotype: ga.MustVariableLengthBinaryGenotype(32, 32), Phenotype: &ga.Phenotype{}}
+		}
+		for i, ind := range crossover(population, crossoverRate) {
+			if !bytes.Equal(ind.Genotype.Genome, population[i].Genotype.Genome) {
+				changed++
+			}
+			total++
+		}
+	}
+	return float64(changed) / float64(total)
+}
+
+func TestCrossoverPipeline(t *testing.T) {
+	const crossoverRate = 0.5
+	pipeline := changedFraction(CrossoverPipeline(ga.SinglePointCrossover, ga.UniformCrossover), crossoverRate)
+
+	cases := []struct {
+		name      string
+		crossover func([]*ga.Individual, float64) []*ga.Individual
+	}{
+		{name: "SinglePointCrossover", crossover: ga.SinglePointCrossover},
+		{name: "UniformCrossover", crossover: ga.UniformCrossover},
+	}
+
+	for _, tc := range cases {
+		if alone := changedFraction(tc.crossover, crossoverRate); pipeline <= alone {
+			t.Errorf("Expected the pipeline to change more individuals than %s alone, but got %f and %f", tc.name, pipeline, alone)
+		}
+	}
+}
+
+func TestMutationPipeline(t *testing.T) {
+	var calls []float64
+	record := func(id float64) func([]*ga.Individual, float64) {
+		return func(_ []*ga.Individual, mutationRate float64) {
+			calls = append(calls, id, mutationRate)
+		}
+	}
+
+	MutationPipeline(record(1), record(2), record(3))(nil, 0.25)
+
+	if expected := []float64{1, 0.25, 2, 0.25, 3, 0.25}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected the operators to be applied in order with the same rate, but got %v", calls)
+	}
+}
+
+func TestEmptyPipelines(t *testing.T) {
+	population := []*ga.Individual{{Genotype: &ga.Genotype{Genome: []byte{1, 0}}}}
+
+	offspring := CrossoverPipeline()(population, 1.0)
+	MutationPipeline()(population, 1.0)
+
+	if len(offspring) != 1 || offspring[0] != population[0] || population[0].Genotype.Genome[0] != 1 {
+		t.Errorf("Expected empty pipelines to leave the population unchanged, but got %v", offspring)
+	}
+}
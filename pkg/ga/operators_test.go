@@ -0,0 +1,84 @@
+package ga
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga/registry"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		cfg OperatorConfig
+	}{
+		{cfg: OperatorConfig{Selection: "tournament", Crossover: "single_point", Mutation: "bit_flip"}},
+		{cfg: OperatorConfig{Selection: "roulette_wheel", Crossover: "uniform", Mutation: "swap"}},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{CrossoverRate: 0.7, MutationRate: 0.01, Generations: 2}
+
+		if err := ga.LoadConfig(tc.cfg); err != nil {
+			t.Fatalf("Expected config %+v to load, but got error: %v", tc.cfg, err)
+		}
+		if ga.Selection == nil || ga.Crossover == nil || ga.Mutation == nil {
+			t.Fatalf("Expected all operators to be set for config %+v", tc.cfg)
+		}
+
+		ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+		ga.Evolve(oneMax)
+	}
+}
+
+func TestLoadConfigUnknownOperator(t *testing.T) {
+	cases := []struct {
+		cfg     OperatorConfig
+		unknown string
+	}{
+		{cfg: OperatorConfig{Selection: "nonexistent", Crossover: "single_point", Mutation: "bit_flip"}, unknown: "nonexistent"},
+		{cfg: OperatorConfig{Selection: "tournament", Crossover: "three_point", Mutation: "bit_flip"}, unknown: "three_point"},
+		{cfg: OperatorConfig{Selection: "tournament", Crossover: "single_point", Mutation: "scramble"}, unknown: "scramble"},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{}
+
+		err := ga.LoadConfig(tc.cfg)
+		if err == nil {
+			t.Fatalf("Expected an error for config %+v, but got nil", tc.cfg)
+		}
+		if !errors.Is(err, registry.ErrUnknownOperator) || !strings.Contains(err.Error(), tc.unknown) {
+			t.Errorf("Expected a descriptive error naming %q, but got %v", tc.unknown, err)
+		}
+		if ga.Selection != nil || ga.Crossover != nil || ga.Mutation != nil {
+			t.Errorf("Expected no operators to be set after a failed load")
+		}
+	}
+}
+
+func TestLoadConfigFrom(t *testing.T) {
+	selected := false
+	selections := registry.New[func([]*Individual) []*Individual]("selection")
+	selections.Register("first", func(population []*Individual) []*Individual {
+		selected = true
+		return population
+	})
+	operators := &OperatorRegistry{Selection: selections}
+
+	ga := &GA{}
+	if err := ga.LoadConfigFrom(OperatorConfig{Selection: "first", Crossover: "single_point"}, operators); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if ga.Selection == nil || ga.Crossover == nil {
+		t.Fatalf("Expected the selection and crossover operators to be set")
+	}
+	ga.Selection(nil)
+	if !selected {
+		t.Errorf("Expected the selection operator to be resolved in the given registry")
+	}
+
+	if err := ga.LoadConfigFrom(OperatorConfig{Selection: "tournament"}, operators); !errors.Is(err, registry.ErrUnknownOperator) {
+		t.Errorf("Expected an error wrapping %v for an operator missing from the given registry, but got %v", registry.ErrUnknownOperator, err)
+	}
+}
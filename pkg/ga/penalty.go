@@ -0,0 +1,59 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including penalty schedules for handling soft constraints.
+package ga
+
+import "math"
+
+// PenaltySchedule computes the fitness penalty of an individual violating the problem's
+// constraints, allowing the penalty weight to change over the generations.
+type PenaltySchedule interface {
+	// Penalty returns the amount by which to worsen the fitness of an individual with the given
+	// constraint violation at the given zero-based generation.
+	Penalty(generation int, violation float64) float64
+}
+
+// linearDecayPenalty is the PenaltySchedule returned by LinearDecayPenalty.
+type linearDecayPenalty struct {
+	initial float64
+	decay   float64
+}
+
+// LinearDecayPenalty returns a PenaltySchedule whose weight starts at initial and decreases by
+// decay every generation until it reaches zero. The penalty is the weight times the violation.
+//
+// Parameters:
+// - initial: the penalty weight at generation zero.
+// - decay: the decrease of the penalty weight per generation.
+//
+// Returns:
+// - The penalty schedule.
+func LinearDecayPenalty(initial, decay float64) PenaltySchedule {
+	return linearDecayPenalty{initial: initial, decay: decay}
+}
+
+func (p linearDecayPenalty) Penalty(generation int, violation float64) float64 {
+	return math.Max(p.initial-p.decay*float64(generation), 0) * violation
+}
+
+// exponentialDecayPenalty is the PenaltySchedule returned by ExponentialDecayPenalty.
+type exponentialDecayPenalty struct {
+	initial float64
+	rate    float64
+}
+
+// ExponentialDecayPenalty returns a PenaltySchedule whose weight starts at initial and decays
+// exponentially as initial * exp(-rate * generation). The penalty is the weight times the violation.
+//
+// Parameters:
+// - initial: the penalty weight at generation zero.
+// - rate: the decay rate per generation.
+//
+// Returns:
+// - The penalty schedule.
+func ExponentialDecayPenalty(initial, rate float64) PenaltySchedule {
+	return exponentialDecayPenalty{initial: initial, rate: rate}
+}
+
+func (p exponentialDecayPenalty) Penalty(generation int, violation float64) float64 {
+	return p.initial * math.Exp(-p.rate*float64(generation)) * violation
+}
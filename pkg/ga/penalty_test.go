@@ -0,0 +1,72 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPenaltySchedules(t *testing.T) {
+	cases := []struct {
+		name       string
+		schedule   PenaltySchedule
+		generation int
+		violation  float64
+		expected   float64
+	}{
+		{name: "linear start", schedule: LinearDecayPenalty(100, 1), generation: 0, violation: 2, expected: 200},
+		{name: "linear middle", schedule: LinearDecayPenalty(100, 1), generation: 60, violation: 2, expected: 80},
+		{name: "linear end", schedule: LinearDecayPenalty(100, 1), generation: 100, violation: 2, expected: 0},
+		{name: "linear after end", schedule: LinearDecayPenalty(100, 1), generation: 150, violation: 2, expected: 0},
+		{name: "linear feasible", schedule: LinearDecayPenalty(100, 1), generation: 0, violation: 0, expected: 0},
+		{name: "exponential start", schedule: ExponentialDecayPenalty(10, 0.1), generation: 0, violation: 1, expected: 10},
+		{name: "exponential decayed", schedule: ExponentialDecayPenalty(10, 0.1), generation: 10, violation: 1, expected: 10 / math.E},
+	}
+
+	for _, tc := range cases {
+		if got := tc.schedule.Penalty(tc.generation, tc.violation); math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("%s: Expected penalty %f, but got %f", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestEvolveWithPenaltySchedule(t *testing.T) {
+	minValues, maxValues := []float64{0}, []float64{10}
+	// identity rewards larger x, but x must not exceed 5.
+	identity := func(genotype *Genotype) *Phenotype { return &Phenotype{Fitness: genotype.GetRealValue(0)} }
+	violation := func(ind *Individual) float64 { return math.Max(ind.Genotype.GetRealValue(0)-5, 0) }
+
+	penalized := make(map[int]bool)
+	ga := &GA{
+		Selection:           func(population []*Individual) []*Individual { return population },
+		Crossover:           SinglePointCrossover,
+		Mutation:            GaussianMutation,
+		CrossoverRate:       0.0,
+		MutationRate:        0.5,
+		Generations:         101,
+		PenaltySchedule:     LinearDecayPenalty(100, 1),
+		ConstraintViolation: violation,
+		OnGeneration: func(gen int, population []*Individual) {
+			for _, ind := range population {
+				raw := identity(ind.Genotype).Fitness
+				if ind.ConstraintViolation != violation(ind) {
+					t.Errorf("Expected recorded violation %f, but got %f", violation(ind), ind.ConstraintViolation)
+				}
+				if ind.ConstraintViolation > 0 && ind.Phenotype.Fitness < raw {
+					penalized[gen] = true
+				}
+				if ind.ConstraintViolation == 0 && ind.Phenotype.Fitness != raw {
+					t.Errorf("Expected feasible individuals to be unpenalized, but got %f for %f", ind.Phenotype.Fitness, raw)
+				}
+			}
+		},
+	}
+	ga.Initialize(20, func() *Genotype { return MustNewFloat64Genotype(1, minValues, maxValues) }, identity)
+	ga.Evolve(identity)
+
+	if !penalized[0] {
+		t.Errorf("Expected infeasible individuals to be penalized at generation 0")
+	}
+	if penalized[100] {
+		t.Errorf("Expected infeasible individuals to receive zero penalty by generation 100")
+	}
+}
@@ -0,0 +1,51 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the adaptation of the population size to its diversity.
+package ga
+
+import "sync/atomic"
+
+// adaptPopulationSize grows the population by PopGrowthRate freshly initialized individuals
+// if its diversity is below LowDiversityThreshold, or removes its PopShrinkRate worst
+// individuals if its diversity is above HighDiversityThreshold, keeping the size within
+// MinPopSize and MaxPopSize. The best individual is never removed.
+//
+// Parameters:
+// - evaluatePhenotype: a function to evaluate a Genotype and return its Phenotype.
+func (ga *GA) adaptPopulationSize(evaluatePhenotype func(*Genotype) *Phenotype) {
+	if ga.initializeGenotype == nil || len(ga.Population) == 0 {
+		return
+	}
+	size := len(ga.Population)
+	diversity := ga.statistics().Diversity
+
+	switch {
+	case diversity < ga.LowDiversityThreshold:
+		n := ga.PopGrowthRate
+		if ga.MaxPopSize > 0 {
+			n = min(n, ga.MaxPopSize-size)
+		}
+		if ga.EvaluationBudget > 0 {
+			n = min(n, ga.EvaluationBudget-int(atomic.LoadInt64(&ga.EvaluationsUsed)))
+		}
+		for i := 0; i < n; i++ {
+			ga.Population = append(ga.Population, ga.newIndividual(evaluatePhenotype))
+		}
+	case diversity > ga.HighDiversityThreshold:
+		n := min(ga.PopShrinkRate, size-max(ga.MinPopSize, 1))
+		removed := make(map[int]bool, n)
+		for _, i := range worstIndices(ga.Population, n, ga.Minimize) {
+			removed[i] = true
+		}
+		kept := make([]*Individual, 0, size)
+		for i, ind := range ga.Population {
+			if !removed[i] {
+				kept = append(kept, ind)
+			}
+		}
+		ga.Population = kept
+	}
+
+	if len(ga.Population) != size {
+		ga.log("Adapted population size", "PopulationSize", len(ga.Population))
+	}
+}
@@ -0,0 +1,41 @@
+package ga
+
+import "testing"
+
+func TestAdaptPopulationSize(t *testing.T) {
+	cases := []struct {
+		name         string
+		genotype     func() *Genotype
+		expectedSize int
+	}{
+		{name: "converged", genotype: func() *Genotype { return MustNewGenotype(64) }, expectedSize: 30},
+		{name: "diverse", genotype: func() *Genotype { return MustVariableLengthBinaryGenotype(64, 64) }, expectedSize: 10},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:              func(population []*Individual) []*Individual { return population },
+			Crossover:              SinglePointCrossover,
+			Mutation:               BitFlipMutation,
+			Generations:            20,
+			MinPopSize:             10,
+			MaxPopSize:             30,
+			PopSizeAdaptInterval:   1,
+			LowDiversityThreshold:  0.1,
+			HighDiversityThreshold: 0.5,
+			PopGrowthRate:          3,
+			PopShrinkRate:          3,
+		}
+		ga.Initialize(20, tc.genotype, oneMax)
+		ga.Evolve(oneMax)
+
+		for gen, stats := range ga.GetHistory() {
+			if stats.PopulationSize < ga.MinPopSize || stats.PopulationSize > ga.MaxPopSize {
+				t.Errorf("Expected the %s population size to stay within [%d, %d], but got %d at generation %d", tc.name, ga.MinPopSize, ga.MaxPopSize, stats.PopulationSize, gen)
+			}
+		}
+		if len(ga.Population) != tc.expectedSize {
+			t.Errorf("Expected the %s population to reach size %d, but got %d", tc.name, tc.expectedSize, len(ga.Population))
+		}
+	}
+}
@@ -1 +1,578 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the Population type and its summary statistics.
 package ga
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Statistics summarizes the fitness values of a population.
+//
+// Diversity is the standard deviation of the fitness values, a phenotypic measure.
+// GenotypicDiversity is the normalized mean pairwise Hamming distance between genomes,
+// and is only calculated for binary-encoded populations. AlleleFrequencies holds, for each
+// gene position, the fraction of individuals whose gene is 1, and is only calculated for
+// binary-encoded populations whose genomes have equal length. Percentiles maps each of the
+// percentiles 0.05, 0.25, 0.50, 0.75, and 0.95 to the linearly interpolated fitness at it.
+// PopulationSize records the number of individuals, which may change between generations
+// when the GA adapts its population size. Converged reports whether Diversity is below the
+// convergence epsilon of the population. SelectionPressure is the ratio of the selection
+// probability of the best individual to the average selection probability, as measured by
+// the selection pressure metric of the population, and is zero if it has none.
+type Statistics struct {
+	PopulationSize     int
+	BestFitness        float64
+	WorstFitness       float64
+	AverageFitness     float64
+	Diversity          float64
+	GenotypicDiversity float64
+	AlleleFrequencies  []float64
+	Percentiles        map[float64]float64
+	Converged          bool
+	SelectionPressure  float64
+}
+
+var (
+	// ErrIndexOutOfRange is returned when an index is outside the individuals of a population.
+	ErrIndexOutOfRange = errors.New("individual index out of range")
+	// ErrPopulationSizeMismatch is returned when replacing the individuals of a population
+	// with a different number of individuals.
+	ErrPopulationSizeMismatch = errors.New("population size mismatch")
+)
+
+// DefaultConvergenceEpsilon is the diversity below which a population is considered
+// converged when no convergence epsilon is set.
+const DefaultConvergenceEpsilon = 0.001
+
+// fitnessPercentiles are the percentiles recorded in Statistics.Percentiles.
+var fitnessPercentiles = []float64{0.05, 0.25, 0.50, 0.75, 0.95}
+
+// Population represents a collection of individuals together with their statistics.
+//
+// Statistics holds the statistics as of the last calculation. Callers that change the
+// individuals should call MarkDirty, or use Add, and read the statistics through
+// GetStatistics, which recalculates them only when they are out of date. If Minimize is
+// set, lower fitness is better, so BestFitness is the lowest fitness and WorstFitness the
+// highest. ConvergenceEpsilon, if positive, overrides DefaultConvergenceEpsilon as the
+// diversity below which the population is considered converged. SelectionPressureMetric, if
+// set, measures the SelectionPressure recorded in the statistics.
+type Population struct {
+	Individuals             []*Individual
+	Statistics              *Statistics
+	Minimize                bool
+	ConvergenceEpsilon      float64
+	SelectionPressureMetric func([]*Individual) float64
+
+	statisticsDirty bool
+	best            *Individual
+	calculations    int
+}
+
+// NewPopulation creates a new Population from the given individuals and calculates its statistics.
+//
+// Parameters:
+// - individuals: a slice of pointers to Individual to include in the population.
+//
+// Returns:
+// - A pointer to the newly created Population.
+func NewPopulation(individuals []*Individual) *Population {
+	p := &Population{Individuals: individuals}
+	p.CalculateStatistics()
+	return p
+}
+
+// Size returns the number of individuals in the population.
+func (p *Population) Size() int {
+	return len(p.Individuals)
+}
+
+// Add appends individuals to the population and marks its statistics out of date.
+//
+// Parameters:
+// - individuals: the individuals to add.
+func (p *Population) Add(individuals ...*Individual) {
+	p.Individuals = append(p.Individuals, individuals...)
+	p.MarkDirty()
+}
+
+// Replace replaces the individual at the given index and marks the statistics of the
+// population out of date.
+//
+// Parameters:
+// - index: the index of the individual to replace.
+// - individual: the individual to put in its place.
+//
+// Returns:
+// - An error wrapping ErrIndexOutOfRange if the index is outside the population.
+func (p *Population) Replace(index int, individual *Individual) error {
+	if index < 0 || index >= len(p.Individuals) {
+		return fmt.Errorf("%w: index %d, population size %d", ErrIndexOutOfRange, index, len(p.Individuals))
+	}
+	p.Individuals[index] = individual
+	p.MarkDirty()
+	return nil
+}
+
+// ReplaceAll replaces every individual of the population, keeping its size, and marks its
+// statistics out of date.
+//
+// Parameters:
+// - individuals: the individuals to put in place of the current ones.
+//
+// Returns:
+// - An error wrapping ErrPopulationSizeMismatch if the number of individuals differs from
+// the size of the population.
+func (p *Population) ReplaceAll(individuals []*Individual) error {
+	if len(individuals) != p.Size() {
+		return fmt.Errorf("%w: %d individuals, population size %d", ErrPopulationSizeMismatch, len(individuals), p.Size())
+	}
+	p.Individuals = individuals
+	p.MarkDirty()
+	return nil
+}
+
+// MarkDirty marks the statistics of the population out of date, so that the next call to
+// GetStatistics or GetBestIndividual recalculates them. It should be called after individuals
+// are replaced or mutated in place.
+func (p *Population) MarkDirty() {
+	p.statisticsDirty = true
+}
+
+// GetStatistics returns the statistics of the population, calculating them only if they
+// have not been calculated yet or the population has been marked dirty since.
+//
+// Returns:
+// - The statistics of the population.
+func (p *Population) GetStatistics() *Statistics {
+	if p.Statistics == nil || p.statisticsDirty {
+		p.CalculateStatistics()
+	}
+	return p.Statistics
+}
+
+// GetBestIndividual returns the individual with the highest fitness, calculating the
+// statistics of the population first if they are out of date.
+//
+// Returns:
+// - The best individual, or nil if the population is empty.
+func (p *Population) GetBestIndividual() *Individual {
+	p.GetStatistics()
+	return p.best
+}
+
+// GetNthBest returns the n individuals with the best fitness, best first, or every individual
+// if the population holds fewer than n. Individuals of equal fitness keep their order in the
+// population, which is not modified.
+//
+// Parameters:
+// - n: the number of individuals to return.
+//
+// Returns:
+// - A new slice holding the best individuals.
+func (p *Population) GetNthBest(n int) []*Individual {
+	return p.ranked(n, func(a, b *Individual) bool { return fitter(a.Phenotype.Fitness, b.Phenotype.Fitness, p.Minimize) })
+}
+
+// GetNthWorst returns the n individuals with the worst fitness, worst first, or every
+// individual if the population holds fewer than n. Individuals of equal fitness keep their
+// order in the population, which is not modified.
+//
+// Parameters:
+// - n: the number of individuals to return.
+//
+// Returns:
+// - A new slice holding the worst individuals.
+func (p *Population) GetNthWorst(n int) []*Individual {
+	return p.ranked(n, func(a, b *Individual) bool { return fitter(b.Phenotype.Fitness, a.Phenotype.Fitness, p.Minimize) })
+}
+
+// ranked returns the first n individuals of a copy of the population stably sorted by less.
+func (p *Population) ranked(n int, less func(a, b *Individual) bool) []*Individual {
+	sorted := append([]*Individual(nil), p.Individuals...)
+	sort.SliceStable(sorted, func(a, b int) bool { return less(sorted[a], sorted[b]) })
+	return sorted[:min(max(n, 0), len(sorted))]
+}
+
+// CalculateStatistics calculates the best, worst, and average fitness of the population,
+// and its diversity measured as the standard deviation of the fitness values, and whether
+// the population has converged.
+//
+// The statistics of an empty population are all zero.
+func (p *Population) CalculateStatistics() {
+	stats := &Statistics{PopulationSize: len(p.Individuals)}
+	p.Statistics = stats
+	p.statisticsDirty = false
+	p.best = nil
+	p.calculations++
+	if len(p.Individuals) == 0 {
+		return
+	}
+
+	p.best = p.Individuals[0]
+	stats.BestFitness = p.Individuals[0].Phenotype.Fitness
+	stats.WorstFitness = p.Individuals[0].Phenotype.Fitness
+	total := 0.0
+	for _, ind := range p.Individuals {
+		fitness := ind.Phenotype.Fitness
+		if fitter(fitness, stats.BestFitness, p.Minimize) {
+			stats.BestFitness = fitness
+			p.best = ind
+		}
+		if fitter(stats.WorstFitness, fitness, p.Minimize) {
+			stats.WorstFitness = fitness
+		}
+		total += fitness
+	}
+	stats.AverageFitness = total / float64(len(p.Individuals))
+
+	variance := 0.0
+	for _, ind := range p.Individuals {
+		diff := ind.Phenotype.Fitness - stats.AverageFitness
+		variance += diff * diff
+	}
+	stats.Diversity = math.Sqrt(variance / float64(len(p.Individuals)))
+	epsilon := p.ConvergenceEpsilon
+	if epsilon <= 0 {
+		epsilon = DefaultConvergenceEpsilon
+	}
+	stats.Converged = stats.Diversity < epsilon
+	if p.SelectionPressureMetric != nil {
+		stats.SelectionPressure = p.SelectionPressureMetric(p.Individuals)
+	}
+
+	sorted := p.sortedFitness()
+	stats.Percentiles = make(map[float64]float64, len(fitnessPercentiles))
+	for _, percentile := range fitnessPercentiles {
+		stats.Percentiles[percentile] = interpolatePercentile(sorted, percentile)
+	}
+
+	if isBinaryEncoded(p.Individuals) {
+		stats.GenotypicDiversity = HammingDiversity(p.Individuals)
+		stats.AlleleFrequencies = alleleFrequencies(p.Individuals)
+	}
+}
+
+// alleleFrequencies returns, for each gene position, the fraction of individuals whose
+// gene is 1, or nil if the genomes differ in length.
+func alleleFrequencies(individuals []*Individual) []float64 {
+	length := len(individuals[0].Genotype.Genome)
+	frequencies := make([]float64, length)
+	for _, ind := range individuals {
+		if len(ind.Genotype.Genome) != length {
+			return nil
+		}
+		for i, gene := range ind.Genotype.Genome {
+			if gene == 1 {
+				frequencies[i]++
+			}
+		}
+	}
+	for i := range frequencies {
+		frequencies[i] /= float64(len(individuals))
+	}
+	return frequencies
+}
+
+// ConvergedPositions returns the gene positions at which the population has converged,
+// that is, where the frequency of allele 1 is at least 0.95 or at most 0.05, calculating the
+// statistics of the population first if they are out of date.
+//
+// Returns:
+// - The converged positions in ascending order, or nil if allele frequencies are unavailable.
+func (p *Population) ConvergedPositions() []int {
+	var positions []int
+	for i, frequency := range p.GetStatistics().AlleleFrequencies {
+		if frequency >= 0.95 || frequency <= 0.05 {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// sortedFitness returns the fitness values of the population in ascending order.
+func (p *Population) sortedFitness() []float64 {
+	fitness := make([]float64, len(p.Individuals))
+	for i, ind := range p.Individuals {
+		fitness[i] = ind.Phenotype.Fitness
+	}
+	sort.Float64s(fitness)
+	return fitness
+}
+
+// interpolatePercentile returns the value at the given percentile of the sorted values,
+// linearly interpolating between the two nearest ranks.
+func interpolatePercentile(sorted []float64, percentile float64) float64 {
+	position := percentile * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+// FitnessHistogram divides the range of fitness values in the population into equal-width
+// bins and counts the individuals in each bin. The maximum fitness falls in the last bin.
+//
+// Parameters:
+// - bins: the number of bins.
+//
+// Returns:
+// - The bin edges, of length bins+1, in ascending order.
+// - The number of individuals in each bin, of length bins.
+func (p *Population) FitnessHistogram(bins int) ([]float64, []int) {
+	if bins <= 0 || len(p.Individuals) == 0 {
+		return nil, nil
+	}
+
+	sorted := p.sortedFitness()
+	lower, upper := sorted[0], sorted[len(sorted)-1]
+	width := (upper - lower) / float64(bins)
+
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = lower + width*float64(i)
+	}
+	edges[bins] = upper
+
+	counts := make([]int, bins)
+	for _, fitness := range sorted {
+		bin := bins - 1
+		if width > 0 {
+			bin = min(int((fitness-lower)/width), bins-1)
+		}
+		counts[bin]++
+	}
+	return edges, counts
+}
+
+// isBinaryEncoded reports whether every individual has a binary-encoded genotype.
+func isBinaryEncoded(individuals []*Individual) bool {
+	for _, ind := range individuals {
+		if ind.Genotype == nil || ind.Genotype.GenomeType != BinaryEncoding {
+			return false
+		}
+	}
+	return true
+}
+
+// HammingDiversity returns the mean pairwise Hamming distance between the genomes of the
+// given individuals, normalized to [0, 1] by dividing by the genome length.
+//
+// A population of identical genomes has a diversity of 0, and a pair of complementary
+// binary genomes has a diversity of 1. For genomes of differing lengths, each pairwise
+// distance is normalized by the longer of the two genomes.
+//
+// Parameters:
+// - individuals: a slice of pointers to Individual whose genomes are compared.
+//
+// Returns:
+// - The normalized mean pairwise Hamming distance, or zero for fewer than two individuals.
+func HammingDiversity(individuals []*Individual) float64 {
+	n := len(individuals)
+	if n < 2 {
+		return 0
+	}
+	pairs := float64(n * (n - 1) / 2)
+
+	length := len(individuals[0].Genotype.Genome)
+	for _, ind := range individuals {
+		if len(ind.Genotype.Genome) != length {
+			return pairwiseHammingDiversity(individuals) / pairs
+		}
+	}
+	if length == 0 {
+		return 0
+	}
+
+	// With equal lengths, the number of differing pairs at each position follows from
+	// the allele counts at that position, avoiding a quadratic number of comparisons.
+	differing := 0.0
+	var counts [256]int
+	for i := 0; i < length; i++ {
+		counts = [256]int{}
+		for _, ind := range individuals {
+			counts[ind.Genotype.Genome[i]]++
+		}
+		same := 0
+		for _, c := range counts {
+			same += c * (c - 1) / 2
+		}
+		differing += pairs - float64(same)
+	}
+	return differing / pairs / float64(length)
+}
+
+// pairwiseHammingDiversity returns the sum over all pairs of individuals of their Hamming
+// distance normalized by the longer genome.
+func pairwiseHammingDiversity(individuals []*Individual) float64 {
+	total := 0.0
+	for i := range individuals {
+		for j := i + 1; j < len(individuals); j++ {
+			length := max(len(individuals[i].Genotype.Genome), len(individuals[j].Genotype.Genome))
+			if length > 0 {
+				total += HammingDistance(individuals[i], individuals[j]) / float64(length)
+			}
+		}
+	}
+	return total
+}
+
+// averageFitness returns the average fitness of the given individuals, or zero if there are none.
+func averageFitness(individuals []*Individual) float64 {
+	if len(individuals) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, ind := range individuals {
+		total += ind.Phenotype.Fitness
+	}
+	return total / float64(len(individuals))
+}
+
+// worstIndices returns the indices of the n individuals with the worst fitness, which is
+// the highest fitness if minimize is set and the lowest otherwise, ordered from worst to best.
+func worstIndices(individuals []*Individual, n int, minimize bool) []int {
+	indices := make([]int, len(individuals))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return fitter(individuals[indices[b]].Phenotype.Fitness, individuals[indices[a]].Phenotype.Fitness, minimize)
+	})
+	if n < 0 {
+		n = 0
+	}
+	return indices[:min(n, len(indices))]
+}
+
+// Filter returns a new population containing only the individuals whose fitness
+// is at least minFitness. The original population is not modified.
+//
+// Parameters:
+// - minFitness: the minimum fitness an individual must have to be kept.
+//
+// Returns:
+// - A new Population of the kept individuals, with its statistics calculated.
+func (p *Population) Filter(minFitness float64) *Population {
+	return p.FilterFunc(func(ind *Individual) bool {
+		return ind.Phenotype.Fitness >= minFitness
+	})
+}
+
+// FilterFunc returns a new population containing only the individuals for which
+// keep returns true. The original population is not modified.
+//
+// Parameters:
+// - keep: a predicate reporting whether an individual should be kept.
+//
+// Returns:
+// - A new Population of the kept individuals, with its statistics calculated.
+func (p *Population) FilterFunc(keep func(*Individual) bool) *Population {
+	kept := make([]*Individual, 0, len(p.Individuals))
+	for _, ind := range p.Individuals {
+		if keep(ind) {
+			kept = append(kept, ind)
+		}
+	}
+	return NewPopulation(kept)
+}
+
+// Sample returns a new population of n individuals drawn uniformly at random without
+// replacement, using a partial Fisher-Yates shuffle. If n is at least the size of the
+// population, the sample is a shuffled copy of the whole population. The original
+// population is not modified.
+//
+// Parameters:
+// - n: the number of individuals to draw.
+// - rng: the source of randomness.
+//
+// Returns:
+// - A new Population of the sampled individuals, with its statistics calculated.
+func (p *Population) Sample(n int, rng *rand.Rand) *Population {
+	shuffled := append([]*Individual(nil), p.Individuals...)
+	n = min(max(n, 0), len(shuffled))
+	for i := 0; i < n; i++ {
+		j := i + rng.Intn(len(shuffled)-i)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	sample := &Population{Individuals: shuffled[:n:n], Minimize: p.Minimize}
+	sample.CalculateStatistics()
+	return sample
+}
+
+// MergePopulations combines the individuals of two populations into a new population.
+// Neither input population is modified.
+//
+// Parameters:
+// - a: the first population.
+// - b: the second population.
+//
+// Returns:
+// - A new Population containing the individuals of a followed by those of b,
+// with its statistics calculated.
+func MergePopulations(a, b *Population) *Population {
+	merged := make([]*Individual, 0, len(a.Individuals)+len(b.Individuals))
+	merged = append(merged, a.Individuals...)
+	merged = append(merged, b.Individuals...)
+	return NewPopulation(merged)
+}
+
+// MergeUnique combines the individuals of two populations into a new population,
+// keeping only the first individual seen for each distinct genome. Neither input
+// population is modified.
+//
+// Parameters:
+// - a: the first population.
+// - b: the second population.
+//
+// Returns:
+// - A new Population containing the individuals with unique genomes,
+// with its statistics calculated.
+func MergeUnique(a, b *Population) *Population {
+	seen := make(map[string]bool, len(a.Individuals)+len(b.Individuals))
+	merged := make([]*Individual, 0, len(a.Individuals)+len(b.Individuals))
+	for _, individuals := range [][]*Individual{a.Individuals, b.Individuals} {
+		for _, ind := range individuals {
+			key := string(ind.Genotype.key())
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, ind)
+		}
+	}
+	return NewPopulation(merged)
+}
+
+// maxDeduplicateAttempts is the number of fresh genotypes Deduplicate initializes for each
+// duplicate before it accepts one that is still a duplicate, which bounds its work when
+// the space of genotypes is small.
+const maxDeduplicateAttempts = 10
+
+// Deduplicate replaces every individual whose genotype equals that of an earlier individual
+// with a freshly initialized and evaluated one, and recalculates the statistics. The first
+// individual of each genotype is kept. Fresh genotypes that duplicate an individual are
+// re-initialized up to maxDeduplicateAttempts times.
+//
+// Parameters:
+// - initFunc: a function to create a new Genotype.
+// - evalFunc: a function to evaluate a Genotype and return its Phenotype.
+func (p *Population) Deduplicate(initFunc func() *Genotype, evalFunc func(*Genotype) *Phenotype) {
+	seen := make(map[string]bool, len(p.Individuals))
+	for i, ind := range p.Individuals {
+		key := string(ind.Genotype.key())
+		if !seen[key] {
+			seen[key] = true
+			continue
+		}
+		genotype := initFunc()
+		for attempt := 1; attempt < maxDeduplicateAttempts && seen[string(genotype.key())]; attempt++ {
+			genotype = initFunc()
+		}
+		seen[string(genotype.key())] = true
+		p.Individuals[i] = &Individual{Genotype: genotype, Phenotype: evalFunc(genotype), ID: NextIndividualID()}
+	}
+	p.CalculateStatistics()
+}
@@ -0,0 +1,663 @@
+package ga
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCalculateStatistics(t *testing.T) {
+	cases := []struct {
+		population      []*Individual
+		expectedBest    float64
+		expectedWorst   float64
+		expectedAverage float64
+	}{
+		{
+			population: []*Individual{
+				{Phenotype: &Phenotype{Fitness: 1.0}},
+				{Phenotype: &Phenotype{Fitness: 2.0}},
+				{Phenotype: &Phenotype{Fitness: 3.0}},
+			},
+			expectedBest:    3.0,
+			expectedWorst:   1.0,
+			expectedAverage: 2.0,
+		},
+		{
+			population:      []*Individual{},
+			expectedBest:    0.0,
+			expectedWorst:   0.0,
+			expectedAverage: 0.0,
+		},
+	}
+
+	for _, tc := range cases {
+		stats := NewPopulation(tc.population).Statistics
+
+		if stats.BestFitness != tc.expectedBest {
+			t.Errorf("Expected best fitness %f, but got %f", tc.expectedBest, stats.BestFitness)
+		}
+		if stats.WorstFitness != tc.expectedWorst {
+			t.Errorf("Expected worst fitness %f, but got %f", tc.expectedWorst, stats.WorstFitness)
+		}
+		if stats.AverageFitness != tc.expectedAverage {
+			t.Errorf("Expected average fitness %f, but got %f", tc.expectedAverage, stats.AverageFitness)
+		}
+	}
+}
+
+func TestGetNthBest(t *testing.T) {
+	cases := []struct {
+		minimize      bool
+		n             int
+		expectedBest  []float64
+		expectedWorst []float64
+	}{
+		{n: 2, expectedBest: []float64{5, 4}, expectedWorst: []float64{1, 2}},
+		{minimize: true, n: 2, expectedBest: []float64{1, 2}, expectedWorst: []float64{5, 4}},
+		{n: 0, expectedBest: []float64{}, expectedWorst: []float64{}},
+		{n: 10, expectedBest: []float64{5, 4, 3, 2, 1}, expectedWorst: []float64{1, 2, 3, 4, 5}},
+	}
+
+	for _, tc := range cases {
+		fitness := []float64{3, 1, 5, 2, 4}
+		individuals := make([]*Individual, len(fitness))
+		for i, f := range fitness {
+			individuals[i] = &Individual{Genotype: MustNewGenotype(4), Phenotype: &Phenotype{Fitness: f}}
+		}
+		p := &Population{Individuals: individuals, Minimize: tc.minimize}
+		original := append([]*Individual(nil), individuals...)
+
+		for name, got := range map[string][]*Individual{"best": p.GetNthBest(tc.n), "worst": p.GetNthWorst(tc.n)} {
+			expected := tc.expectedBest
+			if name == "worst" {
+				expected = tc.expectedWorst
+			}
+			if len(got) != len(expected) {
+				t.Fatalf("Expected %d %s individuals, but got %d", len(expected), name, len(got))
+			}
+			for i, ind := range got {
+				if ind.Phenotype.Fitness != expected[i] {
+					t.Errorf("Expected %s individual %d to have fitness %f, but got %f", name, i, expected[i], ind.Phenotype.Fitness)
+				}
+			}
+		}
+		if !reflect.DeepEqual(p.Individuals, original) {
+			t.Errorf("Expected GetNthBest and GetNthWorst not to modify the population order")
+		}
+		if tc.n > 0 && p.GetNthBest(1)[0] != p.GetBestIndividual() {
+			t.Errorf("Expected GetNthBest(1) to return the best individual")
+		}
+		if all := p.GetNthBest(p.Size()); !sameIndividuals(all, p.Individuals) {
+			t.Errorf("Expected GetNthBest(Size()) to hold the individuals of the population")
+		}
+	}
+}
+
+// sameIndividuals reports whether a and b hold the same individuals, in any order.
+func sameIndividuals(a, b []*Individual) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[*Individual]int)
+	for _, ind := range a {
+		counts[ind]++
+	}
+	for _, ind := range b {
+		counts[ind]--
+	}
+	for _, count := range counts {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		population   []*Individual
+		minFitness   float64
+		expectedSize int
+	}{
+		{
+			population: []*Individual{
+				{Phenotype: &Phenotype{Fitness: 1.0}},
+				{Phenotype: &Phenotype{Fitness: 2.0}},
+				{Phenotype: &Phenotype{Fitness: 3.0}},
+				{Phenotype: &Phenotype{Fitness: 4.0}},
+			},
+			minFitness:   2.5,
+			expectedSize: 2,
+		},
+		{
+			population: []*Individual{
+				{Phenotype: &Phenotype{Fitness: 1.0}},
+				{Phenotype: &Phenotype{Fitness: 2.0}},
+			},
+			minFitness:   5.0,
+			expectedSize: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		p := NewPopulation(tc.population)
+		original := append([]*Individual(nil), p.Individuals...)
+
+		filtered := p.Filter(tc.minFitness)
+
+		if filtered.Size() != tc.expectedSize {
+			t.Fatalf("Expected filtered size %d, but got %d", tc.expectedSize, filtered.Size())
+		}
+		if filtered.Size() > 0 && filtered.Statistics.WorstFitness < tc.minFitness {
+			t.Errorf("Expected minimum fitness of at least %f, but got %f", tc.minFitness, filtered.Statistics.WorstFitness)
+		}
+		if p.Size() != len(original) {
+			t.Fatalf("Expected original population size %d, but got %d", len(original), p.Size())
+		}
+		for i, ind := range p.Individuals {
+			if ind != original[i] {
+				t.Errorf("Expected original population to be unmodified at index %d", i)
+			}
+		}
+	}
+}
+
+func TestFilterFunc(t *testing.T) {
+	p := NewPopulation([]*Individual{
+		{Genotype: &Genotype{Genome: []byte{1, 1}}, Phenotype: &Phenotype{Fitness: 2.0}},
+		{Genotype: &Genotype{Genome: []byte{0, 1}}, Phenotype: &Phenotype{Fitness: 1.0}},
+		{Genotype: &Genotype{Genome: []byte{1, 0}}, Phenotype: &Phenotype{Fitness: 1.0}},
+	})
+
+	filtered := p.FilterFunc(func(ind *Individual) bool { return ind.Genotype.Genome[0] == 1 })
+
+	if filtered.Size() != 2 {
+		t.Fatalf("Expected filtered size %d, but got %d", 2, filtered.Size())
+	}
+	for _, ind := range filtered.Individuals {
+		if ind.Genotype.Genome[0] != 1 {
+			t.Errorf("Expected only individuals with a leading 1, but got %v", ind.Genotype.Genome)
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	individuals := make([]*Individual, 10)
+	for i := range individuals {
+		individuals[i] = &Individual{Genotype: MustNewGenotype(4), Phenotype: &Phenotype{Fitness: float64(i)}}
+	}
+	population := NewPopulation(individuals)
+	original := append([]*Individual(nil), individuals...)
+
+	cases := []struct {
+		n            int
+		expectedSize int
+	}{
+		{n: 0, expectedSize: 0},
+		{n: 4, expectedSize: 4},
+		{n: 10, expectedSize: 10},
+		{n: 15, expectedSize: 10},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range cases {
+		sample := population.Sample(tc.n, rng)
+
+		if sample.Size() != tc.expectedSize {
+			t.Fatalf("Expected sample size %d for n = %d, but got %d", tc.expectedSize, tc.n, sample.Size())
+		}
+		seen := make(map[*Individual]bool, sample.Size())
+		for _, ind := range sample.Individuals {
+			if seen[ind] {
+				t.Errorf("Expected no duplicates for n = %d, but individual %v was sampled twice", tc.n, ind.Phenotype.Fitness)
+			}
+			seen[ind] = true
+			if !slices.Contains(original, ind) {
+				t.Errorf("Expected sampled individuals to come from the population for n = %d", tc.n)
+			}
+		}
+		if sample.Size() > 0 && sample.Statistics.PopulationSize != sample.Size() {
+			t.Errorf("Expected statistics of the sample, but got population size %d", sample.Statistics.PopulationSize)
+		}
+	}
+	if !reflect.DeepEqual(population.Individuals, original) {
+		t.Errorf("Expected the original population to be unchanged")
+	}
+}
+
+func TestMergePopulations(t *testing.T) {
+	a := NewPopulation([]*Individual{
+		{Genotype: &Genotype{Genome: []byte{1, 1}}, Phenotype: &Phenotype{Fitness: 2.0}},
+		{Genotype: &Genotype{Genome: []byte{0, 1}}, Phenotype: &Phenotype{Fitness: 1.0}},
+	})
+	b := NewPopulation([]*Individual{
+		{Genotype: &Genotype{Genome: []byte{1, 1}}, Phenotype: &Phenotype{Fitness: 2.0}},
+		{Genotype: &Genotype{Genome: []byte{0, 0}}, Phenotype: &Phenotype{Fitness: 0.0}},
+		{Genotype: &Genotype{Genome: []byte{1, 0}}, Phenotype: &Phenotype{Fitness: 1.0}},
+	})
+
+	merged := MergePopulations(a, b)
+
+	if merged.Size() != a.Size()+b.Size() {
+		t.Fatalf("Expected merged size %d, but got %d", a.Size()+b.Size(), merged.Size())
+	}
+	if a.Size() != 2 || b.Size() != 3 {
+		t.Errorf("Expected input populations to be unmodified, but got sizes %d and %d", a.Size(), b.Size())
+	}
+	if merged.Statistics.BestFitness != 2.0 || merged.Statistics.WorstFitness != 0.0 {
+		t.Errorf("Expected merged statistics to cover both populations, but got %+v", merged.Statistics)
+	}
+}
+
+func TestMergeUnique(t *testing.T) {
+	cases := []struct {
+		a            []*Individual
+		b            []*Individual
+		expectedSize int
+	}{
+		{
+			a: []*Individual{
+				{Genotype: &Genotype{Genome: []byte{1, 1}}, Phenotype: &Phenotype{Fitness: 2.0}},
+				{Genotype: &Genotype{Genome: []byte{0, 1}}, Phenotype: &Phenotype{Fitness: 1.0}},
+			},
+			b: []*Individual{
+				{Genotype: &Genotype{Genome: []byte{1, 1}}, Phenotype: &Phenotype{Fitness: 2.0}},
+				{Genotype: &Genotype{Genome: []byte{0, 1}}, Phenotype: &Phenotype{Fitness: 1.0}},
+			},
+			expectedSize: 2,
+		},
+		{
+			a: []*Individual{
+				{Genotype: &Genotype{Genome: []byte{1, 1}}, Phenotype: &Phenotype{Fitness: 2.0}},
+			},
+			b: []*Individual{
+				{Genotype: &Genotype{Genome: []byte{0, 0}}, Phenotype: &Phenotype{Fitness: 0.0}},
+			},
+			expectedSize: 2,
+		},
+		{
+			a: []*Individual{
+				{Genotype: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{0.5, 1.5}}, Phenotype: &Phenotype{Fitness: 2.0}},
+				{Genotype: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{-1, 2}}, Phenotype: &Phenotype{Fitness: 1.0}},
+			},
+			b: []*Individual{
+				{Genotype: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{0.5, 1.5}}, Phenotype: &Phenotype{Fitness: 2.0}},
+				{Genotype: &Genotype{GenomeType: Float64Encoding, Float64Genome: []float64{3, 4}}, Phenotype: &Phenotype{Fitness: 0.0}},
+			},
+			expectedSize: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		a := NewPopulation(tc.a)
+		b := NewPopulation(tc.b)
+
+		merged := MergeUnique(a, b)
+
+		if merged.Size() != tc.expectedSize {
+			t.Fatalf("Expected merged size %d, but got %d", tc.expectedSize, merged.Size())
+		}
+		if a.Size() != len(tc.a) || b.Size() != len(tc.b) {
+			t.Errorf("Expected input populations to be unmodified, but got sizes %d and %d", a.Size(), b.Size())
+		}
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	individuals := make([]*Individual, 20)
+	for i := range individuals {
+		individuals[i] = &Individual{Genotype: MustNewGenotype(16), Phenotype: &Phenotype{Fitness: 0.0}}
+	}
+	first := individuals[0]
+	population := NewPopulation(individuals)
+
+	evaluations := 0
+	population.Deduplicate(func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, func(genotype *Genotype) *Phenotype {
+		evaluations++
+		return oneMax(genotype)
+	})
+
+	if population.Individuals[0] != first {
+		t.Errorf("Expected the first individual of a genotype to be kept")
+	}
+	if evaluations != len(individuals)-1 {
+		t.Errorf("Expected %d evaluations, but got %d", len(individuals)-1, evaluations)
+	}
+	for i, a := range population.Individuals {
+		for _, b := range population.Individuals[i+1:] {
+			if a.GenotypeEqual(b) {
+				t.Fatalf("Expected distinct genotypes, but %v is duplicated", a.Genotype.Genome)
+			}
+		}
+	}
+	if population.Statistics.BestFitness == 0 {
+		t.Errorf("Expected the statistics to be recalculated, but got best fitness %f", population.Statistics.BestFitness)
+	}
+}
+
+func TestHammingDiversity(t *testing.T) {
+	cases := []struct {
+		genomes  [][]byte
+		expected float64
+	}{
+		{genomes: [][]byte{{0, 1, 0, 1}, {1, 0, 1, 0}}, expected: 1.0},
+		{genomes: [][]byte{{0, 1, 0, 1}, {0, 1, 0, 1}, {0, 1, 0, 1}}, expected: 0.0},
+		{genomes: [][]byte{{0, 0}, {0, 1}, {1, 1}}, expected: 2.0 / 3.0},
+		{genomes: [][]byte{{0, 0}, {0, 0, 1, 1}}, expected: 0.5},
+		{genomes: [][]byte{{0, 1}}, expected: 0.0},
+	}
+
+	for _, tc := range cases {
+		individuals := make([]*Individual, len(tc.genomes))
+		for i, genome := range tc.genomes {
+			individuals[i] = &Individual{Genotype: &Genotype{Genome: genome}, Phenotype: &Phenotype{}}
+		}
+
+		if got := HammingDiversity(individuals); math.Abs(got-tc.expected) > 1e-12 {
+			t.Errorf("Expected Hamming diversity %f for %v, but got %f", tc.expected, tc.genomes, got)
+		}
+	}
+}
+
+func TestGenotypicDiversity(t *testing.T) {
+	// Complementary genomes with equal fitness have no phenotypic diversity at all.
+	p := NewPopulation([]*Individual{
+		{Genotype: &Genotype{Genome: []byte{0, 1, 0, 1}}, Phenotype: &Phenotype{Fitness: 2.0}},
+		{Genotype: &Genotype{Genome: []byte{1, 0, 1, 0}}, Phenotype: &Phenotype{Fitness: 2.0}},
+	})
+
+	if p.Statistics.Diversity != 0 {
+		t.Errorf("Expected fitness diversity 0, but got %f", p.Statistics.Diversity)
+	}
+	if math.Abs(p.Statistics.GenotypicDiversity-1.0) > 1e-12 {
+		t.Errorf("Expected genotypic diversity close to 1.0, but got %f", p.Statistics.GenotypicDiversity)
+	}
+
+	realValued := NewPopulation([]*Individual{
+		{Genotype: MustNewRealGenotype(2, []float64{0, 0}, []float64{1, 1}), Phenotype: &Phenotype{}},
+		{Genotype: MustNewRealGenotype(2, []float64{0, 0}, []float64{1, 1}), Phenotype: &Phenotype{}},
+	})
+	if realValued.Statistics.GenotypicDiversity != 0 {
+		t.Errorf("Expected no genotypic diversity for real-valued genotypes, but got %f", realValued.Statistics.GenotypicDiversity)
+	}
+}
+
+func TestAlleleFrequencies(t *testing.T) {
+	const (
+		genomeLength   = 32
+		populationSize = 2000
+	)
+
+	converged := make([]*Individual, 10)
+	for i := range converged {
+		converged[i] = &Individual{Genotype: &Genotype{Genome: []byte{1, 0, 1, 1, 0}}, Phenotype: &Phenotype{}}
+	}
+	p := NewPopulation(converged)
+
+	if positions := p.ConvergedPositions(); len(positions) != 5 {
+		t.Errorf("Expected all %d positions to be converged, but got %v", 5, positions)
+	}
+	for i, frequency := range p.Statistics.AlleleFrequencies {
+		if expected := float64(converged[0].Genotype.Genome[i]); frequency != expected {
+			t.Errorf("Expected allele frequency %f at position %d, but got %f", expected, i, frequency)
+		}
+	}
+
+	random := make([]*Individual, populationSize)
+	for i := range random {
+		random[i] = &Individual{Genotype: MustVariableLengthBinaryGenotype(genomeLength, genomeLength), Phenotype: &Phenotype{}}
+	}
+	p = NewPopulation(random)
+
+	if len(p.Statistics.AlleleFrequencies) != genomeLength {
+		t.Fatalf("Expected %d allele frequencies, but got %d", genomeLength, len(p.Statistics.AlleleFrequencies))
+	}
+	for i, frequency := range p.Statistics.AlleleFrequencies {
+		if math.Abs(frequency-0.5) > 0.1 {
+			t.Errorf("Expected allele frequency near 0.5 at position %d, but got %f", i, frequency)
+		}
+	}
+	if positions := p.ConvergedPositions(); len(positions) != 0 {
+		t.Errorf("Expected no converged positions in a random population, but got %v", positions)
+	}
+
+	// Converging the population after its statistics were calculated is seen once it is
+	// marked dirty.
+	for _, ind := range random {
+		copy(ind.Genotype.Genome, converged[0].Genotype.Genome)
+	}
+	p.MarkDirty()
+	if positions := p.ConvergedPositions(); len(positions) != 5 {
+		t.Errorf("Expected the first %d positions to be converged after MarkDirty, but got %v", 5, positions)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	individuals := make([]*Individual, 101)
+	for i := range individuals {
+		// Shuffle the fitness values so that percentiles depend on sorting.
+		individuals[i] = &Individual{Phenotype: &Phenotype{Fitness: float64((i * 37) % 101)}}
+	}
+	stats := NewPopulation(individuals).Statistics
+
+	cases := []struct {
+		percentile float64
+		expected   float64
+	}{
+		{percentile: 0.05, expected: 5},
+		{percentile: 0.25, expected: 25},
+		{percentile: 0.50, expected: 50},
+		{percentile: 0.75, expected: 75},
+		{percentile: 0.95, expected: 95},
+	}
+
+	for _, tc := range cases {
+		if got := stats.Percentiles[tc.percentile]; math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("Expected P%.0f %f, but got %f", tc.percentile*100, tc.expected, got)
+		}
+	}
+	if stats.Percentiles[0.50] < stats.Percentiles[0.25] || stats.Percentiles[0.50] > stats.Percentiles[0.75] {
+		t.Errorf("Expected P50 between P25 and P75, but got %v", stats.Percentiles)
+	}
+
+	interpolated := NewPopulation([]*Individual{
+		{Phenotype: &Phenotype{Fitness: 0}},
+		{Phenotype: &Phenotype{Fitness: 10}},
+	}).Statistics
+	if got := interpolated.Percentiles[0.25]; got != 2.5 {
+		t.Errorf("Expected interpolated P25 %f, but got %f", 2.5, got)
+	}
+}
+
+func TestFitnessHistogram(t *testing.T) {
+	cases := []struct {
+		fitness        []float64
+		bins           int
+		expectedCounts []int
+	}{
+		{fitness: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, bins: 5, expectedCounts: []int{2, 2, 2, 2, 3}},
+		{fitness: []float64{3, 3, 3}, bins: 4, expectedCounts: []int{0, 0, 0, 3}},
+		{fitness: []float64{-1, 1}, bins: 1, expectedCounts: []int{2}},
+	}
+
+	for _, tc := range cases {
+		individuals := make([]*Individual, len(tc.fitness))
+		for i, fitness := range tc.fitness {
+			individuals[i] = &Individual{Phenotype: &Phenotype{Fitness: fitness}}
+		}
+		p := NewPopulation(individuals)
+
+		edges, counts := p.FitnessHistogram(tc.bins)
+
+		if len(edges) != tc.bins+1 {
+			t.Fatalf("Expected %d bin edges, but got %d", tc.bins+1, len(edges))
+		}
+		total := 0
+		for i, count := range counts {
+			total += count
+			if count != tc.expectedCounts[i] {
+				t.Errorf("Expected count %d in bin %d, but got %d", tc.expectedCounts[i], i, count)
+			}
+		}
+		if total != p.Size() {
+			t.Errorf("Expected bin counts to sum to %d, but got %d", p.Size(), total)
+		}
+	}
+}
+
+func TestLazyStatistics(t *testing.T) {
+	const generations = 10
+	ga := &GA{
+		Selection:   func(population []*Individual) []*Individual { return population },
+		Crossover:   func(population []*Individual, _ float64) []*Individual { return population },
+		Mutation:    func([]*Individual, float64) {},
+		Generations: generations,
+		// The restart check reads the statistics of every generation before History does.
+		MinDiversity:    1e-9,
+		RestartFraction: 0.5,
+	}
+	if err := ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if _, _, err := ga.Evolve(oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	ga.statistics()
+
+	// History records the statistics of the initial population and of every generation, and
+	// every other read within a generation reuses them.
+	if expected := generations + 1; ga.population.calculations != expected {
+		t.Errorf("Expected %d calculations, but got %d", expected, ga.population.calculations)
+	}
+}
+
+func TestGetStatisticsAfterChanges(t *testing.T) {
+	population := NewPopulation([]*Individual{
+		{Phenotype: &Phenotype{Fitness: 1.0}},
+		{Phenotype: &Phenotype{Fitness: 2.0}},
+	})
+
+	cases := []struct {
+		change               func()
+		expectedBest         float64
+		expectedCalculations int
+	}{
+		{change: func() {}, expectedBest: 2.0, expectedCalculations: 1},
+		{change: func() { population.Add(&Individual{Phenotype: &Phenotype{Fitness: 5.0}}) }, expectedBest: 5.0, expectedCalculations: 2},
+		{change: func() { population.Individuals[0].Phenotype.Fitness = 7.0 }, expectedBest: 5.0, expectedCalculations: 2},
+		{change: population.MarkDirty, expectedBest: 7.0, expectedCalculations: 3},
+	}
+
+	for _, tc := range cases {
+		tc.change()
+
+		if best := population.GetStatistics().BestFitness; best != tc.expectedBest {
+			t.Errorf("Expected best fitness %f, but got %f", tc.expectedBest, best)
+		}
+		if best := population.GetBestIndividual().Phenotype.Fitness; best != tc.expectedBest {
+			t.Errorf("Expected the best individual to have fitness %f, but got %f", tc.expectedBest, best)
+		}
+		if population.calculations != tc.expectedCalculations {
+			t.Errorf("Expected %d calculations, but got %d", tc.expectedCalculations, population.calculations)
+		}
+	}
+}
+
+func TestReplace(t *testing.T) {
+	cases := []struct {
+		index       int
+		expectedErr error
+	}{
+		{index: -1, expectedErr: ErrIndexOutOfRange},
+		{index: 0},
+		{index: 1},
+		{index: 2, expectedErr: ErrIndexOutOfRange},
+	}
+
+	for _, tc := range cases {
+		population := NewPopulation([]*Individual{
+			{Phenotype: &Phenotype{Fitness: 1.0}},
+			{Phenotype: &Phenotype{Fitness: 2.0}},
+		})
+		replacement := &Individual{Phenotype: &Phenotype{Fitness: 5.0}}
+
+		err := population.Replace(tc.index, replacement)
+		if !errors.Is(err, tc.expectedErr) {
+			t.Fatalf("Expected error %v for index %d, but got %v", tc.expectedErr, tc.index, err)
+		}
+		if err != nil {
+			continue
+		}
+		if population.Individuals[tc.index] != replacement {
+			t.Errorf("Expected the individual at index %d to be replaced", tc.index)
+		}
+		if best := population.GetStatistics().BestFitness; best != 5.0 {
+			t.Errorf("Expected best fitness %f after replacing index %d, but got %f", 5.0, tc.index, best)
+		}
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	individuals := func(fitnesses ...float64) []*Individual {
+		population := make([]*Individual, len(fitnesses))
+		for i, fitness := range fitnesses {
+			population[i] = &Individual{Phenotype: &Phenotype{Fitness: fitness}}
+		}
+		return population
+	}
+
+	cases := []struct {
+		replacement  []*Individual
+		expectedErr  error
+		expectedBest float64
+	}{
+		{replacement: individuals(3.0, 4.0), expectedBest: 4.0},
+		{replacement: individuals(3.0), expectedErr: ErrPopulationSizeMismatch, expectedBest: 2.0},
+		{replacement: individuals(3.0, 4.0, 5.0), expectedErr: ErrPopulationSizeMismatch, expectedBest: 2.0},
+	}
+
+	for _, tc := range cases {
+		population := NewPopulation(individuals(1.0, 2.0))
+
+		if err := population.ReplaceAll(tc.replacement); !errors.Is(err, tc.expectedErr) {
+			t.Errorf("Expected error %v for %d individuals, but got %v", tc.expectedErr, len(tc.replacement), err)
+		}
+		if best := population.GetStatistics().BestFitness; best != tc.expectedBest {
+			t.Errorf("Expected best fitness %f, but got %f", tc.expectedBest, best)
+		}
+	}
+}
+
+func TestCalculateStatisticsMinimize(t *testing.T) {
+	cases := []struct {
+		minimize      bool
+		expectedBest  float64
+		expectedWorst float64
+	}{
+		{minimize: false, expectedBest: 3.0, expectedWorst: 1.0},
+		{minimize: true, expectedBest: 1.0, expectedWorst: 3.0},
+	}
+
+	for _, tc := range cases {
+		population := &Population{
+			Individuals: []*Individual{
+				{Phenotype: &Phenotype{Fitness: 2.0}},
+				{Phenotype: &Phenotype{Fitness: 1.0}},
+				{Phenotype: &Phenotype{Fitness: 3.0}},
+			},
+			Minimize: tc.minimize,
+		}
+		stats := population.GetStatistics()
+
+		if stats.BestFitness != tc.expectedBest || stats.WorstFitness != tc.expectedWorst {
+			t.Errorf("Expected best %f and worst %f with minimize %t, but got %f and %f", tc.expectedBest, tc.expectedWorst, tc.minimize, stats.BestFitness, stats.WorstFitness)
+		}
+		if best := population.GetBestIndividual().Phenotype.Fitness; best != tc.expectedBest {
+			t.Errorf("Expected the best individual to have fitness %f, but got %f", tc.expectedBest, best)
+		}
+	}
+}
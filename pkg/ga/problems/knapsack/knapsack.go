@@ -0,0 +1,74 @@
+// Package knapsack provides helpers for solving the 0/1 knapsack problem with genetic
+// algorithms, such as a penalized evaluator over binary genotypes.
+package knapsack
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// KnapsackInstance describes a 0/1 knapsack problem: choose a subset of the items whose total
+// weight does not exceed Capacity, maximizing their total value. Item i has weight Weights[i]
+// and value Values[i].
+type KnapsackInstance struct {
+	Weights  []float64
+	Values   []float64
+	Capacity float64
+}
+
+// KnapsackEval returns an evaluator for binary genotypes whose gene i is 1 if item i is
+// selected and 0 otherwise.
+//
+// The fitness is the total value of the selected items. If their total weight exceeds the
+// capacity, the fitness is reduced by the excess weight times the largest value-to-weight
+// ratio of any item, so that an overweight selection is never fitter than the selection
+// without the items responsible for the excess.
+//
+// Parameters:
+// - instance: the knapsack problem to evaluate selections for.
+//
+// Returns:
+// - The evaluator.
+func KnapsackEval(instance *KnapsackInstance) func(*ga.Genotype) *ga.Phenotype {
+	penaltyWeight := 0.0
+	for i, weight := range instance.Weights {
+		if weight > 0 {
+			penaltyWeight = math.Max(penaltyWeight, instance.Values[i]/weight)
+		}
+	}
+
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		value, weight := 0.0, 0.0
+		for i, gene := range genotype.Genome {
+			if gene == 1 {
+				value += instance.Values[i]
+				weight += instance.Weights[i]
+			}
+		}
+		if excess := weight - instance.Capacity; excess > 0 {
+			value -= penaltyWeight * excess
+		}
+		return &ga.Phenotype{Fitness: value}
+	}
+}
+
+// RandomKnapsack creates a random knapsack instance whose weights and values are drawn
+// uniformly from [1, 100) and whose capacity is half the total weight of the items.
+//
+// Parameters:
+// - n: the number of items.
+// - rng: the source of randomness, so that instances can be reproduced.
+//
+// Returns:
+// - A pointer to the newly created KnapsackInstance.
+func RandomKnapsack(n int, rng *rand.Rand) *KnapsackInstance {
+	instance := &KnapsackInstance{Weights: make([]float64, n), Values: make([]float64, n)}
+	for i := 0; i < n; i++ {
+		instance.Weights[i] = 1 + 99*rng.Float64()
+		instance.Values[i] = 1 + 99*rng.Float64()
+		instance.Capacity += instance.Weights[i] / 2
+	}
+	return instance
+}
@@ -0,0 +1,77 @@
+package knapsack
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// smallInstance has the unique optimal selection {1, 2, 3, 4}, of weight 8 and value 15.
+func smallInstance() *KnapsackInstance {
+	return &KnapsackInstance{
+		Weights:  []float64{12, 2, 1, 1, 4},
+		Values:   []float64{4, 2, 1, 2, 10},
+		Capacity: 15,
+	}
+}
+
+func TestKnapsackEval(t *testing.T) {
+	eval := KnapsackEval(smallInstance())
+
+	cases := []struct {
+		genome   []byte
+		expected float64
+	}{
+		{genome: []byte{0, 0, 0, 0, 0}, expected: 0},
+		{genome: []byte{0, 1, 1, 1, 1}, expected: 15},
+		{genome: []byte{1, 1, 1, 0, 0}, expected: 7},
+		{genome: []byte{1, 1, 1, 1, 1}, expected: 19 - 2.5*5},
+	}
+
+	for _, tc := range cases {
+		if got := eval(&ga.Genotype{Genome: tc.genome}).Fitness; got != tc.expected {
+			t.Errorf("Expected fitness %f for %v, but got %f", tc.expected, tc.genome, got)
+		}
+	}
+}
+
+func TestEvolveKnapsack(t *testing.T) {
+	eval := KnapsackEval(smallInstance())
+	g := &ga.GA{
+		Selection:      func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 2) },
+		Crossover:      ga.UniformCrossover,
+		Mutation:       ga.BitFlipMutation,
+		CrossoverRate:  0.8,
+		MutationRate:   0.1,
+		Generations:    200,
+		HallOfFameSize: 1,
+	}
+	g.Initialize(20, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(5, 5) }, eval)
+	g.Evolve(eval)
+
+	best := g.HallOfFame[0]
+	if expected := []byte{0, 1, 1, 1, 1}; !reflect.DeepEqual(best.Genotype.Genome, expected) {
+		t.Errorf("Expected the optimal selection %v, but got %v with fitness %f", expected, best.Genotype.Genome, best.Phenotype.Fitness)
+	}
+}
+
+func TestRandomKnapsack(t *testing.T) {
+	a := RandomKnapsack(10, rand.New(rand.NewSource(1)))
+	b := RandomKnapsack(10, rand.New(rand.NewSource(1)))
+
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected instances from equal seeds to be equal, but got %v and %v", a, b)
+	}
+	total := 0.0
+	for i := range a.Weights {
+		if a.Weights[i] < 1 || a.Weights[i] >= 100 || a.Values[i] < 1 || a.Values[i] >= 100 {
+			t.Errorf("Expected weights and values in [1, 100), but got %f and %f", a.Weights[i], a.Values[i])
+		}
+		total += a.Weights[i]
+	}
+	if a.Capacity != total/2 {
+		t.Errorf("Expected capacity %f, but got %f", total/2, a.Capacity)
+	}
+}
@@ -0,0 +1,109 @@
+// Package tsp provides helpers for solving the traveling salesman problem with genetic
+// algorithms, such as tour length evaluation over permutation genotypes.
+package tsp
+
+import (
+	"math"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// DistanceMatrix holds the distance between every pair of cities: the distance from city i to
+// city j is at row i, column j.
+type DistanceMatrix [][]float64
+
+// Size returns the number of cities.
+func (dm *DistanceMatrix) Size() int {
+	return len(*dm)
+}
+
+// TourLength returns the length of the closed tour visiting the cities in the given order
+// and returning to the first.
+//
+// Parameters:
+// - tour: the cities in the order they are visited.
+//
+// Returns:
+// - The total length of the tour.
+func (dm *DistanceMatrix) TourLength(tour []int) float64 {
+	length := 0.0
+	for i, city := range tour {
+		length += (*dm)[city][tour[(i+1)%len(tour)]]
+	}
+	return length
+}
+
+// TourLengthEval returns an evaluator for permutation genotypes whose genes are the cities in
+// the order they are visited, held either in the byte Genome or, with LargePermutationEncoding,
+// in the Int32Genome.
+//
+// Parameters:
+// - dm: the distances between the cities.
+//
+// Returns:
+// - An evaluator whose fitness is the negated tour length, so that maximizing the fitness
+// minimizes the tour length.
+func TourLengthEval(dm *DistanceMatrix) func(*ga.Genotype) *ga.Phenotype {
+	return func(genotype *ga.Genotype) *ga.Phenotype {
+		return &ga.Phenotype{Fitness: -dm.TourLength(decodeTour(genotype))}
+	}
+}
+
+// decodeTour returns the cities of a permutation genotype in the order they are visited.
+func decodeTour(genotype *ga.Genotype) []int {
+	if genotype.GenomeType == ga.LargePermutationEncoding {
+		tour := make([]int, len(genotype.Int32Genome))
+		for i, city := range genotype.Int32Genome {
+			tour[i] = int(city)
+		}
+		return tour
+	}
+	tour := make([]int, len(genotype.Genome))
+	for i, city := range genotype.Genome {
+		tour[i] = int(city)
+	}
+	return tour
+}
+
+// NearestNeighborSeed builds a tour with the nearest neighbor heuristic, starting at city 0
+// and always moving to the closest unvisited city, for use as a seed individual that
+// warm-starts the GA.
+//
+// Parameters:
+// - dm: the distances between the cities.
+//
+// Returns:
+// - A permutation genotype of the tour, with byte genes for up to 256 cities and with
+// LargePermutationEncoding otherwise.
+func NearestNeighborSeed(dm *DistanceMatrix) *ga.Genotype {
+	n := dm.Size()
+	tour := make([]int, 0, n)
+	visited := make([]bool, n)
+	for city := 0; n > 0; {
+		tour = append(tour, city)
+		visited[city] = true
+		if len(tour) == n {
+			break
+		}
+		next, nearest := -1, math.Inf(1)
+		for candidate, distance := range (*dm)[city] {
+			if !visited[candidate] && (next < 0 || distance < nearest) {
+				next, nearest = candidate, distance
+			}
+		}
+		city = next
+	}
+
+	if n > math.MaxUint8+1 {
+		genome := make([]int32, n)
+		for i, city := range tour {
+			genome[i] = int32(city)
+		}
+		return &ga.Genotype{Int32Genome: genome, GenomeType: ga.LargePermutationEncoding}
+	}
+	genome := make([]byte, n)
+	for i, city := range tour {
+		genome[i] = byte(city)
+	}
+	return &ga.Genotype{Genome: genome}
+}
@@ -0,0 +1,73 @@
+package tsp
+
+import (
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// rectangle returns the exact distances between the cities (0, 0), (3, 0), (3, 4), (0, 4), and
+// (0, 2). Visiting them in order is an optimal tour of length 14.
+func rectangle() *DistanceMatrix {
+	return &DistanceMatrix{
+		{0, 3, 5, 4, 2},
+		{3, 0, 4, 5, 3.605551275463989},
+		{5, 4, 0, 3, 3.605551275463989},
+		{4, 5, 3, 0, 2},
+		{2, 3.605551275463989, 3.605551275463989, 2, 0},
+	}
+}
+
+func TestTourLengthEval(t *testing.T) {
+	eval := TourLengthEval(rectangle())
+
+	cases := []struct {
+		genotype *ga.Genotype
+		expected float64
+	}{
+		{genotype: &ga.Genotype{Genome: []byte{0, 1, 2, 3, 4}}, expected: -14},
+		{genotype: &ga.Genotype{Genome: []byte{2, 1, 0, 4, 3}}, expected: -14},
+		{genotype: &ga.Genotype{Genome: []byte{0, 2, 1, 3, 4}}, expected: -18},
+		{genotype: &ga.Genotype{Int32Genome: []int32{3, 4, 0, 1, 2}, GenomeType: ga.LargePermutationEncoding}, expected: -14},
+	}
+
+	for _, tc := range cases {
+		if got := eval(tc.genotype).Fitness; got != tc.expected {
+			t.Errorf("Expected fitness %f for tour %v, but got %f", tc.expected, tc.genotype, got)
+		}
+	}
+}
+
+func TestNearestNeighborSeed(t *testing.T) {
+	dm := rectangle()
+	seed := NearestNeighborSeed(dm)
+
+	expected := []byte{0, 4, 3, 2, 1}
+	if string(seed.Genome) != string(expected) {
+		t.Fatalf("Expected tour %v, but got %v", expected, seed.Genome)
+	}
+	if length := -TourLengthEval(dm)(seed).Fitness; length != 14 {
+		t.Errorf("Expected tour length %f, but got %f", 14.0, length)
+	}
+}
+
+func TestNearestNeighborSeedLarge(t *testing.T) {
+	const n = 300
+	dm := DistanceMatrix(newDistanceMatrix(n))
+	for i := range dm {
+		for j := range dm[i] {
+			dm[i][j] = float64((i - j) * (i - j))
+		}
+	}
+
+	seed := NearestNeighborSeed(&dm)
+
+	if seed.GenomeType != ga.LargePermutationEncoding || len(seed.Int32Genome) != n {
+		t.Fatalf("Expected a large permutation of %d cities, but got type %d with %d genes", n, seed.GenomeType, len(seed.Int32Genome))
+	}
+	for i, city := range seed.Int32Genome {
+		if int(city) != i {
+			t.Fatalf("Expected city %d at position %d, but got %d", i, i, city)
+		}
+	}
+}
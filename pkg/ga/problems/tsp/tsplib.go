@@ -0,0 +1,200 @@
+// Package tsp provides helpers for solving the traveling salesman problem with genetic
+// algorithms, such as loading instances in the TSPLIB format.
+package tsp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidTSPLIB is returned by LoadTSPLIB when the input is not a well-formed TSPLIB instance.
+	ErrInvalidTSPLIB = errors.New("invalid TSPLIB instance")
+	// ErrUnsupportedTSPLIB is returned by LoadTSPLIB when the instance uses an edge weight type
+	// or format that is not supported.
+	ErrUnsupportedTSPLIB = errors.New("unsupported TSPLIB instance")
+)
+
+// tsplibInstance holds the parts of a TSPLIB instance that LoadTSPLIB needs.
+type tsplibInstance struct {
+	dimension        int
+	edgeWeightType   string
+	edgeWeightFormat string
+	coordinates      [][2]float64
+	weights          []float64
+}
+
+// LoadTSPLIB reads a symmetric traveling salesman instance in the TSPLIB .tsp format.
+//
+// Cities given by coordinates in a NODE_COORD_SECTION are supported with the EUC_2D, CEIL_2D,
+// and ATT edge weight types, whose distances are rounded as TSPLIB specifies. Explicit
+// distances in an EDGE_WEIGHT_SECTION are supported in the FULL_MATRIX, UPPER_ROW,
+// LOWER_ROW, UPPER_DIAG_ROW, and LOWER_DIAG_ROW formats. Other sections are ignored.
+//
+// Parameters:
+// - r: the reader to read the instance from.
+//
+// Returns:
+// - A pointer to the distance matrix of the instance.
+// - An error wrapping ErrInvalidTSPLIB or ErrUnsupportedTSPLIB if the instance cannot be loaded,
+// or the error of the reader.
+func LoadTSPLIB(r io.Reader) (*DistanceMatrix, error) {
+	instance, err := parseTSPLIB(r)
+	if err != nil {
+		return nil, err
+	}
+	if instance.dimension <= 0 {
+		return nil, fmt.Errorf("%w: missing or invalid DIMENSION", ErrInvalidTSPLIB)
+	}
+
+	if instance.edgeWeightType == "EXPLICIT" {
+		return explicitDistances(instance)
+	}
+	return coordinateDistances(instance)
+}
+
+// parseTSPLIB reads the specification and the data sections of a TSPLIB instance.
+func parseTSPLIB(r io.Reader) (*tsplibInstance, error) {
+	instance := &tsplibInstance{}
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if _, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			switch section {
+			case "NODE_COORD_SECTION":
+				if len(fields) < 3 {
+					return nil, fmt.Errorf("%w: malformed coordinates %q", ErrInvalidTSPLIB, scanner.Text())
+				}
+				x, errX := strconv.ParseFloat(fields[1], 64)
+				y, errY := strconv.ParseFloat(fields[2], 64)
+				if errX != nil || errY != nil {
+					return nil, fmt.Errorf("%w: malformed coordinates %q", ErrInvalidTSPLIB, scanner.Text())
+				}
+				instance.coordinates = append(instance.coordinates, [2]float64{x, y})
+			case "EDGE_WEIGHT_SECTION":
+				for _, field := range fields {
+					weight, err := strconv.ParseFloat(field, 64)
+					if err != nil {
+						return nil, fmt.Errorf("%w: malformed edge weight %q", ErrInvalidTSPLIB, field)
+					}
+					instance.weights = append(instance.weights, weight)
+				}
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(scanner.Text(), ":")
+		key, value = strings.ToUpper(strings.TrimSpace(key)), strings.ToUpper(strings.TrimSpace(value))
+		section = key
+		switch key {
+		case "DIMENSION":
+			dimension, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid DIMENSION %q", ErrInvalidTSPLIB, value)
+			}
+			instance.dimension = dimension
+		case "EDGE_WEIGHT_TYPE":
+			instance.edgeWeightType = value
+		case "EDGE_WEIGHT_FORMAT":
+			instance.edgeWeightFormat = value
+		case "EOF":
+			return instance, nil
+		}
+	}
+	return instance, scanner.Err()
+}
+
+// coordinateDistances computes the distance matrix of an instance whose cities are given by
+// their coordinates.
+func coordinateDistances(instance *tsplibInstance) (*DistanceMatrix, error) {
+	var distance func(a, b [2]float64) float64
+	switch instance.edgeWeightType {
+	case "EUC_2D":
+		distance = func(a, b [2]float64) float64 { return math.Floor(math.Hypot(a[0]-b[0], a[1]-b[1]) + 0.5) }
+	case "CEIL_2D":
+		distance = func(a, b [2]float64) float64 { return math.Ceil(math.Hypot(a[0]-b[0], a[1]-b[1])) }
+	case "ATT":
+		distance = func(a, b [2]float64) float64 {
+			r := math.Hypot(a[0]-b[0], a[1]-b[1]) / math.Sqrt(10)
+			t := math.Floor(r + 0.5)
+			if t < r {
+				t++
+			}
+			return t
+		}
+	default:
+		return nil, fmt.Errorf("%w: EDGE_WEIGHT_TYPE %q", ErrUnsupportedTSPLIB, instance.edgeWeightType)
+	}
+	if len(instance.coordinates) != instance.dimension {
+		return nil, fmt.Errorf("%w: expected %d coordinates, but got %d", ErrInvalidTSPLIB, instance.dimension, len(instance.coordinates))
+	}
+
+	dm := newDistanceMatrix(instance.dimension)
+	for i, a := range instance.coordinates {
+		for j, b := range instance.coordinates {
+			if i != j {
+				dm[i][j] = distance(a, b)
+			}
+		}
+	}
+	return &dm, nil
+}
+
+// explicitDistances builds the distance matrix of an instance whose distances are given
+// explicitly in an EDGE_WEIGHT_SECTION.
+func explicitDistances(instance *tsplibInstance) (*DistanceMatrix, error) {
+	n := instance.dimension
+	// entries lists the row and column of each weight in the order of the format, which for
+	// the triangular formats is mirrored to the other triangle.
+	var entries [][2]int
+	for i := 0; i < n; i++ {
+		var from, to int
+		switch instance.edgeWeightFormat {
+		case "FULL_MATRIX":
+			from, to = 0, n
+		case "UPPER_ROW":
+			from, to = i+1, n
+		case "UPPER_DIAG_ROW":
+			from, to = i, n
+		case "LOWER_ROW":
+			from, to = 0, i
+		case "LOWER_DIAG_ROW":
+			from, to = 0, i+1
+		default:
+			return nil, fmt.Errorf("%w: EDGE_WEIGHT_FORMAT %q", ErrUnsupportedTSPLIB, instance.edgeWeightFormat)
+		}
+		for j := from; j < to; j++ {
+			entries = append(entries, [2]int{i, j})
+		}
+	}
+	if len(instance.weights) != len(entries) {
+		return nil, fmt.Errorf("%w: expected %d edge weights, but got %d", ErrInvalidTSPLIB, len(entries), len(instance.weights))
+	}
+
+	dm := newDistanceMatrix(n)
+	for k, entry := range entries {
+		dm[entry[0]][entry[1]] = instance.weights[k]
+		if instance.edgeWeightFormat != "FULL_MATRIX" {
+			dm[entry[1]][entry[0]] = instance.weights[k]
+		}
+	}
+	return &dm, nil
+}
+
+// newDistanceMatrix returns an n by n distance matrix of zeros.
+func newDistanceMatrix(n int) DistanceMatrix {
+	dm := make(DistanceMatrix, n)
+	for i := range dm {
+		dm[i] = make([]float64, n)
+	}
+	return dm
+}
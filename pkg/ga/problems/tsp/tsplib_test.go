@@ -0,0 +1,111 @@
+package tsp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadTSPLIB(t *testing.T) {
+	expected := &DistanceMatrix{
+		{0, 3, 5, 4, 2},
+		{3, 0, 4, 5, 4},
+		{5, 4, 0, 3, 4},
+		{4, 5, 3, 0, 2},
+		{2, 4, 4, 2, 0},
+	}
+
+	cases := []struct {
+		name     string
+		instance string
+	}{
+		{
+			name: "EUC_2D",
+			instance: `NAME : rectangle
+TYPE : TSP
+DIMENSION : 5
+EDGE_WEIGHT_TYPE : EUC_2D
+NODE_COORD_SECTION
+1 0 0
+2 3 0
+3 3 4
+4 0 4
+5 0 2
+EOF
+`,
+		},
+		{
+			name: "FULL_MATRIX",
+			instance: `NAME: rectangle
+TYPE: TSP
+DIMENSION: 5
+EDGE_WEIGHT_TYPE: EXPLICIT
+EDGE_WEIGHT_FORMAT: FULL_MATRIX
+EDGE_WEIGHT_SECTION
+0 3 5 4 2
+3 0 4 5 4
+5 4 0 3 4
+4 5 3 0 2
+2 4 4 2 0
+EOF
+`,
+		},
+		{
+			name: "UPPER_ROW",
+			instance: `DIMENSION: 5
+EDGE_WEIGHT_TYPE: EXPLICIT
+EDGE_WEIGHT_FORMAT: UPPER_ROW
+EDGE_WEIGHT_SECTION
+3 5 4 2 4 5 4 3 4 2
+`,
+		},
+		{
+			name: "LOWER_DIAG_ROW",
+			instance: `DIMENSION: 5
+EDGE_WEIGHT_TYPE: EXPLICIT
+EDGE_WEIGHT_FORMAT: LOWER_DIAG_ROW
+EDGE_WEIGHT_SECTION
+0
+3 0
+5 4 0
+4 5 3 0
+2 4 4 2 0
+EOF
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		dm, err := LoadTSPLIB(strings.NewReader(tc.instance))
+		if err != nil {
+			t.Fatalf("%s: Expected no error, but got %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(dm, expected) {
+			t.Errorf("%s: Expected distances %v, but got %v", tc.name, *expected, *dm)
+		}
+		if length := dm.TourLength([]int{0, 1, 2, 3, 4}); length != 14 {
+			t.Errorf("%s: Expected optimal tour length %f, but got %f", tc.name, 14.0, length)
+		}
+	}
+}
+
+func TestLoadTSPLIBErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		instance string
+		expected error
+	}{
+		{name: "missing dimension", instance: "EDGE_WEIGHT_TYPE: EUC_2D\nNODE_COORD_SECTION\n1 0 0\n", expected: ErrInvalidTSPLIB},
+		{name: "missing coordinates", instance: "DIMENSION: 2\nEDGE_WEIGHT_TYPE: EUC_2D\nNODE_COORD_SECTION\n1 0 0\n", expected: ErrInvalidTSPLIB},
+		{name: "malformed weight", instance: "DIMENSION: 2\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEDGE_WEIGHT_SECTION\n1 x\n", expected: ErrInvalidTSPLIB},
+		{name: "GEO", instance: "DIMENSION: 1\nEDGE_WEIGHT_TYPE: GEO\nNODE_COORD_SECTION\n1 0 0\n", expected: ErrUnsupportedTSPLIB},
+		{name: "FUNCTION", instance: "DIMENSION: 1\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: FUNCTION\n", expected: ErrUnsupportedTSPLIB},
+	}
+
+	for _, tc := range cases {
+		if _, err := LoadTSPLIB(strings.NewReader(tc.instance)); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: Expected error %v, but got %v", tc.name, tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Package qd provides quality-diversity archives for genetic algorithms, which collect
+// high-quality solutions spread across a space of behaviors rather than a single optimum.
+package qd
+
+// Elite is the constraint on the individuals stored in a MAPElites archive.
+// It is satisfied by *ga.Individual.
+type Elite[T any] interface {
+	// Fitness returns the fitness of the individual.
+	Fitness() float64
+	// Clone returns a deep copy of the individual.
+	Clone() T
+}
+
+// MAPElites is a MAP-Elites archive: a grid over a two-dimensional behavior space in which
+// each cell holds the fittest individual seen with that behavior.
+//
+// BehaviorFunc maps an individual to the grid cell describing its behavior. Minimize, if
+// set, makes lower fitness better, so that each cell keeps the individual with the lowest
+// fitness instead.
+type MAPElites[T Elite[T]] struct {
+	Grid         map[[2]int]T
+	BehaviorFunc func(T) [2]int
+	Minimize     bool
+}
+
+// NewMAPElites creates a new MAPElites archive with an empty grid.
+//
+// Parameters:
+// - behavior: a function mapping an individual to the grid cell describing its behavior.
+//
+// Returns:
+// - A pointer to the newly created MAPElites archive.
+func NewMAPElites[T Elite[T]](behavior func(T) [2]int) *MAPElites[T] {
+	return &MAPElites[T]{
+		Grid:         make(map[[2]int]T),
+		BehaviorFunc: behavior,
+	}
+}
+
+// Update places a copy of the individual into the cell given by BehaviorFunc if the cell
+// is empty or the individual is fitter than the cell's current elite. A copy is stored so
+// that later modifications of the individual do not alter the archive.
+//
+// Parameters:
+// - ind: the individual to consider for the archive.
+//
+// Returns:
+// - true if the individual was placed into the grid, false otherwise.
+func (m *MAPElites[T]) Update(ind T) bool {
+	if m.Grid == nil {
+		m.Grid = make(map[[2]int]T)
+	}
+	cell := m.BehaviorFunc(ind)
+	if elite, ok := m.Grid[cell]; ok && !m.fitter(ind.Fitness(), elite.Fitness()) {
+		return false
+	}
+	m.Grid[cell] = ind.Clone()
+	return true
+}
+
+// fitter reports whether fitness a is strictly better than fitness b.
+//
+// Parameters:
+// - a: the fitness to compare.
+// - b: the fitness to compare against.
+//
+// Returns:
+// - true if a is greater than b, or less than b if Minimize is set.
+func (m *MAPElites[T]) fitter(a, b float64) bool {
+	if m.Minimize {
+		return a < b
+	}
+	return a > b
+}
+
+// BestInCell returns the elite of the given cell.
+//
+// Parameters:
+// - cell: the grid cell.
+//
+// Returns:
+// - The elite of the cell, or the zero value of T (nil for pointers) if the cell is empty.
+func (m *MAPElites[T]) BestInCell(cell [2]int) T {
+	return m.Grid[cell]
+}
+
+// AllElites returns the elites of all occupied cells, in no particular order.
+func (m *MAPElites[T]) AllElites() []T {
+	elites := make([]T, 0, len(m.Grid))
+	for _, elite := range m.Grid {
+		elites = append(elites, elite)
+	}
+	return elites
+}
+
+// CoverageRatio returns the fraction of the grid's cells that are occupied.
+//
+// Parameters:
+// - totalCells: the total number of cells in the grid.
+//
+// Returns:
+// - The number of occupied cells divided by totalCells, or 0 if totalCells is not positive.
+func (m *MAPElites[T]) CoverageRatio(totalCells int) float64 {
+	if totalCells <= 0 {
+		return 0
+	}
+	return float64(len(m.Grid)) / float64(totalCells)
+}
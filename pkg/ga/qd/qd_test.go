@@ -0,0 +1,91 @@
+package qd_test
+
+import (
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"github.com/Okabe-Junya/gago/pkg/ga/qd"
+)
+
+// newIndividual returns an individual with a two-gene genome and the given fitness.
+func newIndividual(x, y byte, fitness float64) *ga.Individual {
+	return &ga.Individual{Genotype: &ga.Genotype{Genome: []byte{x, y}}, Phenotype: &ga.Phenotype{Fitness: fitness}}
+}
+
+// geneCell uses the two genes of the genome as the grid cell.
+func geneCell(ind *ga.Individual) [2]int {
+	return [2]int{int(ind.Genotype.Genome[0]), int(ind.Genotype.Genome[1])}
+}
+
+func TestMAPElitesUpdate(t *testing.T) {
+	archive := qd.NewMAPElites(geneCell)
+
+	cases := []struct {
+		ind      *ga.Individual
+		expected bool
+		fitness  float64
+	}{
+		{ind: newIndividual(0, 0, 1), expected: true, fitness: 1},
+		{ind: newIndividual(0, 0, 0.5), expected: false, fitness: 1},
+		{ind: newIndividual(0, 0, 2), expected: true, fitness: 2},
+		{ind: newIndividual(1, 0, 0), expected: true, fitness: 0},
+	}
+
+	for _, tc := range cases {
+		if got := archive.Update(tc.ind); got != tc.expected {
+			t.Errorf("Expected Update to return %v, but got %v", tc.expected, got)
+		}
+		if got := archive.BestInCell(geneCell(tc.ind)).Fitness(); got != tc.fitness {
+			t.Errorf("Expected elite fitness %f, but got %f", tc.fitness, got)
+		}
+	}
+
+	if len(archive.AllElites()) != 2 {
+		t.Errorf("Expected %d elites, but got %d", 2, len(archive.AllElites()))
+	}
+	if got := archive.CoverageRatio(4); got != 0.5 {
+		t.Errorf("Expected coverage %f, but got %f", 0.5, got)
+	}
+	if archive.BestInCell([2]int{5, 5}) != nil {
+		t.Errorf("Expected empty cell to have no elite")
+	}
+}
+
+func TestMAPElitesStoresCopy(t *testing.T) {
+	archive := qd.NewMAPElites(geneCell)
+	ind := newIndividual(0, 0, 1)
+	archive.Update(ind)
+
+	ind.Genotype.Genome[1] = 1
+	ind.Phenotype.Fitness = 5
+
+	elite := archive.BestInCell([2]int{0, 0})
+	if elite.Genotype.Genome[1] != 0 || elite.Fitness() != 1 {
+		t.Errorf("Expected the archive to be unaffected by changes to the original individual")
+	}
+}
+
+func TestMAPElitesMinimize(t *testing.T) {
+	archive := qd.NewMAPElites(geneCell)
+	archive.Minimize = true
+
+	cases := []struct {
+		ind      *ga.Individual
+		expected bool
+		fitness  float64
+	}{
+		{ind: newIndividual(0, 0, 1), expected: true, fitness: 1},
+		{ind: newIndividual(0, 0, 2), expected: false, fitness: 1},
+		{ind: newIndividual(0, 0, 1), expected: false, fitness: 1},
+		{ind: newIndividual(0, 0, 0.5), expected: true, fitness: 0.5},
+	}
+
+	for _, tc := range cases {
+		if got := archive.Update(tc.ind); got != tc.expected {
+			t.Errorf("Expected Update to return %v, but got %v", tc.expected, got)
+		}
+		if got := archive.BestInCell(geneCell(tc.ind)).Fitness(); got != tc.fitness {
+			t.Errorf("Expected elite fitness %f, but got %f", tc.fitness, got)
+		}
+	}
+}
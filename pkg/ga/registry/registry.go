@@ -0,0 +1,116 @@
+// Package registry provides a generic, name-based registry for genetic operators,
+// allowing operators to be selected at runtime by name rather than by function reference.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownOperator is returned when an operator name has not been registered.
+var ErrUnknownOperator = errors.New("unknown operator")
+
+// Registry maps operator names to operators of type F. It is safe for concurrent use.
+type Registry[F any] struct {
+	kind      string
+	mu        sync.RWMutex
+	operators map[string]F
+}
+
+// New creates a new, empty Registry.
+//
+// Parameters:
+// - kind: a description of the kind of operator held by the registry, used in error messages.
+//
+// Returns:
+// - A pointer to the newly created Registry.
+func New[F any](kind string) *Registry[F] {
+	return &Registry[F]{
+		kind:      kind,
+		operators: make(map[string]F),
+	}
+}
+
+// Register registers an operator under the given name, replacing any operator
+// previously registered under that name.
+//
+// Parameters:
+// - name: the name under which the operator is registered.
+// - fn: the operator to register.
+func (r *Registry[F]) Register(name string, fn F) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operators[name] = fn
+}
+
+// Get returns the operator registered under the given name.
+//
+// Parameters:
+// - name: the name of the operator to look up.
+//
+// Returns:
+// - The registered operator.
+// - An error wrapping ErrUnknownOperator if no operator is registered under the name.
+func (r *Registry[F]) Get(name string) (F, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, ok := r.operators[name]
+	if !ok {
+		return fn, fmt.Errorf("%w: no %s operator named %q (registered: %s)", ErrUnknownOperator, r.kind, name, strings.Join(r.names(), ", "))
+	}
+	return fn, nil
+}
+
+// Name returns the name under which the given operator is registered. Operators are
+// compared by their code, so that any closure created by the same function literal as a
+// registered operator resolves to its name.
+//
+// Parameters:
+// - fn: the operator to look up.
+//
+// Returns:
+// - The first name, in sorted order, under which the operator is registered.
+// - Whether the operator is registered.
+func (r *Registry[F]) Name(fn F) (string, bool) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return "", false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, name := range r.names() {
+		if reflect.ValueOf(r.operators[name]).Pointer() == v.Pointer() {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Names returns the sorted names of all registered operators.
+func (r *Registry[F]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.names()
+}
+
+func (r *Registry[F]) names() []string {
+	names := make([]string, 0, len(r.operators))
+	for name := range r.operators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OperatorRegistry groups the registries of selection operators of type S, crossover
+// operators of type C, and mutation operators of type M from which a GA resolves its
+// operators by name. A nil registry stands for the default one of the resolving package.
+type OperatorRegistry[S, C, M any] struct {
+	Selection *Registry[S]
+	Crossover *Registry[C]
+	Mutation  *Registry[M]
+}
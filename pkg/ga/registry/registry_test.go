@@ -0,0 +1,77 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := New[func(int) int]("test")
+	r.Register("double", func(x int) int { return 2 * x })
+	r.Register("square", func(x int) int { return x * x })
+
+	cases := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{name: "double", input: 3, expected: 6},
+		{name: "square", input: 3, expected: 9},
+	}
+
+	for _, tc := range cases {
+		fn, err := r.Get(tc.name)
+		if err != nil {
+			t.Fatalf("Expected operator %q to be registered, but got error: %v", tc.name, err)
+		}
+		if got := fn(tc.input); got != tc.expected {
+			t.Errorf("Expected %d, but got %d", tc.expected, got)
+		}
+	}
+
+	if names := r.Names(); strings.Join(names, ",") != "double,square" {
+		t.Errorf("Expected sorted names [double square], but got %v", names)
+	}
+}
+
+func TestRegistryUnknownOperator(t *testing.T) {
+	r := New[func(int) int]("test")
+	r.Register("double", func(x int) int { return 2 * x })
+
+	_, err := r.Get("triple")
+	if err == nil {
+		t.Fatalf("Expected an error for an unregistered operator, but got nil")
+	}
+	if !errors.Is(err, ErrUnknownOperator) {
+		t.Errorf("Expected error to wrap ErrUnknownOperator, but got %v", err)
+	}
+	for _, want := range []string{"test", "triple", "double"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error %q to mention %q", err.Error(), want)
+		}
+	}
+}
+
+func TestRegistryName(t *testing.T) {
+	double := func(x int) int { return 2 * x }
+	r := New[func(int) int]("test")
+	r.Register("double", double)
+
+	cases := []struct {
+		name     string
+		fn       func(int) int
+		expected string
+		ok       bool
+	}{
+		{name: "registered", fn: double, expected: "double", ok: true},
+		{name: "unregistered", fn: func(x int) int { return x * x }, ok: false},
+		{name: "nil", fn: nil, ok: false},
+	}
+
+	for _, tc := range cases {
+		if name, ok := r.Name(tc.fn); name != tc.expected || ok != tc.ok {
+			t.Errorf("%s: Expected (%q, %t), but got (%q, %t)", tc.name, tc.expected, tc.ok, name, ok)
+		}
+	}
+}
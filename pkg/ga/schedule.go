@@ -0,0 +1,45 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including schedules that vary the crossover rate over the generations.
+package ga
+
+import "math"
+
+// LinearDecaySchedule returns a crossover rate schedule, for use as GA.CrossoverRateSchedule,
+// that interpolates linearly from start at the first generation to end at the last.
+//
+// Parameters:
+// - start: the crossover rate at generation zero.
+// - end: the crossover rate at generation maxGen-1.
+//
+// Returns:
+// - The crossover rate schedule.
+func LinearDecaySchedule(start, end float64) func(int, int) float64 {
+	return func(gen, maxGen int) float64 {
+		return start + (end-start)*scheduleProgress(gen, maxGen)
+	}
+}
+
+// CosineAnnealingSchedule returns a crossover rate schedule, for use as GA.CrossoverRateSchedule,
+// that follows half a cosine wave from start at the first generation to end at the last, so
+// that the rate changes slowly at both ends and fastest midway.
+//
+// Parameters:
+// - start: the crossover rate at generation zero.
+// - end: the crossover rate at generation maxGen-1.
+//
+// Returns:
+// - The crossover rate schedule.
+func CosineAnnealingSchedule(start, end float64) func(int, int) float64 {
+	return func(gen, maxGen int) float64 {
+		return end + (start-end)*(1+math.Cos(math.Pi*scheduleProgress(gen, maxGen)))/2
+	}
+}
+
+// scheduleProgress returns the fraction of the evolution completed at the zero-based
+// generation gen of maxGen, from 0 at the first generation to 1 at the last, clamped to [0, 1].
+func scheduleProgress(gen, maxGen int) float64 {
+	if maxGen <= 1 {
+		return 1
+	}
+	return math.Min(math.Max(float64(gen)/float64(maxGen-1), 0), 1)
+}
@@ -0,0 +1,65 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCrossoverRateSchedules(t *testing.T) {
+	cases := []struct {
+		name     string
+		schedule func(int, int) float64
+		gen      int
+		expected float64
+	}{
+		{name: "linear start", schedule: LinearDecaySchedule(0.9, 0.1), gen: 0, expected: 0.9},
+		{name: "linear middle", schedule: LinearDecaySchedule(0.9, 0.1), gen: 5, expected: 0.5},
+		{name: "linear end", schedule: LinearDecaySchedule(0.9, 0.1), gen: 10, expected: 0.1},
+		{name: "cosine start", schedule: CosineAnnealingSchedule(0.9, 0.1), gen: 0, expected: 0.9},
+		{name: "cosine middle", schedule: CosineAnnealingSchedule(0.9, 0.1), gen: 5, expected: 0.5},
+		{name: "cosine end", schedule: CosineAnnealingSchedule(0.9, 0.1), gen: 10, expected: 0.1},
+	}
+
+	for _, tc := range cases {
+		if got := tc.schedule(tc.gen, 11); math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("%s: Expected rate %f, but got %f", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestEvolveWithCrossoverRateSchedule(t *testing.T) {
+	schedules := map[string]func(int, int) float64{
+		"LinearDecaySchedule":     LinearDecaySchedule(0.9, 0.2),
+		"CosineAnnealingSchedule": CosineAnnealingSchedule(0.9, 0.2),
+	}
+
+	for name, schedule := range schedules {
+		var rates []float64
+		ga := &GA{
+			Selection: func(population []*Individual) []*Individual { return population },
+			Crossover: func(population []*Individual, crossoverRate float64) []*Individual {
+				rates = append(rates, crossoverRate)
+				return population
+			},
+			Mutation:              func([]*Individual, float64) {},
+			CrossoverRate:         0.5,
+			Generations:           30,
+			CrossoverRateSchedule: schedule,
+		}
+		ga.Initialize(10, func() *Genotype { return MustNewGenotype(8) }, oneMax)
+		ga.Evolve(oneMax)
+
+		if len(rates) != ga.Generations {
+			t.Fatalf("%s: Expected %d crossovers, but got %d", name, ga.Generations, len(rates))
+		}
+		if math.Abs(rates[0]-0.9) > 1e-9 {
+			t.Errorf("%s: Expected rate %f at the first generation, but got %f", name, 0.9, rates[0])
+		}
+		if last := rates[len(rates)-1]; math.Abs(last-0.2) > 1e-9 {
+			t.Errorf("%s: Expected rate %f at the last generation, but got %f", name, 0.2, last)
+		}
+		if ga.CrossoverRate != 0.5 {
+			t.Errorf("%s: Expected CrossoverRate to stay %f, but got %f", name, 0.5, ga.CrossoverRate)
+		}
+	}
+}
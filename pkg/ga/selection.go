@@ -3,7 +3,11 @@
 // to create the next generation.
 package ga
 
-import "math/rand"
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
 
 // TournamentSelection performs tournament selection on the given population.
 //
@@ -63,3 +67,510 @@ func RouletteWheelSelection(population []*Individual) []*Individual {
 	}
 	return selected
 }
+
+// NormalizeFitness rescales the fitness values of the population to [0, 1] by min-max
+// normalization, so that the lowest fitness becomes 0 and the highest 1. If every individual
+// has the same fitness, every fitness becomes 1, so that selection operators that select in
+// proportion to fitness treat them all alike. An individual placed in the population several
+// times is rescaled once.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, whose fitness values are rescaled in place.
+func NormalizeFitness(population []*Individual) {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, ind := range population {
+		lowest = math.Min(lowest, ind.Phenotype.Fitness)
+		highest = math.Max(highest, ind.Phenotype.Fitness)
+	}
+	normalized := make(map[*Individual]bool, len(population))
+	for _, ind := range population {
+		if normalized[ind] {
+			continue
+		}
+		normalized[ind] = true
+		if highest == lowest {
+			ind.Phenotype.Fitness = 1
+		} else {
+			ind.Phenotype.Fitness = (ind.Phenotype.Fitness - lowest) / (highest - lowest)
+		}
+	}
+}
+
+// BoltzmannSelection performs Boltzmann selection on the given population.
+//
+// In Boltzmann selection, each individual is selected with probability proportional to
+// exp(fitness/temperature). A high temperature makes the probabilities nearly uniform, and a
+// low temperature concentrates them on the fittest individuals, so the temperature controls
+// the selection pressure. Unlike roulette wheel selection, negative fitness values are allowed.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - temperature: the positive temperature controlling the selection pressure.
+//
+// Returns:
+// - A new population of selected individuals.
+func BoltzmannSelection(population []*Individual, temperature float64) []*Individual {
+	best := math.Inf(-1)
+	for _, ind := range population {
+		best = math.Max(best, ind.Phenotype.Fitness)
+	}
+	// Shifting by the best fitness keeps the weights within (0, 1] without changing their ratios.
+	weights := make([]float64, len(population))
+	total := 0.0
+	for i, ind := range population {
+		weights[i] = math.Exp((ind.Phenotype.Fitness - best) / temperature)
+		total += weights[i]
+	}
+
+	selected := make([]*Individual, len(population))
+	for i := range selected {
+		pick := rand.Float64() * total
+		selected[i] = population[len(population)-1]
+		for j, weight := range weights {
+			if pick -= weight; pick < 0 {
+				selected[i] = population[j]
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// BoltzmannAnnealingSelection performs Boltzmann selection at a temperature annealed linearly
+// from initialTemp at generation 0 to finalTemp at generation maxGen, so that the selection
+// pressure increases as the evolution progresses.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - initialTemp: the temperature at generation 0.
+// - finalTemp: the temperature at generation maxGen.
+// - gen: the current zero-based generation.
+// - maxGen: the number of generations.
+//
+// Returns:
+// - A new population of selected individuals.
+func BoltzmannAnnealingSelection(population []*Individual, initialTemp, finalTemp float64, gen, maxGen int) []*Individual {
+	temperature := initialTemp
+	if maxGen > 0 {
+		temperature -= (initialTemp - finalTemp) * float64(gen) / float64(maxGen)
+	}
+	return BoltzmannSelection(population, temperature)
+}
+
+// BoltzmannAnnealingSelectionFunc returns a factory of Boltzmann selection operators annealed
+// from initialTemp to finalTemp, suitable for setting the Selection field each generation, for
+// example from OnGeneration.
+//
+// Parameters:
+// - initialTemp: the temperature at generation 0.
+// - finalTemp: the temperature at generation maxGen.
+//
+// Returns:
+// - A function returning the selection operator for the given generation and number of generations.
+func BoltzmannAnnealingSelectionFunc(initialTemp, finalTemp float64) func(gen, maxGen int) func([]*Individual) []*Individual {
+	return func(gen, maxGen int) func([]*Individual) []*Individual {
+		return func(population []*Individual) []*Individual {
+			return BoltzmannAnnealingSelection(population, initialTemp, finalTemp, gen, maxGen)
+		}
+	}
+}
+
+// TournamentSelectionPressure returns a selection pressure metric for tournament selection
+// with the given tournament size.
+//
+// The best individual wins every tournament it enters, so each selection picks it with
+// probability 1 - (1 - 1/n)^k in a population of n. The metric is this probability relative
+// to the average selection probability 1/n, which is about k for large populations.
+//
+// Parameters:
+// - tournamentSize: the number of individuals chosen randomly for each tournament.
+//
+// Returns:
+// - The selection pressure metric, suitable for the SelectionPressureMetric field.
+func TournamentSelectionPressure(tournamentSize int) func([]*Individual) float64 {
+	return func(population []*Individual) float64 {
+		n := float64(len(population))
+		if n == 0 {
+			return 0
+		}
+		return n * (1 - math.Pow(1-1/n, float64(tournamentSize)))
+	}
+}
+
+// RouletteWheelSelectionPressure returns the selection pressure of roulette wheel selection on
+// the given population: the best fitness relative to the average fitness, which is the ratio of
+// the selection probability of the best individual to the average selection probability. It is
+// zero if the total fitness is not positive.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+//
+// Returns:
+// - The selection pressure.
+func RouletteWheelSelectionPressure(population []*Individual) float64 {
+	total, best := 0.0, math.Inf(-1)
+	for _, ind := range population {
+		total += ind.Phenotype.Fitness
+		best = math.Max(best, ind.Phenotype.Fitness)
+	}
+	if total <= 0 {
+		return 0
+	}
+	return best * float64(len(population)) / total
+}
+
+// RankSelectionPressure returns the selection pressure of rank selection, in which individuals
+// are selected with probability proportional to their rank, from 1 for the worst to n for the
+// best. The best individual is selected with probability 2/(n+1), so the pressure is 2n/(n+1)
+// whatever the fitness values, approaching 2 for large populations.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+//
+// Returns:
+// - The selection pressure.
+func RankSelectionPressure(population []*Individual) float64 {
+	n := float64(len(population))
+	return 2 * n / (n + 1)
+}
+
+// ConstrainedTournamentSelection performs tournament selection using Deb's feasibility rules.
+//
+// Contenders are compared as follows: (1) a feasible individual beats an infeasible one,
+// (2) between two feasible individuals the one with higher fitness wins, and (3) between
+// two infeasible individuals the one with the smaller ConstraintViolation wins.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - tournamentSize: the number of individuals to be chosen randomly for each tournament.
+// - feasible: a function reporting whether an individual satisfies all constraints.
+//
+// Returns:
+// - A new population of selected individuals.
+func ConstrainedTournamentSelection(population []*Individual, tournamentSize int, feasible func(*Individual) bool) []*Individual {
+	selected := make([]*Individual, len(population))
+	for i := range selected {
+		best := population[rand.Intn(len(population))]
+		for j := 0; j < tournamentSize-1; j++ {
+			contender := population[rand.Intn(len(population))]
+			if feasibilityBetter(contender, best, feasible) {
+				best = contender
+			}
+		}
+		selected[i] = best
+	}
+	return selected
+}
+
+// feasibilityBetter reports whether a is strictly better than b under Deb's feasibility rules.
+func feasibilityBetter(a, b *Individual, feasible func(*Individual) bool) bool {
+	aFeasible, bFeasible := feasible(a), feasible(b)
+	switch {
+	case aFeasible && bFeasible:
+		return a.Phenotype.Fitness > b.Phenotype.Fitness
+	case aFeasible != bFeasible:
+		return aFeasible
+	default:
+		return a.ConstraintViolation < b.ConstraintViolation
+	}
+}
+
+// StochasticRankingSelection ranks the population by stochastic ranking and returns it in
+// ranked order.
+//
+// Stochastic ranking applies up to len(population) bubble-sort sweeps to the population.
+// Each pair of adjacent individuals is compared by fitness if both are feasible or, otherwise,
+// with probability pf; the remaining comparisons are by constraint violation. A sweep that
+// swaps no pair ends the ranking early. pf balances the objective against the constraints:
+// with pf = 1 the ranking is by fitness alone, and with pf = 0 feasible individuals rank
+// first by fitness, followed by infeasible individuals by increasing violation.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - pf: the probability of comparing two individuals that are not both feasible by fitness.
+// - feasible: a function reporting whether an individual satisfies all constraints.
+// - constraintViolation: a function returning the constraint violation of an individual.
+//
+// Returns:
+// - A new population holding the individuals in ranked order, best first.
+func StochasticRankingSelection(population []*Individual, pf float64, feasible func(*Individual) bool, constraintViolation func(*Individual) float64) []*Individual {
+	ranked := append([]*Individual(nil), population...)
+	for sweep := 0; sweep < len(ranked); sweep++ {
+		swapped := false
+		for j := 0; j < len(ranked)-1; j++ {
+			a, b := ranked[j], ranked[j+1]
+			var worse bool
+			if (feasible(a) && feasible(b)) || rand.Float64() < pf {
+				worse = a.Phenotype.Fitness < b.Phenotype.Fitness
+			} else {
+				worse = constraintViolation(a) > constraintViolation(b)
+			}
+			if worse {
+				ranked[j], ranked[j+1] = b, a
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+	return ranked
+}
+
+// MultiObjectiveSelection performs NSGA-II selection on a population whose phenotypes hold
+// the values of every objective in Objectives, all of which are maximized.
+//
+// The population is sorted into Pareto fronts by non-dominated sorting, and individuals are
+// selected by binary tournaments that prefer the lower front and, within a front, the larger
+// crowding distance, so that selection favors both convergence and spread along the front.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+//
+// Returns:
+// - A new population of selected individuals.
+func MultiObjectiveSelection(population []*Individual) []*Individual {
+	rank := make([]int, len(population))
+	crowding := make([]float64, len(population))
+	for r, front := range fastNonDominatedSort(population) {
+		distances := crowdingDistance(population, front)
+		for k, i := range front {
+			rank[i] = r
+			crowding[i] = distances[k]
+		}
+	}
+
+	selected := make([]*Individual, len(population))
+	for i := range selected {
+		a, b := rand.Intn(len(population)), rand.Intn(len(population))
+		if rank[b] < rank[a] || (rank[b] == rank[a] && crowding[b] > crowding[a]) {
+			a = b
+		}
+		selected[i] = population[a]
+	}
+	return selected
+}
+
+// dominates reports whether objectives a Pareto-dominate objectives b: a is at least as good
+// as b in every objective and strictly better in at least one, all objectives being maximized.
+func dominates(a, b []float64) bool {
+	better := false
+	for k := range a {
+		if a[k] < b[k] {
+			return false
+		}
+		if a[k] > b[k] {
+			better = true
+		}
+	}
+	return better
+}
+
+// nonDominatedSort sorts the population into Pareto fronts by the Objectives of the
+// phenotypes. The first front holds the indices of the individuals that no individual
+// dominates, and each later front those dominated only by individuals of earlier fronts.
+// It compares every pair of individuals, and is kept as the reference for
+// fastNonDominatedSort.
+func nonDominatedSort(population []*Individual) [][]int {
+	dominatedBy := make([]int, len(population))
+	dominating := make([][]int, len(population))
+	var fronts [][]int
+	var front []int
+	for i, a := range population {
+		for j, b := range population {
+			if i == j {
+				continue
+			}
+			if dominates(a.Phenotype.Objectives, b.Phenotype.Objectives) {
+				dominating[i] = append(dominating[i], j)
+			} else if dominates(b.Phenotype.Objectives, a.Phenotype.Objectives) {
+				dominatedBy[i]++
+			}
+		}
+		if dominatedBy[i] == 0 {
+			front = append(front, i)
+		}
+	}
+
+	for len(front) > 0 {
+		fronts = append(fronts, front)
+		var next []int
+		for _, i := range front {
+			for _, j := range dominating[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		front = next
+	}
+	return fronts
+}
+
+// fastNonDominatedSort sorts the population into the same Pareto fronts as nonDominatedSort
+// using efficient non-dominated sorting with sequential search (ENS-SS). The individuals are
+// sorted lexicographically by their objectives, best first, so that none can be dominated by
+// one that follows it; each individual is then added to the first front that none of its
+// members dominates, searching the fronts in order. For typical populations, with few fronts,
+// this needs far fewer domination checks than comparing every pair. The indices of each front
+// are in increasing order.
+func fastNonDominatedSort(population []*Individual) [][]int {
+	order := make([]int, len(population))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		oa, ob := population[order[a]].Phenotype.Objectives, population[order[b]].Phenotype.Objectives
+		for k := range oa {
+			if oa[k] != ob[k] {
+				return oa[k] > ob[k]
+			}
+		}
+		return false
+	})
+
+	var fronts [][]int
+	for _, i := range order {
+		objectives := population[i].Phenotype.Objectives
+		k := 0
+		for ; k < len(fronts); k++ {
+			dominated := false
+			// Later members of a front are the likeliest to dominate, being the closest in order.
+			for m := len(fronts[k]) - 1; m >= 0; m-- {
+				if dominates(population[fronts[k][m]].Phenotype.Objectives, objectives) {
+					dominated = true
+					break
+				}
+			}
+			if !dominated {
+				break
+			}
+		}
+		if k == len(fronts) {
+			fronts = append(fronts, nil)
+		}
+		fronts[k] = append(fronts[k], i)
+	}
+	for _, front := range fronts {
+		sort.Ints(front)
+	}
+	return fronts
+}
+
+// crowdingDistance returns the crowding distance of each individual of a front, in the order
+// of the front. The distance is the sum over the objectives of the normalized gap between
+// the individual's neighbors along that objective; the extremes of each objective get an
+// infinite distance so that they are always preferred.
+func crowdingDistance(population []*Individual, front []int) []float64 {
+	distances := make([]float64, len(front))
+	if len(front) == 0 {
+		return distances
+	}
+
+	order := make([]int, len(front))
+	for m := range population[front[0]].Phenotype.Objectives {
+		for k := range order {
+			order[k] = k
+		}
+		objective := func(k int) float64 { return population[front[order[k]]].Phenotype.Objectives[m] }
+		sort.Slice(order, func(a, b int) bool {
+			return population[front[order[a]]].Phenotype.Objectives[m] < population[front[order[b]]].Phenotype.Objectives[m]
+		})
+
+		distances[order[0]] = math.Inf(1)
+		distances[order[len(order)-1]] = math.Inf(1)
+		spread := objective(len(order)-1) - objective(0)
+		if spread == 0 {
+			continue
+		}
+		for k := 1; k < len(order)-1; k++ {
+			distances[order[k]] += (objective(k+1) - objective(k-1)) / spread
+		}
+	}
+	return distances
+}
+
+// FitnessSharing returns a fitness modifier implementing fitness sharing for the population.
+//
+// The niche count of an individual is the sum of the sharing function over its distances to
+// every individual in the population, including itself. The sharing function maps a distance
+// d to 1 - (d/sigma)^alpha when d < sigma, and to 0 otherwise, so that individuals closer than
+// the niche radius sigma share their fitness. The niche counts are calculated when
+// FitnessSharing is called, and the modifier divides the fitness passed to its i-th call by
+// the niche count of the i-th individual of the population, wrapping around after the last.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - sigma: the niche radius.
+// - alpha: the exponent controlling the shape of the sharing function, typically 1.
+// - distance: a function measuring the distance between two individuals, such as HammingDistance.
+//
+// Returns:
+// - The fitness modifier, to be called with the fitness of each individual in population order.
+func FitnessSharing(population []*Individual, sigma float64, alpha float64, distance func(a, b *Individual) float64) func(float64) float64 {
+	share := sharingFunction(sigma, alpha)
+	nicheCounts := make([]float64, len(population))
+	for i, ind := range population {
+		for _, other := range population {
+			nicheCounts[i] += share(distance(ind, other))
+		}
+	}
+
+	next := 0
+	return func(fitness float64) float64 {
+		if len(nicheCounts) == 0 {
+			return fitness
+		}
+		nicheCount := nicheCounts[next]
+		next = (next + 1) % len(nicheCounts)
+		return fitness / nicheCount
+	}
+}
+
+// ApplyFitnessSharing applies fitness sharing to the given population.
+//
+// Each individual's fitness is divided by its niche count, as calculated by FitnessSharing.
+// This lowers the fitness of individuals in crowded regions so that multiple peaks can be
+// maintained.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - sigma: the niche radius.
+// - alpha: the exponent controlling the shape of the sharing function, typically 1.
+// - distance: a function measuring the distance between two individuals, such as HammingDistance.
+//
+// This function modifies the fitness of the input population in place.
+func ApplyFitnessSharing(population []*Individual, sigma, alpha float64, distance func(*Individual, *Individual) float64) {
+	modify := FitnessSharing(population, sigma, alpha, distance)
+	for _, ind := range population {
+		ind.Phenotype.Fitness = modify(ind.Phenotype.Fitness)
+	}
+}
+
+// sharingFunction returns the sharing function of fitness sharing, which maps the distance d
+// between two individuals to 1 - (d/sigma)^alpha when d < sigma, and to 0 otherwise.
+func sharingFunction(sigma float64, alpha float64) func(float64) float64 {
+	return func(d float64) float64 {
+		if d >= sigma {
+			return 0
+		}
+		return 1 - math.Pow(d/sigma, alpha)
+	}
+}
+
+// HammingDistance returns the number of positions at which the genomes of two individuals
+// differ. If the genomes differ in length, each extra gene counts as a difference.
+func HammingDistance(a, b *Individual) float64 {
+	genomeA, genomeB := a.Genotype.Genome, b.Genotype.Genome
+	if len(genomeA) > len(genomeB) {
+		genomeA, genomeB = genomeB, genomeA
+	}
+	distance := len(genomeB) - len(genomeA)
+	for i := range genomeA {
+		if genomeA[i] != genomeB[i] {
+			distance++
+		}
+	}
+	return float64(distance)
+}
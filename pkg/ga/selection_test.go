@@ -1,7 +1,11 @@
 package ga
 
 import (
+	"errors"
+	"math"
+	"math/rand"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -95,3 +99,555 @@ func TestRouletteWheelSelection(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeFitness(t *testing.T) {
+	cases := []struct {
+		fitness  []float64
+		expected []float64
+	}{
+		{fitness: []float64{-4, -2, -1, -3}, expected: []float64{0, 2.0 / 3, 1, 1.0 / 3}},
+		{fitness: []float64{-1, 0, 1}, expected: []float64{0, 0.5, 1}},
+		{fitness: []float64{-5, -5}, expected: []float64{1, 1}},
+	}
+
+	for _, tc := range cases {
+		population := make([]*Individual, len(tc.fitness))
+		for i, fitness := range tc.fitness {
+			population[i] = &Individual{Phenotype: &Phenotype{Fitness: fitness}}
+		}
+		// A duplicated individual must be rescaled only once.
+		NormalizeFitness(append(population, population[0]))
+
+		for i, ind := range population {
+			if math.Abs(ind.Phenotype.Fitness-tc.expected[i]) > 1e-12 {
+				t.Errorf("Expected fitness %v to be normalized to %v, but got %f at %d", tc.fitness, tc.expected, ind.Phenotype.Fitness, i)
+			}
+		}
+	}
+}
+
+func TestNormalizeFitnessBeforeSelection(t *testing.T) {
+	// All fitness values are negative, which RouletteWheelSelection does not support.
+	negativeOneMax := func(genotype *Genotype) *Phenotype {
+		return &Phenotype{Fitness: oneMax(genotype).Fitness - 100}
+	}
+
+	for _, minimize := range []bool{false, true} {
+		ga := &GA{
+			Selection: func(population []*Individual) []*Individual {
+				for _, ind := range population {
+					if ind.Phenotype.Fitness < 0 || ind.Phenotype.Fitness > 1 {
+						t.Fatalf("Expected normalized fitness in [0, 1], but got %f", ind.Phenotype.Fitness)
+					}
+				}
+				selected := RouletteWheelSelection(population)
+				for i, ind := range selected {
+					if ind == nil {
+						t.Fatalf("Expected a valid individual at %d, but got nil", i)
+					}
+				}
+				return selected
+			},
+			Crossover:                       SinglePointCrossover,
+			Mutation:                        BitFlipMutation,
+			CrossoverRate:                   0.7,
+			MutationRate:                    0.05,
+			Generations:                     10,
+			Minimize:                        minimize,
+			NormalizeFitnessBeforeSelection: true,
+		}
+		ga.Initialize(20, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, negativeOneMax)
+		ga.Evolve(negativeOneMax)
+
+		for _, ind := range ga.Population {
+			if expected := negativeOneMax(ind.Genotype).Fitness; ind.Phenotype.Fitness != expected {
+				t.Errorf("Expected fitness %f to be restored after selection with minimize %t, but got %f", expected, minimize, ind.Phenotype.Fitness)
+			}
+		}
+	}
+}
+
+func TestBoltzmannAnnealingSelection(t *testing.T) {
+	const maxGen, rounds = 10, 200
+	population := make([]*Individual, 10)
+	for i := range population {
+		population[i] = &Individual{Genotype: MustNewGenotype(4), Phenotype: &Phenotype{Fitness: float64(i + 1)}}
+	}
+	selection := BoltzmannAnnealingSelectionFunc(100, 0.5)
+
+	// The mean fitness of the selected individuals measures the selection pressure.
+	pressure := func(gen int) float64 {
+		total := 0.0
+		for round := 0; round < rounds; round++ {
+			for _, ind := range selection(gen, maxGen)(population) {
+				total += ind.Phenotype.Fitness
+			}
+		}
+		return total / float64(rounds*len(population))
+	}
+
+	if first, last := pressure(0), pressure(maxGen-1); first >= last {
+		t.Errorf("Expected the selection pressure to increase from generation 0 to %d, but got mean fitness %f and %f", maxGen-1, first, last)
+	}
+}
+
+func TestSelectionPressure(t *testing.T) {
+	population := newSelectionPopulation(10)
+
+	previous := 0.0
+	for _, tournamentSize := range []int{1, 2, 4, 8} {
+		pressure := TournamentSelectionPressure(tournamentSize)(population)
+		if pressure <= previous {
+			t.Errorf("Expected tournament size %d to have a higher selection pressure than %f, but got %f", tournamentSize, previous, pressure)
+		}
+		previous = pressure
+	}
+	if pressure := TournamentSelectionPressure(1)(population); math.Abs(pressure-1) > 1e-12 {
+		t.Errorf("Expected tournament size 1 to have selection pressure %f, but got %f", 1.0, pressure)
+	}
+
+	cases := []struct {
+		name     string
+		metric   func([]*Individual) float64
+		expected float64
+	}{
+		{name: "roulette wheel", metric: RouletteWheelSelectionPressure, expected: 5.0 / 3.0},
+		{name: "rank", metric: RankSelectionPressure, expected: 20.0 / 11.0},
+	}
+	for _, tc := range cases {
+		if pressure := tc.metric(population); math.Abs(pressure-tc.expected) > 1e-12 {
+			t.Errorf("%s: Expected selection pressure %f, but got %f", tc.name, tc.expected, pressure)
+		}
+	}
+}
+
+func TestSelectionPressureStatistics(t *testing.T) {
+	ga := &GA{
+		Selection:               func(population []*Individual) []*Individual { return TournamentSelection(population, 4) },
+		Crossover:               SinglePointCrossover,
+		Mutation:                BitFlipMutation,
+		Generations:             2,
+		SelectionPressureMetric: TournamentSelectionPressure(4),
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+	ga.Evolve(oneMax)
+
+	expected := TournamentSelectionPressure(4)(ga.Population)
+	for gen, stats := range ga.GetHistory() {
+		if stats.SelectionPressure != expected {
+			t.Errorf("Expected selection pressure %f at generation %d, but got %f", expected, gen, stats.SelectionPressure)
+		}
+	}
+}
+
+func TestConstrainedTournamentSelection(t *testing.T) {
+	// Infeasible individuals have higher fitness, so only the feasibility rules can favor the feasible ones.
+	population := []*Individual{
+		{Phenotype: &Phenotype{Fitness: 1.0}},
+		{Phenotype: &Phenotype{Fitness: 2.0}},
+		{Phenotype: &Phenotype{Fitness: 10.0}, ConstraintViolation: 1.0},
+		{Phenotype: &Phenotype{Fitness: 20.0}, ConstraintViolation: 2.0},
+	}
+	feasible := func(ind *Individual) bool { return ind.ConstraintViolation == 0 }
+
+	selected := ConstrainedTournamentSelection(population, len(population)*4, feasible)
+
+	if len(selected) != len(population) {
+		t.Fatalf("Expected selected length %d, but got %d", len(population), len(selected))
+	}
+	for _, ind := range selected {
+		if !feasible(ind) {
+			t.Errorf("Expected only feasible individuals to be selected, but got %+v", ind)
+		}
+	}
+}
+
+func TestFeasibilityBetter(t *testing.T) {
+	feasible := func(ind *Individual) bool { return ind.ConstraintViolation == 0 }
+
+	cases := []struct {
+		a        *Individual
+		b        *Individual
+		expected bool
+	}{
+		{
+			a:        &Individual{Phenotype: &Phenotype{Fitness: 1.0}},
+			b:        &Individual{Phenotype: &Phenotype{Fitness: 5.0}, ConstraintViolation: 0.1},
+			expected: true,
+		},
+		{
+			a:        &Individual{Phenotype: &Phenotype{Fitness: 2.0}},
+			b:        &Individual{Phenotype: &Phenotype{Fitness: 1.0}},
+			expected: true,
+		},
+		{
+			a:        &Individual{Phenotype: &Phenotype{Fitness: 1.0}, ConstraintViolation: 0.5},
+			b:        &Individual{Phenotype: &Phenotype{Fitness: 5.0}, ConstraintViolation: 0.1},
+			expected: false,
+		},
+		{
+			a:        &Individual{Phenotype: &Phenotype{Fitness: 1.0}, ConstraintViolation: 0.1},
+			b:        &Individual{Phenotype: &Phenotype{Fitness: 5.0}, ConstraintViolation: 0.5},
+			expected: true,
+		},
+	}
+
+	for i, tc := range cases {
+		if got := feasibilityBetter(tc.a, tc.b, feasible); got != tc.expected {
+			t.Errorf("Expected %t for case %d, but got %t", tc.expected, i, got)
+		}
+	}
+}
+
+func TestStochasticRankingSelection(t *testing.T) {
+	feasible := func(ind *Individual) bool { return ind.ConstraintViolation == 0 }
+	violation := func(ind *Individual) float64 { return ind.ConstraintViolation }
+	population := []*Individual{
+		{Phenotype: &Phenotype{Fitness: 5.0}, ConstraintViolation: 0.5},
+		{Phenotype: &Phenotype{Fitness: 1.0}},
+		{Phenotype: &Phenotype{Fitness: 4.0}, ConstraintViolation: 0.1},
+		{Phenotype: &Phenotype{Fitness: 3.0}},
+		{Phenotype: &Phenotype{Fitness: 2.0}, ConstraintViolation: 0.3},
+	}
+
+	cases := []struct {
+		pf       float64
+		expected []float64
+	}{
+		{pf: 1.0, expected: []float64{5.0, 4.0, 3.0, 2.0, 1.0}},
+		{pf: 0.0, expected: []float64{3.0, 1.0, 4.0, 2.0, 5.0}},
+	}
+
+	for _, tc := range cases {
+		ranked := StochasticRankingSelection(population, tc.pf, feasible, violation)
+
+		fitness := make([]float64, len(ranked))
+		for i, ind := range ranked {
+			fitness[i] = ind.Phenotype.Fitness
+		}
+		if !reflect.DeepEqual(fitness, tc.expected) {
+			t.Errorf("Expected ranking %v with pf %.1f, but got %v", tc.expected, tc.pf, fitness)
+		}
+	}
+}
+
+func TestApplyFitnessSharing(t *testing.T) {
+	population := []*Individual{
+		{Genotype: &Genotype{Genome: []byte{1, 1, 1, 1}}, Phenotype: &Phenotype{Fitness: 4.0}},
+		{Genotype: &Genotype{Genome: []byte{1, 1, 1, 1}}, Phenotype: &Phenotype{Fitness: 4.0}},
+		{Genotype: &Genotype{Genome: []byte{0, 0, 0, 0}}, Phenotype: &Phenotype{Fitness: 4.0}},
+	}
+
+	ApplyFitnessSharing(population, 2.0, 1.0, HammingDistance)
+
+	isolated := population[2].Phenotype.Fitness
+	if isolated != 4.0 {
+		t.Errorf("Expected isolated individual to keep fitness %f, but got %f", 4.0, isolated)
+	}
+	for i := 0; i < 2; i++ {
+		if shared := population[i].Phenotype.Fitness; shared != isolated/2 {
+			t.Errorf("Expected shared fitness %f for individual %d, but got %f", isolated/2, i, shared)
+		}
+	}
+}
+
+func TestFitnessSharing(t *testing.T) {
+	population := []*Individual{
+		{Genotype: &Genotype{Genome: []byte{1, 1, 1, 1}}},
+		{Genotype: &Genotype{Genome: []byte{1, 1, 1, 1}}},
+		{Genotype: &Genotype{Genome: []byte{0, 0, 0, 0}}},
+	}
+
+	modify := FitnessSharing(population, 2.0, 1.0, HammingDistance)
+	// The modifier wraps around, so the second pass divides by the same niche counts.
+	expected := []float64{2.0, 2.0, 4.0, 2.0, 2.0, 4.0}
+	for i, want := range expected {
+		if got := modify(4.0); got != want {
+			t.Errorf("Expected shared fitness %f for call %d, but got %f", want, i, got)
+		}
+	}
+
+	if got := FitnessSharing(nil, 2.0, 1.0, HammingDistance)(4.0); got != 4.0 {
+		t.Errorf("Expected an empty population to leave fitness %f unchanged, but got %f", 4.0, got)
+	}
+}
+
+func TestSharingFunction(t *testing.T) {
+	cases := []struct {
+		distance float64
+		expected float64
+	}{
+		{distance: 0.0, expected: 1.0},
+		{distance: 1.0, expected: 0.5},
+		{distance: 2.0, expected: 0.0},
+		{distance: 3.0, expected: 0.0},
+	}
+
+	share := sharingFunction(2.0, 1.0)
+	for _, tc := range cases {
+		if got := share(tc.distance); got != tc.expected {
+			t.Errorf("Expected sharing %f at distance %f, but got %f", tc.expected, tc.distance, got)
+		}
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	cases := []struct {
+		a        []byte
+		b        []byte
+		expected float64
+	}{
+		{a: []byte{1, 0, 1, 0}, b: []byte{1, 0, 1, 0}, expected: 0.0},
+		{a: []byte{1, 0, 1, 0}, b: []byte{0, 1, 0, 1}, expected: 4.0},
+		{a: []byte{1, 0}, b: []byte{1, 1, 1}, expected: 2.0},
+	}
+
+	for _, tc := range cases {
+		got := HammingDistance(&Individual{Genotype: &Genotype{Genome: tc.a}}, &Individual{Genotype: &Genotype{Genome: tc.b}})
+		if got != tc.expected {
+			t.Errorf("Expected distance %f between %v and %v, but got %f", tc.expected, tc.a, tc.b, got)
+		}
+	}
+}
+
+// selectionOperators returns every selection operator, including those in SelectionOperators.
+func selectionOperators() map[string]func([]*Individual) []*Individual {
+	operators := map[string]func([]*Individual) []*Individual{
+		"TournamentSelection": func(population []*Individual) []*Individual { return TournamentSelection(population, 3) },
+		"ConstrainedTournamentSelection": func(population []*Individual) []*Individual {
+			return ConstrainedTournamentSelection(population, 3, func(ind *Individual) bool { return ind.Phenotype.Fitness > 2 })
+		},
+		"StochasticRankingSelection": func(population []*Individual) []*Individual {
+			return StochasticRankingSelection(population, 0.45, func(ind *Individual) bool { return ind.Phenotype.Fitness > 2 },
+				func(ind *Individual) float64 { return math.Max(2-ind.Phenotype.Fitness, 0) })
+		},
+		"GA.ConstrainedTournament": (&GA{}).ConstrainedTournament(3),
+		"BoltzmannSelection":       func(population []*Individual) []*Individual { return BoltzmannSelection(population, 1) },
+	}
+	for _, name := range SelectionOperators.Names() {
+		operators[name], _ = SelectionOperators.Get(name)
+	}
+	return operators
+}
+
+func newSelectionPopulation(size int) []*Individual {
+	population := make([]*Individual, size)
+	for i := range population {
+		population[i] = &Individual{Genotype: MustNewGenotype(4), Phenotype: &Phenotype{Fitness: float64(i%5) + 1}}
+	}
+	return population
+}
+
+func TestSelectionImmutability(t *testing.T) {
+	for name, selection := range selectionOperators() {
+		population := newSelectionPopulation(20)
+		original := append([]*Individual(nil), population...)
+
+		selection(population)
+
+		for i := range population {
+			if population[i] != original[i] {
+				t.Errorf("%s: expected the input population to be unchanged, but individual %d was replaced", name, i)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectionFromPopulation(t *testing.T) {
+	for name, selection := range selectionOperators() {
+		population := newSelectionPopulation(20)
+		members := make(map[*Individual]bool, len(population))
+		for _, ind := range population {
+			members[ind] = true
+		}
+
+		for i, ind := range selection(population) {
+			if !members[ind] {
+				t.Errorf("%s: expected selected individual %d to be from the original population", name, i)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectionParallel(t *testing.T) {
+	population := newSelectionPopulation(50)
+
+	for name, selection := range selectionOperators() {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if selected := selection(population); len(selected) != len(population) {
+					t.Errorf("%s: expected %d selected individuals, but got %d", name, len(population), len(selected))
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestNonDominatedSort(t *testing.T) {
+	objectives := [][]float64{
+		{1, 5}, {2, 4}, {4, 1}, // first front
+		{1, 3}, {3, 1}, // second front
+		{0, 0}, // third front
+	}
+	population := make([]*Individual, len(objectives))
+	for i, o := range objectives {
+		population[i] = &Individual{Phenotype: &Phenotype{Objectives: o}}
+	}
+
+	fronts := nonDominatedSort(population)
+	expected := [][]int{{0, 1, 2}, {3, 4}, {5}}
+
+	if !reflect.DeepEqual(fronts, expected) {
+		t.Errorf("Expected fronts %v, but got %v", expected, fronts)
+	}
+}
+
+// randomObjectivePopulation returns n individuals with m random objectives each. Discrete
+// objectives take few distinct values, so that ties and duplicates are common.
+func randomObjectivePopulation(rng *rand.Rand, n, m int, discrete bool) []*Individual {
+	population := make([]*Individual, n)
+	for i := range population {
+		objectives := make([]float64, m)
+		for k := range objectives {
+			if discrete {
+				objectives[k] = float64(rng.Intn(5))
+			} else {
+				objectives[k] = rng.Float64()
+			}
+		}
+		population[i] = &Individual{Phenotype: &Phenotype{Objectives: objectives}}
+	}
+	return population
+}
+
+func TestFastNonDominatedSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranks := func(fronts [][]int, n int) []int {
+		rank := make([]int, n)
+		for r, front := range fronts {
+			for _, i := range front {
+				rank[i] = r
+			}
+		}
+		return rank
+	}
+
+	for input := 0; input < 100; input++ {
+		n, m, discrete := 1+rng.Intn(100), 2+rng.Intn(3), input%2 == 0
+		population := randomObjectivePopulation(rng, n, m, discrete)
+
+		expected, fronts := nonDominatedSort(population), fastNonDominatedSort(population)
+		if len(fronts) != len(expected) {
+			t.Fatalf("Input %d: Expected %d fronts, but got %d", input, len(expected), len(fronts))
+		}
+		if expectedRanks, gotRanks := ranks(expected, n), ranks(fronts, n); !reflect.DeepEqual(gotRanks, expectedRanks) {
+			t.Fatalf("Input %d: Expected front assignments %v, but got %v", input, expectedRanks, gotRanks)
+		}
+	}
+
+	population := make([]*Individual, 6)
+	for i, o := range [][]float64{{1, 5}, {2, 4}, {4, 1}, {1, 3}, {3, 1}, {0, 0}} {
+		population[i] = &Individual{Phenotype: &Phenotype{Objectives: o}}
+	}
+	if fronts, expected := fastNonDominatedSort(population), [][]int{{0, 1, 2}, {3, 4}, {5}}; !reflect.DeepEqual(fronts, expected) {
+		t.Errorf("Expected fronts %v, but got %v", expected, fronts)
+	}
+}
+
+func TestCrowdingDistance(t *testing.T) {
+	population := []*Individual{
+		{Phenotype: &Phenotype{Objectives: []float64{0, 4}}},
+		{Phenotype: &Phenotype{Objectives: []float64{1, 3}}},
+		{Phenotype: &Phenotype{Objectives: []float64{3, 1}}},
+		{Phenotype: &Phenotype{Objectives: []float64{4, 0}}},
+	}
+
+	distances := crowdingDistance(population, []int{0, 1, 2, 3})
+	expected := []float64{math.Inf(1), 1.5, 1.5, math.Inf(1)}
+
+	if !reflect.DeepEqual(distances, expected) {
+		t.Errorf("Expected crowding distances %v, but got %v", expected, distances)
+	}
+}
+
+// schaffer evaluates Schaffer's bi-objective problem, negated so that both objectives are
+// maximized: its Pareto front is the set of x in [0, 2].
+func schaffer(genotype *Genotype) *Phenotype {
+	x := genotype.GetRealValue(0)
+	objectives := []float64{-x * x, -(x - 2) * (x - 2)}
+	return &Phenotype{Fitness: objectives[0] + objectives[1], Objectives: objectives}
+}
+
+func TestMultiObjectiveSelection(t *testing.T) {
+	// Every individual evaluated during the run is archived, so that the check does not
+	// depend on the last, non-elitist generation having kept the front.
+	var archive []*Individual
+	var xs []float64
+	record := func(population []*Individual) {
+		for _, ind := range population {
+			archive = append(archive, &Individual{Phenotype: &Phenotype{Objectives: append([]float64(nil), ind.Phenotype.Objectives...)}})
+			xs = append(xs, ind.Genotype.GetRealValue(0))
+		}
+	}
+	ga := &GA{
+		Selection: MultiObjectiveSelection,
+		Crossover: func(population []*Individual, crossoverRate float64) []*Individual {
+			return BlendCrossover(population, crossoverRate, 0.5)
+		},
+		Mutation:       GaussianMutation,
+		CrossoverRate:  1.0,
+		MutationRate:   0.5,
+		Generations:    50,
+		ObjectiveCount: 2,
+		OnGeneration:   func(_ int, population []*Individual) { record(population) },
+	}
+	err := ga.Initialize(40, func() *Genotype { return MustNewRealGenotype(1, []float64{-10}, []float64{10}) }, schaffer)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	record(ga.Population)
+	if _, _, err := ga.Evolve(schaffer); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	front := nonDominatedSort(archive)[0]
+	for _, i := range front {
+		if x := xs[i]; x < -0.1 || x > 2.1 {
+			t.Errorf("Expected the first front of all evaluated individuals to lie on the Pareto front [0, 2], but it holds x = %f", x)
+		}
+	}
+	if len(front) < 20 {
+		t.Errorf("Expected at least 20 individuals on the Pareto front, but found %d", len(front))
+	}
+}
+
+func TestObjectiveCountValidation(t *testing.T) {
+	cases := []struct {
+		objectiveCount int
+		expectedErr    error
+	}{
+		{objectiveCount: 2, expectedErr: nil},
+		{objectiveCount: 3, expectedErr: ErrObjectiveCount},
+		{objectiveCount: 0, expectedErr: nil},
+	}
+
+	for _, tc := range cases {
+		ga := &GA{
+			Selection:      MultiObjectiveSelection,
+			Crossover:      SinglePointCrossover,
+			Mutation:       GaussianMutation,
+			Generations:    2,
+			ObjectiveCount: tc.objectiveCount,
+		}
+		err := ga.Initialize(10, func() *Genotype { return MustNewRealGenotype(1, []float64{-10}, []float64{10}) }, schaffer)
+		if !errors.Is(err, tc.expectedErr) {
+			t.Errorf("Expected error %v from Initialize with %d objectives, but got %v", tc.expectedErr, tc.objectiveCount, err)
+		}
+	}
+}
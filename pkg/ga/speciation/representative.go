@@ -0,0 +1,45 @@
+// Package speciation provides speciation for genetic algorithms, clustering the population
+// into species of similar individuals so that distinct niches can evolve independently.
+package speciation
+
+import (
+	"math/rand"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// RepresentativeStrategy chooses the representative a species carries into the next
+// generation, against which the individuals of that generation are compared.
+type RepresentativeStrategy interface {
+	// Representative returns the representative of the species for the next generation.
+	Representative(s Species) *ga.Individual
+}
+
+// OldestRepresentativeStrategy keeps the individual that founded the species as its
+// representative for as long as the species exists.
+type OldestRepresentativeStrategy struct{}
+
+// Representative returns the current representative of the species.
+func (OldestRepresentativeStrategy) Representative(s Species) *ga.Individual {
+	return s.Representative
+}
+
+// RandomRepresentativeStrategy picks a random member of the species as its representative
+// each generation, so that the species follows its members as they move through the search
+// space. If Rng is nil, the global source of math/rand is used.
+type RandomRepresentativeStrategy struct {
+	Rng *rand.Rand
+}
+
+// Representative returns a random member of the species, or its current representative if
+// it has no members.
+func (r RandomRepresentativeStrategy) Representative(s Species) *ga.Individual {
+	if len(s.Members) == 0 {
+		return s.Representative
+	}
+	intn := rand.Intn
+	if r.Rng != nil {
+		intn = r.Rng.Intn
+	}
+	return s.Members[intn(len(s.Members))]
+}
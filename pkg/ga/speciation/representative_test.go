@@ -0,0 +1,59 @@
+package speciation
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+func TestRepresentativeStrategy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	population := make([]*ga.Individual, 30)
+	for i := range population {
+		genome := make([]byte, 20)
+		for j := range genome {
+			genome[j] = byte(rng.Intn(2))
+		}
+		population[i] = &ga.Individual{Genotype: &ga.Genotype{Genome: genome}, Phenotype: &ga.Phenotype{}}
+	}
+
+	cases := []struct {
+		name            string
+		strategy        RepresentativeStrategy
+		expectedChanges bool
+	}{
+		{name: "oldest", strategy: OldestRepresentativeStrategy{}, expectedChanges: false},
+		{name: "random", strategy: RandomRepresentativeStrategy{Rng: rand.New(rand.NewSource(2))}, expectedChanges: true},
+	}
+
+	for _, tc := range cases {
+		var species []Species
+		var previous []int
+		changes := 0
+		for gen := 0; gen < 10; gen++ {
+			species = Speciate(population, species, 8, ga.HammingDistance, tc.strategy)
+
+			for _, s := range species {
+				for _, member := range s.Members {
+					if member.SpeciesID != s.ID {
+						t.Fatalf("%s: Expected members of species %d to have SpeciesID %d, but got %d", tc.name, s.ID, s.ID, member.SpeciesID)
+					}
+				}
+			}
+			composition := make([]int, len(population))
+			for i, ind := range population {
+				composition[i] = ind.SpeciesID
+			}
+			if previous != nil && !slices.Equal(composition, previous) {
+				changes++
+			}
+			previous = composition
+		}
+
+		if (changes > 0) != tc.expectedChanges {
+			t.Errorf("%s: Expected species composition to change to be %t, but it changed in %d of 9 generations", tc.name, tc.expectedChanges, changes)
+		}
+	}
+}
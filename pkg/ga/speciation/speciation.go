@@ -0,0 +1,90 @@
+// Package speciation provides speciation for genetic algorithms, clustering the population
+// into species of similar individuals so that distinct niches can evolve independently.
+package speciation
+
+import "github.com/Okabe-Junya/gago/pkg/ga"
+
+// Species is a cluster of similar individuals.
+//
+// ID identifies the species across generations, starting from one. Representative is the
+// individual new individuals are compared with: they join the species if they are close
+// enough to it. It is the founder of a new species, and is chosen by a
+// RepresentativeStrategy for a species carried over from the previous generation.
+type Species struct {
+	ID             int
+	Representative *ga.Individual
+	Members        []*ga.Individual
+}
+
+// Speciate clusters the population into species using sequential speciation: each
+// individual joins the first species whose representative is closer than sigma, or
+// founds a new species if there is none. The species of the previous generation are
+// considered first, in order, with the representatives chosen by the strategy, and keep
+// their IDs; those left without members are dropped. The SpeciesID of every individual is
+// set to the ID of its species.
+//
+// Parameters:
+// - population: a slice of pointers to Individual, representing the current population.
+// - previous: the species of the previous generation, as returned by Speciate, or nil.
+// - sigma: the distance threshold below which an individual joins a species.
+// - distance: a function returning the distance between two individuals, such as ga.HammingDistance.
+// - strategy: the strategy choosing the representatives of the previous species, or nil
+// for OldestRepresentativeStrategy.
+//
+// Returns:
+// - The species, in order of founding. Every individual is a member of exactly one species.
+func Speciate(population []*ga.Individual, previous []Species, sigma float64, distance func(a, b *ga.Individual) float64, strategy RepresentativeStrategy) []Species {
+	if strategy == nil {
+		strategy = OldestRepresentativeStrategy{}
+	}
+	species := make([]Species, 0, len(previous))
+	nextID := 1
+	for _, s := range previous {
+		species = append(species, Species{ID: s.ID, Representative: strategy.Representative(s)})
+		nextID = max(nextID, s.ID+1)
+	}
+	for _, ind := range population {
+		assigned := false
+		for i := range species {
+			if distance(ind, species[i].Representative) < sigma {
+				species[i].Members = append(species[i].Members, ind)
+				ind.SpeciesID = species[i].ID
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			species = append(species, Species{ID: nextID, Representative: ind, Members: []*ga.Individual{ind}})
+			ind.SpeciesID = nextID
+			nextID++
+		}
+	}
+	surviving := species[:0]
+	for _, s := range species {
+		if len(s.Members) > 0 {
+			surviving = append(surviving, s)
+		}
+	}
+	return surviving
+}
+
+// WithinSpeciesTournamentSelection performs tournament selection within each species
+// independently, so that individuals compete only against members of their own species.
+// Each species contributes as many selected individuals as it has members.
+//
+// Parameters:
+// - species: the species to select from, as returned by Speciate.
+// - tournamentSize: the number of individuals to be chosen randomly for each tournament.
+//
+// Returns:
+// - A new population of selected individuals, grouped by species.
+func WithinSpeciesTournamentSelection(species []Species, tournamentSize int) []*ga.Individual {
+	var selected []*ga.Individual
+	for _, s := range species {
+		if len(s.Members) == 0 {
+			continue
+		}
+		selected = append(selected, ga.TournamentSelection(s.Members, tournamentSize)...)
+	}
+	return selected
+}
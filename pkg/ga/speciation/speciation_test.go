@@ -0,0 +1,76 @@
+package speciation
+
+import (
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// newCluster returns n individuals with identical genomes of the given length, filled with gene.
+func newCluster(n, length int, gene byte, fitness float64) []*ga.Individual {
+	cluster := make([]*ga.Individual, n)
+	for i := range cluster {
+		genome := make([]byte, length)
+		for j := range genome {
+			genome[j] = gene
+		}
+		cluster[i] = &ga.Individual{Genotype: &ga.Genotype{Genome: genome}, Phenotype: &ga.Phenotype{Fitness: fitness}}
+	}
+	return cluster
+}
+
+func TestSpeciate(t *testing.T) {
+	zeros := newCluster(5, 10, 0, 1)
+	ones := newCluster(3, 10, 1, 2)
+	population := []*ga.Individual{zeros[0], ones[0], zeros[1], zeros[2], ones[1], zeros[3], ones[2], zeros[4]}
+
+	cases := []struct {
+		sigma           float64
+		expectedSpecies int
+	}{
+		{sigma: 1, expectedSpecies: 2},
+		{sigma: 11, expectedSpecies: 1},
+		{sigma: 0, expectedSpecies: len(population)},
+	}
+
+	for _, tc := range cases {
+		species := Speciate(population, nil, tc.sigma, ga.HammingDistance, nil)
+		if len(species) != tc.expectedSpecies {
+			t.Errorf("Expected %d species with sigma %f, but got %d", tc.expectedSpecies, tc.sigma, len(species))
+		}
+	}
+
+	species := Speciate(population, nil, 1, ga.HammingDistance, nil)
+	if len(species[0].Members) != len(zeros) || len(species[1].Members) != len(ones) {
+		t.Errorf("Expected species sizes %d and %d, but got %d and %d", len(zeros), len(ones), len(species[0].Members), len(species[1].Members))
+	}
+	for i, s := range species {
+		for _, member := range s.Members {
+			if member.Genotype.Genome[0] != s.Representative.Genotype.Genome[0] {
+				t.Errorf("Expected members of species %d to match its representative", i)
+			}
+		}
+	}
+}
+
+func TestWithinSpeciesTournamentSelection(t *testing.T) {
+	zeros := newCluster(5, 10, 0, 1)
+	ones := newCluster(3, 10, 1, 2)
+	species := Speciate(append(zeros, ones...), nil, 1, ga.HammingDistance, nil)
+
+	selected := WithinSpeciesTournamentSelection(species, 3)
+
+	if len(selected) != len(zeros)+len(ones) {
+		t.Fatalf("Expected %d selected individuals, but got %d", len(zeros)+len(ones), len(selected))
+	}
+	// The fitter species must not take over the weaker one.
+	for i, ind := range selected {
+		expected := byte(0)
+		if i >= len(zeros) {
+			expected = 1
+		}
+		if ind.Genotype.Genome[0] != expected {
+			t.Errorf("Expected selected individual %d from species with gene %d, but got %d", i, expected, ind.Genotype.Genome[0])
+		}
+	}
+}
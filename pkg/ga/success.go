@@ -0,0 +1,129 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the 1/5 success rule for adapting the mutation rate.
+package ga
+
+const (
+	// AdaptiveStrategyDiversity adapts MutationRate to the diversity of the population, switching
+	// to pure exploration while it has converged. It is the default AdaptiveStrategy.
+	AdaptiveStrategyDiversity = "diversity"
+	// AdaptiveStrategySuccessRate adapts MutationRate with the 1/5 success rule.
+	AdaptiveStrategySuccessRate = "success_rate"
+
+	// DefaultSuccessRateFactor is the factor applied to MutationRate by the 1/5 success rule
+	// when SuccessRateFactor is not set.
+	DefaultSuccessRateFactor = 0.85
+)
+
+// successRatio is the fraction of successful mutations above which the 1/5 success rule
+// increases MutationRate, and below which it decreases it.
+const successRatio = 1.0 / 5.0
+
+// mutationRecord records the state of an individual before mutation.
+type mutationRecord struct {
+	fitness float64
+	key     string
+}
+
+// recordBeforeMutation records, for every individual of the population, its genome and the
+// fitness to compare it with after mutation: its own fitness, or the fitness of its fitter
+// parent in the previous population if it has not been evaluated yet.
+//
+// Parameters:
+// - previous: the population at the start of the generation.
+//
+// Returns:
+// - The records of the individuals of the population, one per distinct individual.
+func (ga *GA) recordBeforeMutation(previous []*Individual) map[*Individual]mutationRecord {
+	parentFitness := make(map[int64]float64, len(previous))
+	for _, ind := range previous {
+		if ind.ID != 0 && ind.Phenotype != nil {
+			parentFitness[ind.ID] = ind.Phenotype.Fitness
+		}
+	}
+	records := make(map[*Individual]mutationRecord, len(ga.Population))
+	for _, ind := range ga.Population {
+		if _, ok := records[ind]; ok || ind.Genotype == nil {
+			continue
+		}
+		record := mutationRecord{key: string(ind.Genotype.key())}
+		if ind.Phenotype != nil {
+			record.fitness = ind.Phenotype.Fitness
+		} else {
+			found := false
+			for _, id := range ind.ParentIDs {
+				fitness, ok := parentFitness[id]
+				if ok && (!found || fitter(fitness, record.fitness, ga.Minimize)) {
+					record.fitness, found = fitness, true
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		records[ind] = record
+	}
+	return records
+}
+
+// mutationSuccessRate returns the fraction of the mutated individuals of the population that
+// are fitter than before mutation. Individuals whose genome mutation left unchanged are not
+// counted; if no individual was mutated, the rate is zero.
+//
+// Parameters:
+// - records: the records taken by recordBeforeMutation.
+//
+// Returns:
+// - The fraction of successful mutations.
+func (ga *GA) mutationSuccessRate(records map[*Individual]mutationRecord) float64 {
+	mutated, successful := 0, 0
+	for ind, record := range records {
+		if ind.Phenotype == nil || string(ind.Genotype.key()) == record.key {
+			continue
+		}
+		mutated++
+		if fitter(ind.Phenotype.Fitness, record.fitness, ga.Minimize) {
+			successful++
+		}
+	}
+	if mutated == 0 {
+		return 0
+	}
+	return float64(successful) / float64(mutated)
+}
+
+// applySuccessRule adapts MutationRate with the 1/5 success rule: it is divided by
+// SuccessRateFactor when more than a fifth of the mutations of the last generation were
+// successful, and multiplied by it when fewer were. The rate never exceeds one, nor
+// MaxMutationRate if it is positive. The rate in effect before the first adaptation is kept
+// for resetSuccessRule.
+func (ga *GA) applySuccessRule() {
+	if !ga.adaptingSuccessRate {
+		ga.successBaseRate = ga.MutationRate
+		ga.adaptingSuccessRate = true
+	}
+	c := ga.SuccessRateFactor
+	if c <= 0 {
+		c = DefaultSuccessRateFactor
+	}
+	switch {
+	case ga.MutationSuccessRate > successRatio:
+		ga.MutationRate /= c
+	case ga.MutationSuccessRate < successRatio:
+		ga.MutationRate *= c
+	}
+	limit := 1.0
+	if ga.MaxMutationRate > 0 {
+		limit = min(limit, ga.MaxMutationRate)
+	}
+	ga.MutationRate = min(ga.MutationRate, limit)
+}
+
+// resetSuccessRule restores the MutationRate in effect before the 1/5 success rule first
+// adapted it, if it has, and clears MutationSuccessRate.
+func (ga *GA) resetSuccessRule() {
+	if ga.adaptingSuccessRate {
+		ga.MutationRate = ga.successBaseRate
+		ga.adaptingSuccessRate = false
+	}
+	ga.MutationSuccessRate = 0
+}
@@ -0,0 +1,90 @@
+package ga
+
+import "testing"
+
+func TestSuccessRateStrategy(t *testing.T) {
+	genotypeOf := func(gene byte) func() *Genotype {
+		return func() *Genotype {
+			genotype := MustNewGenotype(32)
+			for i := range genotype.Genome {
+				genotype.Genome[i] = gene
+			}
+			return genotype
+		}
+	}
+
+	// Every genome is optimal on a flat problem, so no mutation can help.
+	flat := func(*Genotype) *Phenotype { return &Phenotype{Fitness: 1} }
+
+	cases := []struct {
+		name        string
+		genotype    func() *Genotype
+		eval        func(*Genotype) *Phenotype
+		generations int
+		increases   bool
+	}{
+		{name: "at optimum", genotype: genotypeOf(1), eval: flat, generations: 20, increases: false},
+		{name: "far from optimum", genotype: genotypeOf(0), eval: oneMax, generations: 1, increases: true},
+	}
+
+	for _, tc := range cases {
+		const initialRate = 0.1
+		rates := []float64{initialRate}
+		ga := &GA{
+			Selection:        func(population []*Individual) []*Individual { return population },
+			Crossover:        func(population []*Individual, _ float64) []*Individual { return population },
+			Mutation:         BitFlipMutation,
+			MutationRate:     initialRate,
+			Generations:      tc.generations,
+			AdaptiveStrategy: AdaptiveStrategySuccessRate,
+		}
+		ga.OnGeneration = func(int, []*Individual) { rates = append(rates, ga.MutationRate) }
+		ga.Initialize(20, tc.genotype, tc.eval)
+		ga.Evolve(tc.eval)
+
+		if len(rates) != tc.generations+1 {
+			t.Fatalf("%s: Expected %d generations, but got %d", tc.name, tc.generations, len(rates)-1)
+		}
+		for i := 1; i < len(rates); i++ {
+			if increased := rates[i] > rates[i-1]; increased != tc.increases {
+				t.Errorf("%s: Expected mutation rate to increase to be %v in generation %d, but got %f after %f", tc.name, tc.increases, i, rates[i], rates[i-1])
+			}
+		}
+	}
+}
+
+func TestSuccessRateStrategyReset(t *testing.T) {
+	const initialRate = 0.1
+	newGenotype := func() *Genotype { return MustNewGenotype(32) }
+	ga := &GA{
+		Selection:        func(population []*Individual) []*Individual { return population },
+		Crossover:        func(population []*Individual, _ float64) []*Individual { return population },
+		Mutation:         BitFlipMutation,
+		MutationRate:     initialRate,
+		Generations:      5,
+		AdaptiveStrategy: AdaptiveStrategySuccessRate,
+	}
+	ga.Initialize(20, newGenotype, oneMax)
+	ga.Evolve(oneMax)
+	if ga.MutationRate == initialRate {
+		t.Fatalf("Expected the success rule to adapt the mutation rate, but it stayed %f", ga.MutationRate)
+	}
+
+	if err := ga.Reset(20, newGenotype, oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if ga.MutationRate != initialRate {
+		t.Errorf("Expected mutation rate %f after Reset, but got %f", initialRate, ga.MutationRate)
+	}
+	if ga.MutationSuccessRate != 0 {
+		t.Errorf("Expected mutation success rate 0 after Reset, but got %f", ga.MutationSuccessRate)
+	}
+
+	// The rate the second run adapted is discarded in the same way.
+	ga.Evolve(oneMax)
+	ga.Reset(20, newGenotype, oneMax)
+	if ga.MutationRate != initialRate {
+		t.Errorf("Expected mutation rate %f after a second Reset, but got %f", initialRate, ga.MutationRate)
+	}
+}
@@ -0,0 +1,130 @@
+// Package surrogate provides surrogate models for genetic algorithms, which approximate an
+// expensive fitness function from the fitness values already computed.
+package surrogate
+
+import "sync"
+
+// DefaultCapacity is the number of training points a KNNSurrogate created by NewKNNSurrogate
+// holds.
+const DefaultCapacity = 1000
+
+// KNNSurrogate approximates fitness by k-nearest neighbor interpolation over the genomes it has
+// been trained on, using the Hamming distance between their genes, so it suits binary genomes
+// only. It is safe for concurrent use.
+//
+// Capacity, if positive, bounds the number of training points, so that predictions do not
+// slow down as evaluations accumulate: once it is reached, each new point replaces the oldest.
+type KNNSurrogate struct {
+	K        int
+	Capacity int
+
+	mu      sync.RWMutex
+	genomes [][]byte
+	fitness []float64
+	oldest  int
+}
+
+// NewKNNSurrogate creates a new, untrained KNNSurrogate holding at most DefaultCapacity
+// training points.
+//
+// Parameters:
+// - k: the number of nearest neighbors to interpolate between.
+//
+// Returns:
+// - A pointer to the newly created KNNSurrogate.
+func NewKNNSurrogate(k int) *KNNSurrogate {
+	return &KNNSurrogate{K: k, Capacity: DefaultCapacity}
+}
+
+// Add adds a training point to the surrogate, replacing the oldest one if the surrogate is
+// at capacity. The genome is copied.
+//
+// Parameters:
+// - genome: the genome whose fitness is known.
+// - fitness: the exact fitness of the genome.
+func (s *KNNSurrogate) Add(genome []byte, fitness float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	genome = append([]byte(nil), genome...)
+	if s.Capacity > 0 && len(s.genomes) >= s.Capacity {
+		// The points form a ring whose oldest entry is overwritten.
+		s.oldest %= len(s.genomes)
+		s.genomes[s.oldest], s.fitness[s.oldest] = genome, fitness
+		s.oldest++
+		return
+	}
+	s.genomes = append(s.genomes, genome)
+	s.fitness = append(s.fitness, fitness)
+}
+
+// Size returns the number of training points.
+func (s *KNNSurrogate) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.genomes)
+}
+
+// Predict approximates the fitness of a genome as the average fitness of its K nearest training
+// genomes, weighted by the inverse of their Hamming distance. A training genome identical to
+// the given one determines the prediction alone.
+//
+// Parameters:
+// - genome: the genome whose fitness to predict.
+//
+// Returns:
+// - The predicted fitness.
+// - true if the prediction is reliable, that is, if there are at least K training points.
+func (s *KNNSurrogate) Predict(genome []byte) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.genomes) == 0 || s.K <= 0 {
+		return 0, false
+	}
+
+	// Only the K nearest points are kept, in order of distance, rather than sorting them all.
+	type neighbor struct {
+		distance int
+		fitness  float64
+	}
+	neighbors := make([]neighbor, 0, min(s.K, len(s.genomes)))
+	for i, other := range s.genomes {
+		distance := hammingDistance(genome, other)
+		if len(neighbors) == cap(neighbors) && distance >= neighbors[len(neighbors)-1].distance {
+			continue
+		}
+		if len(neighbors) < cap(neighbors) {
+			neighbors = append(neighbors, neighbor{})
+		}
+		j := len(neighbors) - 1
+		for ; j > 0 && neighbors[j-1].distance > distance; j-- {
+			neighbors[j] = neighbors[j-1]
+		}
+		neighbors[j] = neighbor{distance: distance, fitness: s.fitness[i]}
+	}
+
+	if neighbors[0].distance == 0 {
+		return neighbors[0].fitness, len(s.genomes) >= s.K
+	}
+	weighted, weights := 0.0, 0.0
+	for _, n := range neighbors {
+		weight := 1 / float64(n.distance)
+		weighted += weight * n.fitness
+		weights += weight
+	}
+	return weighted / weights, len(s.genomes) >= s.K
+}
+
+// hammingDistance returns the number of positions at which the genomes differ, counting each
+// extra gene of the longer genome as a difference.
+func hammingDistance(a, b []byte) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	distance := len(b) - len(a)
+	for i := range a {
+		if a[i] != b[i] {
+			distance++
+		}
+	}
+	return distance
+}
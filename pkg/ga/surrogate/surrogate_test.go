@@ -0,0 +1,144 @@
+package surrogate
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// oneMax returns the number of ones in the genome.
+func oneMax(genome []byte) float64 {
+	fitness := 0.0
+	for _, gene := range genome {
+		fitness += float64(gene)
+	}
+	return fitness
+}
+
+func randomGenome(length int) []byte {
+	genome := make([]byte, length)
+	for i := range genome {
+		genome[i] = byte(rand.Intn(2))
+	}
+	return genome
+}
+
+func TestKNNSurrogateRMSE(t *testing.T) {
+	const genomeLength = 20
+	s := NewKNNSurrogate(5)
+	for i := 0; i < 500; i++ {
+		genome := randomGenome(genomeLength)
+		s.Add(genome, oneMax(genome))
+	}
+
+	squaredError, baselineError := 0.0, 0.0
+	const testSize = 100
+	for i := 0; i < testSize; i++ {
+		genome := randomGenome(genomeLength)
+		predicted, reliable := s.Predict(genome)
+		if !reliable {
+			t.Fatalf("Expected a reliable prediction with %d training points", s.Size())
+		}
+		squaredError += math.Pow(predicted-oneMax(genome), 2)
+		// Predicting the mean fitness of random genomes is the baseline to beat.
+		baselineError += math.Pow(genomeLength/2-oneMax(genome), 2)
+	}
+	rmse := math.Sqrt(squaredError / testSize)
+	baseline := math.Sqrt(baselineError / testSize)
+
+	if rmse >= baseline {
+		t.Errorf("Expected RMSE below the baseline %f, but got %f", baseline, rmse)
+	}
+}
+
+func TestKNNSurrogatePredict(t *testing.T) {
+	s := NewKNNSurrogate(2)
+	if _, reliable := s.Predict([]byte{0, 0}); reliable {
+		t.Errorf("Expected an untrained surrogate to be unreliable")
+	}
+
+	s.Add([]byte{0, 0, 0, 0}, 0)
+	if _, reliable := s.Predict([]byte{0, 0, 0, 1}); reliable {
+		t.Errorf("Expected a prediction from fewer than K points to be unreliable")
+	}
+	s.Add([]byte{1, 1, 1, 1}, 4)
+
+	cases := []struct {
+		genome   []byte
+		expected float64
+	}{
+		{genome: []byte{0, 0, 0, 0}, expected: 0},
+		{genome: []byte{1, 1, 1, 1}, expected: 4},
+		{genome: []byte{1, 1, 0, 0}, expected: 2},
+		// Distances 1 and 3 weigh the neighbors 3:1.
+		{genome: []byte{1, 0, 0, 0}, expected: 1},
+	}
+
+	for _, tc := range cases {
+		predicted, reliable := s.Predict(tc.genome)
+		if !reliable {
+			t.Errorf("Expected a reliable prediction for %v", tc.genome)
+		}
+		if math.Abs(predicted-tc.expected) > 1e-9 {
+			t.Errorf("Expected prediction %f for %v, but got %f", tc.expected, tc.genome, predicted)
+		}
+	}
+}
+
+func TestKNNSurrogateCapacity(t *testing.T) {
+	s := &KNNSurrogate{K: 1, Capacity: 2}
+	s.Add([]byte{0, 0}, 0)
+	s.Add([]byte{1, 1}, 2)
+	s.Add([]byte{1, 0}, 1)
+
+	if s.Size() != 2 {
+		t.Fatalf("Expected the surrogate to hold %d training points, but got %d", 2, s.Size())
+	}
+	// The oldest point was replaced, so the nearest point to it is now at distance 1.
+	if predicted, _ := s.Predict([]byte{0, 0}); predicted != 1 {
+		t.Errorf("Expected prediction %d once the oldest point was replaced, but got %f", 1, predicted)
+	}
+	s.Add([]byte{0, 1}, 1)
+	if predicted, _ := s.Predict([]byte{1, 1}); predicted != 1 {
+		t.Errorf("Expected prediction %d once the second oldest point was replaced, but got %f", 1, predicted)
+	}
+}
+
+func TestKNNSurrogateNearestNeighbors(t *testing.T) {
+	const genomeLength, k = 12, 4
+	s := &KNNSurrogate{K: k}
+	genomes := make([][]byte, 200)
+	for i := range genomes {
+		genomes[i] = randomGenome(genomeLength)
+		s.Add(genomes[i], oneMax(genomes[i]))
+	}
+
+	for i := 0; i < 50; i++ {
+		genome := randomGenome(genomeLength)
+		// The reference sorts every training point by distance, breaking ties by age.
+		order := make([]int, len(genomes))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return hammingDistance(genome, genomes[order[a]]) < hammingDistance(genome, genomes[order[b]])
+		})
+		var expected float64
+		if hammingDistance(genome, genomes[order[0]]) == 0 {
+			expected = oneMax(genomes[order[0]])
+		} else {
+			weighted, weights := 0.0, 0.0
+			for _, j := range order[:k] {
+				weight := 1 / float64(hammingDistance(genome, genomes[j]))
+				weighted += weight * oneMax(genomes[j])
+				weights += weight
+			}
+			expected = weighted / weights
+		}
+
+		if predicted, _ := s.Predict(genome); math.Abs(predicted-expected) > 1e-9 {
+			t.Errorf("Expected prediction %f for %v, but got %f", expected, genome, predicted)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package tabu provides a tabu list for genetic algorithms, recording recently visited
+// genomes so that the search can be steered away from revisiting them.
+package tabu
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// TabuList records the FNV-1a hashes of the most recently added genomes in a ring buffer
+// of fixed capacity, evicting the oldest hash when full. It is safe for concurrent use.
+//
+// Because genomes are compared by hash, a genome that collides with a recorded one is
+// reported as tabu although it was never added.
+type TabuList struct {
+	mu     sync.Mutex
+	hashes []uint64
+	counts map[uint64]int
+	next   int
+	full   bool
+}
+
+// NewTabuList creates a new, empty TabuList.
+//
+// Parameters:
+// - capacity: the number of genomes remembered, at least 1.
+//
+// Returns:
+// - A pointer to the newly created TabuList.
+func NewTabuList(capacity int) *TabuList {
+	return &TabuList{
+		hashes: make([]uint64, max(capacity, 1)),
+		counts: make(map[uint64]int, capacity),
+	}
+}
+
+// IsTabu reports whether the genome is in the list.
+//
+// Parameters:
+// - genome: the genome to look up.
+//
+// Returns:
+// - true if the hash of the genome is among the recorded hashes, false otherwise.
+func (l *TabuList) IsTabu(genome []byte) bool {
+	hash := hashGenome(genome)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.counts[hash] > 0
+}
+
+// Add inserts the genome into the list, evicting the oldest genome if the list is full.
+//
+// Parameters:
+// - genome: the genome to add.
+func (l *TabuList) Add(genome []byte) {
+	hash := hashGenome(genome)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.full {
+		evicted := l.hashes[l.next]
+		if l.counts[evicted]--; l.counts[evicted] == 0 {
+			delete(l.counts, evicted)
+		}
+	}
+	l.hashes[l.next] = hash
+	l.counts[hash]++
+	l.next = (l.next + 1) % len(l.hashes)
+	l.full = l.full || l.next == 0
+}
+
+// Len returns the number of genomes in the list.
+func (l *TabuList) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.full {
+		return len(l.hashes)
+	}
+	return l.next
+}
+
+// hashGenome returns the 64-bit FNV-1a hash of the genome.
+func hashGenome(genome []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(genome)
+	return h.Sum64()
+}
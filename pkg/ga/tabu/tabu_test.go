@@ -0,0 +1,32 @@
+package tabu
+
+import "testing"
+
+func TestTabuList(t *testing.T) {
+	l := NewTabuList(2)
+	a, b, c := []byte{0, 0, 1}, []byte{0, 1, 0}, []byte{1, 0, 0}
+
+	cases := []struct {
+		add           []byte
+		expectedTabu  map[string]bool
+		expectedCount int
+	}{
+		{add: a, expectedTabu: map[string]bool{"a": true, "b": false, "c": false}, expectedCount: 1},
+		{add: b, expectedTabu: map[string]bool{"a": true, "b": true, "c": false}, expectedCount: 2},
+		{add: c, expectedTabu: map[string]bool{"a": false, "b": true, "c": true}, expectedCount: 2},
+		{add: c, expectedTabu: map[string]bool{"a": false, "b": false, "c": true}, expectedCount: 2},
+	}
+
+	genomes := map[string][]byte{"a": a, "b": b, "c": c}
+	for step, tc := range cases {
+		l.Add(tc.add)
+		for name, expected := range tc.expectedTabu {
+			if tabu := l.IsTabu(genomes[name]); tabu != expected {
+				t.Errorf("Step %d: Expected IsTabu(%s) to be %v, but got %v", step, name, expected, tabu)
+			}
+		}
+		if l.Len() != tc.expectedCount {
+			t.Errorf("Step %d: Expected %d genomes in the list, but got %d", step, tc.expectedCount, l.Len())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package ga provides functionalities for implementing genetic algorithms,
+// including the conditions under which evolution terminates early.
+package ga
+
+// TerminationCondition decides when a GA should stop evolving before it reaches its
+// Generations. Implementations of common conditions and of their combinations are provided
+// by the termination package.
+type TerminationCondition interface {
+	// Evaluate reports whether the GA should stop evolving.
+	Evaluate(ga *GA) bool
+}
+
+// TerminationFunc adapts an ordinary function to the TerminationCondition interface.
+type TerminationFunc func(ga *GA) bool
+
+// Evaluate calls f(ga).
+func (f TerminationFunc) Evaluate(ga *GA) bool {
+	return f(ga)
+}
+
+// Generation returns the number of generations evolved since the last call to Initialize.
+func (ga *GA) Generation() int {
+	return max(ga.historyRecorded-1, 0)
+}
+
+// CurrentStatistics returns the statistics of the population: the most recently recorded
+// entry of History if there is one, or otherwise statistics calculated from Population.
+// During evolution the recorded entry is that of the current population.
+//
+// Returns:
+// - The statistics of the population.
+func (ga *GA) CurrentStatistics() *Statistics {
+	if len(ga.History) > 0 {
+		return ga.latestStatistics()
+	}
+	return ga.statistics()
+}
@@ -0,0 +1,89 @@
+// Package termination provides conditions for stopping the evolution of a GA early,
+// including combinations of other conditions.
+package termination
+
+import "github.com/Okabe-Junya/gago/pkg/ga"
+
+// AnyTermination returns a condition that is met when at least one of the given conditions
+// is met. Every condition is evaluated each time, so that conditions that track the
+// evolution observe every generation.
+//
+// Parameters:
+// - conditions: the conditions to combine.
+//
+// Returns:
+// - The combined termination condition.
+func AnyTermination(conditions ...ga.TerminationCondition) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		met := false
+		for _, condition := range conditions {
+			met = condition.Evaluate(g) || met
+		}
+		return met
+	})
+}
+
+// AllTermination returns a condition that is met when all of the given conditions are met.
+// Every condition is evaluated each time, so that conditions that track the evolution
+// observe every generation.
+//
+// Parameters:
+// - conditions: the conditions to combine.
+//
+// Returns:
+// - The combined termination condition.
+func AllTermination(conditions ...ga.TerminationCondition) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		met := true
+		for _, condition := range conditions {
+			met = condition.Evaluate(g) && met
+		}
+		return met
+	})
+}
+
+// NotTermination returns a condition that is met exactly when the given condition is not,
+// for example to keep evolving only while a stagnation condition holds.
+//
+// Parameters:
+// - condition: the condition to negate.
+//
+// Returns:
+// - The negated termination condition.
+func NotTermination(condition ga.TerminationCondition) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		return !condition.Evaluate(g)
+	})
+}
+
+// WeightedCondition is a termination condition with the weight it contributes to a
+// WeightedTermination when it is met.
+type WeightedCondition struct {
+	Condition ga.TerminationCondition
+	Weight    float64
+}
+
+// NewWeightedTermination returns a condition that is met when the weights of the met
+// conditions sum to at least the threshold. Every condition is evaluated each time, so that
+// conditions that track the evolution observe every generation.
+//
+// For example, with a threshold of 0.6, two conditions of weight 0.5 must both be met, while
+// a condition of weight 0.7 suffices on its own.
+//
+// Parameters:
+// - threshold: the total weight at which to terminate.
+// - conditions: the weighted conditions to combine.
+//
+// Returns:
+// - The combined termination condition.
+func NewWeightedTermination(threshold float64, conditions ...WeightedCondition) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		total := 0.0
+		for _, weighted := range conditions {
+			if weighted.Condition.Evaluate(g) {
+				total += weighted.Weight
+			}
+		}
+		return total >= threshold
+	})
+}
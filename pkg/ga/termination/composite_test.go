@@ -0,0 +1,105 @@
+package termination
+
+import (
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// constant returns a condition that is always met, or never, as given by met.
+func constant(met bool) ga.TerminationCondition {
+	return ga.TerminationFunc(func(*ga.GA) bool { return met })
+}
+
+func TestAnyAndAllTermination(t *testing.T) {
+	cases := []struct {
+		a, b        bool
+		expectedAny bool
+		expectedAll bool
+	}{
+		{a: false, b: false, expectedAny: false, expectedAll: false},
+		{a: true, b: false, expectedAny: true, expectedAll: false},
+		{a: false, b: true, expectedAny: true, expectedAll: false},
+		{a: true, b: true, expectedAny: true, expectedAll: true},
+	}
+
+	for _, tc := range cases {
+		if got := AnyTermination(constant(tc.a), constant(tc.b)).Evaluate(&ga.GA{}); got != tc.expectedAny {
+			t.Errorf("Expected AnyTermination(%t, %t) to be %t, but got %t", tc.a, tc.b, tc.expectedAny, got)
+		}
+		if got := AllTermination(constant(tc.a), constant(tc.b)).Evaluate(&ga.GA{}); got != tc.expectedAll {
+			t.Errorf("Expected AllTermination(%t, %t) to be %t, but got %t", tc.a, tc.b, tc.expectedAll, got)
+		}
+	}
+}
+
+func TestAnyTerminationEvaluatesEveryCondition(t *testing.T) {
+	evaluations := 0
+	counting := ga.TerminationFunc(func(*ga.GA) bool {
+		evaluations++
+		return false
+	})
+
+	AnyTermination(constant(true), counting).Evaluate(&ga.GA{})
+	AllTermination(constant(false), counting).Evaluate(&ga.GA{})
+
+	if evaluations != 2 {
+		t.Errorf("Expected every condition to be evaluated, but the last was evaluated %d times", evaluations)
+	}
+}
+
+func TestNotTermination(t *testing.T) {
+	cases := []struct {
+		best     float64
+		expected bool
+	}{
+		{best: 4.0, expected: true},
+		{best: 5.0, expected: false},
+	}
+
+	for _, tc := range cases {
+		condition := FitnessThresholdTermination(5.0)
+		g := gaWithBest(tc.best)
+		if got := NotTermination(condition).Evaluate(g); got != tc.expected {
+			t.Errorf("Expected %t for best fitness %f, but got %t", tc.expected, tc.best, got)
+		}
+		if got := NotTermination(NotTermination(condition)).Evaluate(g); got != condition.Evaluate(g) {
+			t.Errorf("Expected double negation to equal the condition for best fitness %f, but got %t", tc.best, got)
+		}
+	}
+}
+
+func TestWeightedTermination(t *testing.T) {
+	cases := []struct {
+		name       string
+		conditions []WeightedCondition
+		expected   bool
+	}{
+		{
+			name:       "one of two half weights",
+			conditions: []WeightedCondition{{Condition: constant(true), Weight: 0.5}, {Condition: constant(false), Weight: 0.5}},
+			expected:   false,
+		},
+		{
+			name:       "both half weights",
+			conditions: []WeightedCondition{{Condition: constant(true), Weight: 0.5}, {Condition: constant(true), Weight: 0.5}},
+			expected:   true,
+		},
+		{
+			name:       "one heavy weight",
+			conditions: []WeightedCondition{{Condition: constant(true), Weight: 0.7}, {Condition: constant(false), Weight: 0.3}},
+			expected:   true,
+		},
+		{
+			name:       "no met condition",
+			conditions: []WeightedCondition{{Condition: constant(false), Weight: 0.7}, {Condition: constant(false), Weight: 0.3}},
+			expected:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		if got := NewWeightedTermination(0.6, tc.conditions...).Evaluate(&ga.GA{}); got != tc.expected {
+			t.Errorf("%s: Expected %t, but got %t", tc.name, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package termination provides conditions for stopping the evolution of a GA early, for use
+// as ga.GA.Termination.
+package termination
+
+import (
+	"math"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"github.com/Okabe-Junya/gago/pkg/ga/analysis"
+)
+
+// MaxGenerationsTermination returns a condition that is met once the GA has evolved the
+// given number of generations since it was initialized.
+//
+// Parameters:
+// - generations: the number of generations after which to stop.
+//
+// Returns:
+// - The termination condition.
+func MaxGenerationsTermination(generations int) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		return g.Generation() >= generations
+	})
+}
+
+// FitnessThresholdTermination returns a condition that is met once the best fitness of the
+// population reaches the threshold: once it is at least the threshold, or at most the
+// threshold if the GA minimizes.
+//
+// Parameters:
+// - threshold: the fitness to reach.
+//
+// Returns:
+// - The termination condition.
+func FitnessThresholdTermination(threshold float64) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		best := g.CurrentStatistics().BestFitness
+		if g.Minimize {
+			return best <= threshold
+		}
+		return best >= threshold
+	})
+}
+
+// SmoothingWindow is the number of generations over which ConvergenceTermination averages
+// the history when the GA's SmoothConvergenceCheck is set.
+const SmoothingWindow = 5
+
+// ConvergenceTermination returns a condition that is met once the best fitness recorded in
+// the GA's History has improved by less than epsilon over the last generations. If the GA's
+// SmoothConvergenceCheck is set, the history is first smoothed with analysis.SmoothHistory
+// over SmoothingWindow generations. The condition is not met before the given number of
+// generations is recorded.
+//
+// Parameters:
+// - generations: the number of generations over which to measure the improvement.
+// - epsilon: the improvement of the best fitness below which to stop.
+//
+// Returns:
+// - The termination condition.
+func ConvergenceTermination(generations int, epsilon float64) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		history := g.GetHistory()
+		if generations <= 0 || len(history) <= generations {
+			return false
+		}
+		if g.SmoothConvergenceCheck {
+			history = analysis.SmoothHistory(history, SmoothingWindow)
+		}
+		improvement := history[len(history)-1].BestFitness - history[len(history)-1-generations].BestFitness
+		if g.Minimize {
+			improvement = -improvement
+		}
+		return improvement < epsilon
+	})
+}
+
+// EntropyTermination returns a condition that is met once the mean allele entropy of a
+// binary-encoded population falls below the threshold.
+//
+// The allele entropy of a gene position is the Shannon entropy -p log2(p) - (1-p) log2(1-p)
+// of the fraction p of individuals whose gene is 1, which is 1 when both alleles are equally
+// common and 0 when the population has converged on one allele. The mean over all positions
+// is a genotypic measure of convergence. The condition is never met for populations whose
+// AlleleFrequencies are not calculated, such as real-valued ones.
+//
+// Parameters:
+// - threshold: the mean allele entropy, in bits, below which to stop.
+//
+// Returns:
+// - The termination condition.
+func EntropyTermination(threshold float64) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		frequencies := g.CurrentStatistics().AlleleFrequencies
+		return len(frequencies) > 0 && alleleEntropy(frequencies) < threshold
+	})
+}
+
+// alleleEntropy returns the mean binary Shannon entropy, in bits, of the given allele
+// frequencies.
+func alleleEntropy(frequencies []float64) float64 {
+	entropy := 0.0
+	for _, p := range frequencies {
+		entropy += binaryEntropy(p) + binaryEntropy(1-p)
+	}
+	return entropy / float64(len(frequencies))
+}
+
+// binaryEntropy returns the term -p log2(p) of the Shannon entropy, which is 0 for p = 0.
+func binaryEntropy(p float64) float64 {
+	if p <= 0 {
+		return 0
+	}
+	return -p * math.Log2(p)
+}
+
+// fitnessEntropyBins is the number of equal-width bins into which PopulationEntropyTermination
+// divides the fitness range of the population.
+const fitnessEntropyBins = 20
+
+// PopulationEntropyTermination returns a condition that is met once the Shannon entropy of the
+// fitness distribution of the population falls below the threshold.
+//
+// The fitness values are binned into 20 equal-width bins spanning the range of the population,
+// and the entropy is -sum(p log2(p)) over the fraction p of individuals in each bin. Unlike the
+// diversity of Statistics, the entropy accounts for the shape of the distribution: it is
+// log2(20), about 4.32 bits, when the fitness values are spread evenly over the range, and low
+// when most individuals cluster around a few fitness values. A population whose individuals all
+// have the same fitness has an entropy of 0.
+//
+// Parameters:
+// - threshold: the fitness entropy, in bits, below which to stop.
+//
+// Returns:
+// - The termination condition.
+func PopulationEntropyTermination(threshold float64) ga.TerminationCondition {
+	return ga.TerminationFunc(func(g *ga.GA) bool {
+		return len(g.Population) > 0 && fitnessEntropy(g.Population) < threshold
+	})
+}
+
+// fitnessEntropy returns the Shannon entropy, in bits, of the fitness values of the population
+// binned into fitnessEntropyBins equal-width bins.
+func fitnessEntropy(population []*ga.Individual) float64 {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, ind := range population {
+		lowest = math.Min(lowest, ind.Phenotype.Fitness)
+		highest = math.Max(highest, ind.Phenotype.Fitness)
+	}
+	if highest == lowest {
+		return 0
+	}
+
+	var counts [fitnessEntropyBins]int
+	for _, ind := range population {
+		bin := int((ind.Phenotype.Fitness - lowest) / (highest - lowest) * fitnessEntropyBins)
+		counts[min(bin, fitnessEntropyBins-1)]++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		entropy += binaryEntropy(float64(count) / float64(len(population)))
+	}
+	return entropy
+}
@@ -0,0 +1,177 @@
+package termination
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// gaWithBest returns a GA whose population has the given best fitness.
+func gaWithBest(best float64) *ga.GA {
+	return &ga.GA{Population: []*ga.Individual{
+		{Genotype: &ga.Genotype{Genome: []byte{1, 1}}, Phenotype: &ga.Phenotype{Fitness: best}},
+		{Genotype: &ga.Genotype{Genome: []byte{0, 1}}, Phenotype: &ga.Phenotype{Fitness: best - 1}},
+	}}
+}
+
+func TestFitnessThresholdTermination(t *testing.T) {
+	cases := []struct {
+		best     float64
+		minimize bool
+		expected bool
+	}{
+		{best: 4.0, expected: false},
+		{best: 5.0, expected: true},
+		{best: 6.0, expected: true},
+		{best: 4.0, minimize: true, expected: true},
+		{best: 7.0, minimize: true, expected: false},
+	}
+
+	for _, tc := range cases {
+		g := gaWithBest(tc.best)
+		g.Minimize = tc.minimize
+		if tc.minimize {
+			g.Population[1].Phenotype.Fitness = tc.best + 1
+		}
+		if got := FitnessThresholdTermination(5.0).Evaluate(g); got != tc.expected {
+			t.Errorf("Expected %t for best fitness %f with minimize %t, but got %t", tc.expected, tc.best, tc.minimize, got)
+		}
+	}
+}
+
+func TestMaxGenerationsTermination(t *testing.T) {
+	oneMax := func(genotype *ga.Genotype) *ga.Phenotype {
+		fitness := 0.0
+		for _, gene := range genotype.Genome {
+			fitness += float64(gene)
+		}
+		return &ga.Phenotype{Fitness: fitness}
+	}
+	generations := 0
+	g := &ga.GA{
+		Selection:    func(population []*ga.Individual) []*ga.Individual { return population },
+		Crossover:    func(population []*ga.Individual, _ float64) []*ga.Individual { return population },
+		Mutation:     func([]*ga.Individual, float64) {},
+		Generations:  50,
+		OnGeneration: func(int, []*ga.Individual) { generations++ },
+		Termination:  MaxGenerationsTermination(12),
+	}
+	g.Initialize(10, func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+	g.Evolve(oneMax)
+
+	if generations != 12 {
+		t.Errorf("Expected %d generations, but got %d", 12, generations)
+	}
+}
+
+// binaryPopulation returns a GA whose population holds the given genomes.
+func binaryPopulation(genomes ...[]byte) *ga.GA {
+	population := make([]*ga.Individual, len(genomes))
+	for i, genome := range genomes {
+		population[i] = &ga.Individual{Genotype: &ga.Genotype{Genome: genome}, Phenotype: &ga.Phenotype{}}
+	}
+	return &ga.GA{Population: population}
+}
+
+func TestAlleleEntropy(t *testing.T) {
+	cases := []struct {
+		name     string
+		g        *ga.GA
+		expected float64
+	}{
+		{name: "uniform", g: binaryPopulation([]byte{0, 1, 0}, []byte{1, 0, 1}), expected: 1.0},
+		{name: "converged", g: binaryPopulation([]byte{0, 1, 1}, []byte{0, 1, 1}), expected: 0.0},
+		{name: "half converged", g: binaryPopulation([]byte{0, 1}, []byte{0, 0}), expected: 0.5},
+	}
+
+	for _, tc := range cases {
+		entropy := alleleEntropy(tc.g.CurrentStatistics().AlleleFrequencies)
+		if math.Abs(entropy-tc.expected) > 1e-12 {
+			t.Errorf("%s: Expected entropy %f, but got %f", tc.name, tc.expected, entropy)
+		}
+		if met, expected := EntropyTermination(0.25).Evaluate(tc.g), tc.expected < 0.25; met != expected {
+			t.Errorf("%s: Expected EntropyTermination(0.25) to be %t, but got %t", tc.name, expected, met)
+		}
+	}
+}
+
+func TestEntropyTerminationRealValued(t *testing.T) {
+	g := &ga.GA{Population: []*ga.Individual{
+		{Genotype: ga.MustNewFloat64Genotype(2, []float64{0, 0}, []float64{1, 1}), Phenotype: &ga.Phenotype{}},
+	}}
+
+	if EntropyTermination(0.5).Evaluate(g) {
+		t.Errorf("Expected EntropyTermination never to be met for a real-valued population")
+	}
+}
+
+func TestPopulationEntropyTermination(t *testing.T) {
+	fitnessPopulation := func(fitness func(i int) float64) *ga.GA {
+		population := make([]*ga.Individual, 100)
+		for i := range population {
+			population[i] = &ga.Individual{Genotype: ga.MustNewGenotype(2), Phenotype: &ga.Phenotype{Fitness: fitness(i)}}
+		}
+		return &ga.GA{Population: population}
+	}
+
+	cases := []struct {
+		name     string
+		g        *ga.GA
+		expected float64
+	}{
+		{name: "uniform", g: fitnessPopulation(func(i int) float64 { return float64(i) }), expected: math.Log2(20)},
+		{name: "bimodal", g: fitnessPopulation(func(i int) float64 { return float64(i % 2 * 10) }), expected: 1.0},
+		{name: "converged", g: fitnessPopulation(func(int) float64 { return 3 }), expected: 0.0},
+	}
+
+	for _, tc := range cases {
+		entropy := fitnessEntropy(tc.g.Population)
+		if math.Abs(entropy-tc.expected) > 1e-12 {
+			t.Errorf("%s: Expected entropy %f, but got %f", tc.name, tc.expected, entropy)
+		}
+		if met, expected := PopulationEntropyTermination(2).Evaluate(tc.g), tc.expected < 2; met != expected {
+			t.Errorf("%s: Expected PopulationEntropyTermination(2) to be %t, but got %t", tc.name, expected, met)
+		}
+	}
+}
+
+func TestConvergenceTermination(t *testing.T) {
+	historyOf := func(best func(i int) float64) []*ga.Statistics {
+		history := make([]*ga.Statistics, 20)
+		for i := range history {
+			history[i] = &ga.Statistics{BestFitness: best(i)}
+		}
+		return history
+	}
+	// A flat curve whose last generation is lifted by noise.
+	spike := historyOf(func(i int) float64 {
+		if i == 19 {
+			return 12
+		}
+		return 10
+	})
+	progress := historyOf(func(i int) float64 { return float64(i) })
+
+	cases := []struct {
+		name     string
+		history  []*ga.Statistics
+		smooth   bool
+		minimize bool
+		expected bool
+	}{
+		{name: "noise spike", history: spike, expected: false},
+		{name: "smoothed noise spike", history: spike, smooth: true, expected: true},
+		{name: "progress", history: progress, expected: false},
+		{name: "smoothed progress", history: progress, smooth: true, expected: false},
+		{name: "worsening while minimizing", history: progress, minimize: true, expected: true},
+		{name: "too short", history: progress[:5], expected: false},
+	}
+
+	for _, tc := range cases {
+		g := &ga.GA{History: tc.history, SmoothConvergenceCheck: tc.smooth, Minimize: tc.minimize}
+		if met := ConvergenceTermination(5, 1).Evaluate(g); met != tc.expected {
+			t.Errorf("%s: Expected ConvergenceTermination to be %t, but got %t", tc.name, tc.expected, met)
+		}
+	}
+}
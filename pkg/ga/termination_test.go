@@ -0,0 +1,45 @@
+package ga
+
+import "testing"
+
+func TestEvolveWithTermination(t *testing.T) {
+	ga := &GA{
+		Selection:     func(population []*Individual) []*Individual { return TournamentSelection(population, 2) },
+		Crossover:     SinglePointCrossover,
+		Mutation:      BitFlipMutation,
+		CrossoverRate: 0.7,
+		MutationRate:  0.01,
+		Generations:   100,
+		Termination:   TerminationFunc(func(ga *GA) bool { return ga.Generation() >= 7 }),
+	}
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(16, 16) }, oneMax)
+	if generation := ga.Generation(); generation != 0 {
+		t.Fatalf("Expected generation %d after Initialize, but got %d", 0, generation)
+	}
+
+	ga.Evolve(oneMax)
+
+	if generation := ga.Generation(); generation != 7 {
+		t.Errorf("Expected evolution to stop after %d generations, but got %d", 7, generation)
+	}
+	if entries := len(ga.GetHistory()); entries != 8 {
+		t.Errorf("Expected %d history entries, but got %d", 8, entries)
+	}
+}
+
+func TestCurrentStatistics(t *testing.T) {
+	ga := &GA{Population: []*Individual{
+		{Genotype: &Genotype{Genome: []byte{1, 0}}, Phenotype: &Phenotype{Fitness: 4.0}},
+		{Genotype: &Genotype{Genome: []byte{0, 0}}, Phenotype: &Phenotype{Fitness: 1.0}},
+	}}
+
+	if best := ga.CurrentStatistics().BestFitness; best != 4.0 {
+		t.Errorf("Expected best fitness %f without history, but got %f", 4.0, best)
+	}
+
+	ga.recordGeneration()
+	ga.Population[0].Phenotype.Fitness = 9.0
+	if best := ga.CurrentStatistics().BestFitness; best != 4.0 {
+		t.Errorf("Expected the recorded best fitness %f, but got %f", 4.0, best)
+	}
+}
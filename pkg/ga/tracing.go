@@ -0,0 +1,19 @@
+//go:build !otel
+
+// Package ga provides functionalities for implementing genetic algorithms,
+// including no-op tracing hooks used when the otel build tag is not set.
+package ga
+
+import "context"
+
+// tracing is empty without the otel build tag, so that GA has no Tracer field and the
+// OpenTelemetry modules are not linked.
+type tracing struct{}
+
+func (*tracing) traceEvolve(context.Context) func() { return func() {} }
+
+func (*tracing) traceGeneration(int) func(bestFitness, diversity float64) {
+	return func(float64, float64) {}
+}
+
+func (*tracing) traceEvalPopulation() func() { return func() {} }
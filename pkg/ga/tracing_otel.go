@@ -0,0 +1,82 @@
+//go:build otel
+
+// Package ga provides functionalities for implementing genetic algorithms,
+// including OpenTelemetry tracing of evolution, enabled by the otel build tag.
+package ga
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracing holds the OpenTelemetry tracer of a GA.
+type tracing struct {
+	// Tracer, if set, records a "ga.Evolve" span for each call to Evolve, with a child
+	// "ga.Generation" span for each generation and "ga.EvalPopulation" spans for the
+	// evaluation of the population.
+	Tracer trace.Tracer
+
+	ctx context.Context
+}
+
+// traceEvolve starts the span of a call to Evolve, as a child of the span of ctx, if any.
+//
+// Parameters:
+// - ctx: the context passed to EvolveWithContext.
+//
+// Returns:
+// - A function ending the span.
+func (t *tracing) traceEvolve(ctx context.Context) func() {
+	if t.Tracer == nil {
+		return func() {}
+	}
+	ctx, span := t.Tracer.Start(ctx, "ga.Evolve")
+	t.ctx = ctx
+	return func() {
+		span.End()
+		t.ctx = nil
+	}
+}
+
+// traceGeneration starts the span of a generation, as a child of the Evolve span.
+//
+// Parameters:
+// - gen: the zero-based generation number.
+//
+// Returns:
+// - A function recording the best fitness and diversity reached by the generation and ending the span.
+func (t *tracing) traceGeneration(gen int) func(bestFitness, diversity float64) {
+	if t.Tracer == nil {
+		return func(float64, float64) {}
+	}
+	parent := t.ctx
+	ctx, span := t.Tracer.Start(t.context(), "ga.Generation", trace.WithAttributes(attribute.Int("gen", gen)))
+	t.ctx = ctx
+	return func(bestFitness, diversity float64) {
+		span.SetAttributes(attribute.Float64("bestFitness", bestFitness), attribute.Float64("diversity", diversity))
+		span.End()
+		t.ctx = parent
+	}
+}
+
+// traceEvalPopulation starts the span of the evaluation of the population.
+//
+// Returns:
+// - A function ending the span.
+func (t *tracing) traceEvalPopulation() func() {
+	if t.Tracer == nil {
+		return func() {}
+	}
+	_, span := t.Tracer.Start(t.context(), "ga.EvalPopulation")
+	return func() { span.End() }
+}
+
+// context returns the context of the innermost active span.
+func (t *tracing) context() context.Context {
+	if t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
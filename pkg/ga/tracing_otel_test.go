@@ -0,0 +1,107 @@
+//go:build otel
+
+package ga
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEvolveTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	ga := &GA{
+		Selection:   func(population []*Individual) []*Individual { return TournamentSelection(population, 3) },
+		Crossover:   SinglePointCrossover,
+		Mutation:    BitFlipMutation,
+		Generations: 3,
+	}
+	ga.Tracer = provider.Tracer("gago")
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+	ga.Evolve(oneMax)
+
+	spans := exporter.GetSpans()
+	counts := make(map[string]int)
+	var evolve tracetest.SpanStub
+	for _, span := range spans {
+		counts[span.Name]++
+		if span.Name == "ga.Evolve" {
+			evolve = span
+		}
+	}
+
+	cases := []struct {
+		name     string
+		expected int
+	}{
+		{name: "ga.Evolve", expected: 1},
+		{name: "ga.Generation", expected: ga.Generations},
+		{name: "ga.EvalPopulation", expected: ga.Generations},
+	}
+	for _, tc := range cases {
+		if counts[tc.name] != tc.expected {
+			t.Errorf("Expected %d %q spans, but got %d", tc.expected, tc.name, counts[tc.name])
+		}
+	}
+
+	for _, span := range spans {
+		switch span.Name {
+		case "ga.Generation":
+			if span.Parent.SpanID() != evolve.SpanContext.SpanID() {
+				t.Errorf("Expected generation spans to be children of the Evolve span")
+			}
+			keys := make(map[string]bool)
+			for _, attr := range span.Attributes {
+				keys[string(attr.Key)] = true
+			}
+			for _, key := range []string{"gen", "bestFitness", "diversity"} {
+				if !keys[key] {
+					t.Errorf("Expected generation span attribute %q, but got %v", key, span.Attributes)
+				}
+			}
+		case "ga.EvalPopulation":
+			if !span.Parent.IsValid() || span.Parent.SpanID() == evolve.SpanContext.SpanID() {
+				t.Errorf("Expected evaluation spans to be children of a generation span")
+			}
+		}
+	}
+}
+
+func TestEvolveTracingParentSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := provider.Tracer("gago")
+
+	ga := &GA{
+		Selection:   func(population []*Individual) []*Individual { return TournamentSelection(population, 3) },
+		Crossover:   SinglePointCrossover,
+		Mutation:    BitFlipMutation,
+		Generations: 1,
+	}
+	ga.Tracer = tracer
+	ga.Initialize(10, func() *Genotype { return MustVariableLengthBinaryGenotype(8, 8) }, oneMax)
+
+	ctx, request := tracer.Start(context.Background(), "request")
+	if _, _, err := ga.EvolveWithContext(ctx, oneMax); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	request.End()
+
+	found := false
+	for _, span := range exporter.GetSpans() {
+		if span.Name != "ga.Evolve" {
+			continue
+		}
+		found = true
+		if span.Parent.SpanID() != request.SpanContext().SpanID() {
+			t.Errorf("Expected the Evolve span to be a child of the span of the context")
+		}
+	}
+	if !found {
+		t.Errorf("Expected a %q span", "ga.Evolve")
+	}
+}
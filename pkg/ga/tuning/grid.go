@@ -0,0 +1,112 @@
+// Package tuning provides utilities for tuning the parameters of genetic algorithms, such as
+// searching a grid of parameter values for the best-performing configuration.
+package tuning
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+	"github.com/Okabe-Junya/gago/pkg/ga/experiment"
+)
+
+// GridSearch evaluates every combination of the parameter values in ParamGrid.
+//
+// ParamGrid maps each parameter name, such as "MutationRate", to the values to try. For each
+// combination, GAFactory must return a fresh, fully configured GA using the parameter values
+// it is passed. Each GA is initialized with PopulationSize individuals. At most Workers runs
+// of a configuration execute concurrently; if Workers is not positive, runtime.NumCPU() is used.
+type GridSearch struct {
+	ParamGrid      map[string][]interface{}
+	GAFactory      func(params map[string]interface{}) *ga.GA
+	PopulationSize int
+	Workers        int
+}
+
+// ConfigResult holds the results of the runs of one configuration of a GridSearch. The
+// standard deviation is a population standard deviation.
+type ConfigResult struct {
+	Params            map[string]interface{}
+	MeanBestFitness   float64
+	StdDevBestFitness float64
+}
+
+// GridSearchResult holds the results of a GridSearch.
+//
+// BestConfig is the configuration with the highest mean best fitness, or the lowest if the GA
+// returned by GAFactory for it minimizes, and Configs holds the results of every
+// configuration, in the order they were tried: parameter names are sorted, and the values of
+// the last name vary fastest.
+type GridSearchResult struct {
+	BestConfig map[string]interface{}
+	Configs    []ConfigResult
+}
+
+// Run evaluates every configuration of the grid.
+//
+// Parameters:
+// - initFunc: a function creating the Genotype of a new individual.
+// - evalFunc: a function to evaluate a Genotype and return its Phenotype.
+// - runsPerConfig: the number of times each configuration is run.
+//
+// Returns:
+// - The results of the search.
+// - An error if the GridSearch is misconfigured or a run fails to initialize or to evolve.
+func (s *GridSearch) Run(initFunc func() *ga.Genotype, evalFunc func(*ga.Genotype) *ga.Phenotype, runsPerConfig int) (*GridSearchResult, error) {
+	if s.GAFactory == nil {
+		return nil, errors.New("tuning: GAFactory must be set")
+	}
+
+	result := &GridSearchResult{}
+	var bestMean float64
+	for _, params := range s.combinations() {
+		minimize := s.GAFactory(params).Minimize
+		m := &experiment.MultiRun{
+			Runs:           runsPerConfig,
+			Workers:        s.Workers,
+			PopulationSize: s.PopulationSize,
+			NewGA:          func() *ga.GA { return s.GAFactory(params) },
+			InitFunc:       initFunc,
+			EvalFunc:       evalFunc,
+		}
+		runs, err := m.Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		config := ConfigResult{Params: params, MeanBestFitness: runs.MeanBestFitness, StdDevBestFitness: runs.StdDevBestFitness}
+		if result.BestConfig == nil || (minimize && config.MeanBestFitness < bestMean) || (!minimize && config.MeanBestFitness > bestMean) {
+			result.BestConfig, bestMean = params, config.MeanBestFitness
+		}
+		result.Configs = append(result.Configs, config)
+	}
+	return result, nil
+}
+
+// combinations returns the Cartesian product of the parameter values of the grid, with the
+// parameter names sorted and the values of the last name varying fastest. A grid without
+// parameters has a single, empty combination.
+func (s *GridSearch) combinations() []map[string]interface{} {
+	names := make([]string, 0, len(s.ParamGrid))
+	for name := range s.ParamGrid {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	combinations := []map[string]interface{}{{}}
+	for _, name := range names {
+		var next []map[string]interface{}
+		for _, combination := range combinations {
+			for _, value := range s.ParamGrid[name] {
+				params := make(map[string]interface{}, len(combination)+1)
+				for k, v := range combination {
+					params[k] = v
+				}
+				params[name] = value
+				next = append(next, params)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
@@ -0,0 +1,120 @@
+package tuning
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Okabe-Junya/gago/pkg/ga"
+)
+
+// oneMax returns the number of ones in the genome as the fitness.
+func oneMax(genotype *ga.Genotype) *ga.Phenotype {
+	fitness := 0.0
+	for _, gene := range genotype.Genome {
+		fitness += float64(gene)
+	}
+	return &ga.Phenotype{Fitness: fitness}
+}
+
+func TestGridSearch(t *testing.T) {
+	s := &GridSearch{
+		ParamGrid: map[string][]interface{}{
+			"MutationRate":   {0.0, 0.02},
+			"TournamentSize": {1, 4},
+		},
+		GAFactory: func(params map[string]interface{}) *ga.GA {
+			tournamentSize := params["TournamentSize"].(int)
+			return &ga.GA{
+				Selection: func(population []*ga.Individual) []*ga.Individual {
+					return ga.TournamentSelection(population, tournamentSize)
+				},
+				Crossover:     ga.UniformCrossover,
+				Mutation:      ga.BitFlipMutation,
+				CrossoverRate: 0.7,
+				MutationRate:  params["MutationRate"].(float64),
+				Generations:   20,
+			}
+		},
+		PopulationSize: 20,
+	}
+
+	result, err := s.Run(func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(32, 32) }, oneMax, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	expected := []map[string]interface{}{
+		{"MutationRate": 0.0, "TournamentSize": 1},
+		{"MutationRate": 0.0, "TournamentSize": 4},
+		{"MutationRate": 0.02, "TournamentSize": 1},
+		{"MutationRate": 0.02, "TournamentSize": 4},
+	}
+	if len(result.Configs) != len(expected) {
+		t.Fatalf("Expected %d configurations, but got %d", len(expected), len(result.Configs))
+	}
+	var best ConfigResult
+	for i, config := range result.Configs {
+		if !reflect.DeepEqual(config.Params, expected[i]) {
+			t.Errorf("Expected configuration %d to be %v, but got %v", i, expected[i], config.Params)
+		}
+		if reflect.DeepEqual(config.Params, result.BestConfig) {
+			best = config
+		}
+	}
+	for _, config := range result.Configs {
+		if config.MeanBestFitness > best.MeanBestFitness {
+			t.Errorf("Expected the best configuration %v to have the highest mean fitness %f, but %v has %f", result.BestConfig, best.MeanBestFitness, config.Params, config.MeanBestFitness)
+		}
+	}
+}
+
+func TestGridSearchErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		s    *GridSearch
+	}{
+		{name: "missing factory", s: &GridSearch{PopulationSize: 10}},
+		{name: "missing population size", s: &GridSearch{GAFactory: func(map[string]interface{}) *ga.GA { return &ga.GA{} }}},
+	}
+
+	for _, tc := range cases {
+		if _, err := tc.s.Run(func() *ga.Genotype { return ga.MustNewGenotype(4) }, oneMax, 1); err == nil {
+			t.Errorf("%s: Expected an error, but got nil", tc.name)
+		}
+	}
+}
+
+func TestGridSearchMinimize(t *testing.T) {
+	s := &GridSearch{
+		ParamGrid: map[string][]interface{}{"MutationRate": {0.0, 0.02, 0.1}},
+		GAFactory: func(params map[string]interface{}) *ga.GA {
+			return &ga.GA{
+				Selection:     func(population []*ga.Individual) []*ga.Individual { return ga.TournamentSelection(population, 3) },
+				Crossover:     ga.UniformCrossover,
+				Mutation:      ga.BitFlipMutation,
+				CrossoverRate: 0.7,
+				MutationRate:  params["MutationRate"].(float64),
+				Generations:   20,
+				Minimize:      true,
+			}
+		},
+		PopulationSize: 20,
+	}
+
+	result, err := s.Run(func() *ga.Genotype { return ga.MustVariableLengthBinaryGenotype(32, 32) }, oneMax, 4)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	var best ConfigResult
+	for _, config := range result.Configs {
+		if reflect.DeepEqual(config.Params, result.BestConfig) {
+			best = config
+		}
+	}
+	for _, config := range result.Configs {
+		if config.MeanBestFitness < best.MeanBestFitness {
+			t.Errorf("Expected the best configuration %v to have the lowest mean fitness %f, but %v has %f", result.BestConfig, best.MeanBestFitness, config.Params, config.MeanBestFitness)
+		}
+	}
+}